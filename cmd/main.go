@@ -6,9 +6,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
+	"github.com/bungysheep/catalogue-api/pkg/configs"
 	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
 	"github.com/bungysheep/catalogue-api/pkg/protocols/rest"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/lifecycleservice"
 	_ "github.com/lib/pq"
 )
 
@@ -25,6 +28,11 @@ func startUp() error {
 		return err
 	}
 
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+
+	log.Printf("Starting product lifecycle scheduler...\n")
+	go lifecycleservice.NewLifecycleService().Schedule(schedulerCtx, configs.LIFECYCLEINTERVAL*time.Second)
+
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 
@@ -32,6 +40,9 @@ func startUp() error {
 		for range c {
 			ctx := context.TODO()
 
+			log.Printf("Stopping product lifecycle scheduler...\n")
+			stopScheduler()
+
 			log.Printf("Closing database connection...\n")
 			database.DbConnection.Close()
 
@@ -6,4 +6,7 @@ type Key int
 const (
 	// ClaimToken key
 	ClaimToken Key = iota
+
+	// CorrelationID key
+	CorrelationID
 )
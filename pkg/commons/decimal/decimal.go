@@ -0,0 +1,239 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal type, an exact base-10 number held as unscaled integer and scale
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+var ten = big.NewInt(10)
+
+// New - Creates decimal of unscaled * 10^-scale
+func New(unscaled int64, scale int) Decimal {
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// Parse - Parses decimal from its text representation, e.g. "-12.50"
+func Parse(value string) (Decimal, error) {
+	text := strings.TrimSpace(value)
+
+	sign := ""
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		sign, text = text[:1], text[1:]
+	}
+
+	intPart, fracPart := text, ""
+	if idx := strings.Index(text, "."); idx >= 0 {
+		intPart, fracPart = text[:idx], text[idx+1:]
+	}
+
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("'%s' is not a decimal", value)
+	}
+
+	unscaled, ok := new(big.Int).SetString(sign+intPart+fracPart, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("'%s' is not a decimal", value)
+	}
+
+	return Decimal{unscaled: unscaled, scale: len(fracPart)}, nil
+}
+
+// FromFloat - Creates decimal from the shortest text representation of float
+func FromFloat(value float64) Decimal {
+	d, _ := Parse(strconv.FormatFloat(value, 'f', -1, 64))
+	return d
+}
+
+// MustParse - Parses decimal, panics when invalid
+func MustParse(value string) Decimal {
+	d, err := Parse(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (d Decimal) value() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// Scale - Returns number of digits after decimal point
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign - Returns -1, 0 or 1
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero - Whether zero
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Rescale - Returns decimal with the given scale, rounding half away from zero when digits are dropped
+func (d Decimal) Rescale(scale int) Decimal {
+	if scale >= d.scale {
+		factor := new(big.Int).Exp(ten, big.NewInt(int64(scale-d.scale)), nil)
+		return Decimal{unscaled: new(big.Int).Mul(d.value(), factor), scale: scale}
+	}
+
+	factor := new(big.Int).Exp(ten, big.NewInt(int64(d.scale-scale)), nil)
+	quo, rem := new(big.Int).QuoRem(d.value(), factor, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(factor) >= 0 {
+		quo.Add(quo, big.NewInt(int64(d.Sign())))
+	}
+
+	return Decimal{unscaled: quo, scale: scale}
+}
+
+// Normalize - Returns decimal without trailing fractional zeros
+func (d Decimal) Normalize() Decimal {
+	unscaled := new(big.Int).Set(d.value())
+	scale := d.scale
+
+	rem := new(big.Int)
+	for scale > 0 {
+		quo, r := new(big.Int).QuoRem(unscaled, ten, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		unscaled = quo
+		scale--
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// Add - Returns d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return Decimal{unscaled: new(big.Int).Add(d.Rescale(scale).value(), other.Rescale(scale).value()), scale: scale}
+}
+
+// Sub - Returns d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return Decimal{unscaled: new(big.Int).Sub(d.Rescale(scale).value(), other.Rescale(scale).value()), scale: scale}
+}
+
+// Mul - Returns d * other
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.value(), other.value()), scale: d.scale + other.scale}
+}
+
+// IsMultipleOf - Whether d is an exact multiple of step, a zero step is never matched
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.IsZero() {
+		return false
+	}
+
+	scale := maxScale(d, step)
+	return new(big.Int).Rem(d.Rescale(scale).value(), step.Rescale(scale).value()).Sign() == 0
+}
+
+// Cmp - Compares d and other, returns -1, 0 or 1
+func (d Decimal) Cmp(other Decimal) int {
+	scale := maxScale(d, other)
+	return d.Rescale(scale).value().Cmp(other.Rescale(scale).value())
+}
+
+// Equal - Whether d and other are numerically equal
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func maxScale(d Decimal, other Decimal) int {
+	if d.scale > other.scale {
+		return d.scale
+	}
+	return other.scale
+}
+
+// String - Returns text representation keeping its scale, e.g. "12.50"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.value()).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON - Encodes decimal as json string to keep its precision
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON - Decodes decimal from json string or number
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" || text == "" {
+		*d = Decimal{}
+		return nil
+	}
+
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+// Value - Implements driver.Valuer
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan - Implements sql.Scanner
+func (d *Decimal) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+
+	case []byte:
+		return d.UnmarshalJSON(value)
+
+	case string:
+		return d.UnmarshalJSON([]byte(value))
+
+	case int64:
+		*d = New(value, 0)
+		return nil
+
+	}
+
+	return fmt.Errorf("Unsupported decimal source type %T", src)
+}
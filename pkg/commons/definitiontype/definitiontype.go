@@ -12,8 +12,29 @@ const (
 
 	// Date definition type
 	Date
+
+	// Boolean definition type
+	Boolean
+
+	// Integer definition type
+	Integer
+
+	// Decimal definition type, fixed precision
+	Decimal
+
+	// URL definition type
+	URL
+
+	// Email definition type
+	Email
+
+	// Picklist definition type, single option
+	Picklist
+
+	// MultiSelect definition type, multiple options
+	MultiSelect
 )
 
 func (dt DefinitionType) String() string {
-	return [...]string{"A", "N", "D"}[dt]
+	return [...]string{"A", "N", "D", "B", "I", "F", "U", "E", "P", "M"}[dt]
 }
@@ -0,0 +1,22 @@
+package dimension
+
+// Dimension type, physical quantity measured by a unit of measure
+type Dimension int
+
+const (
+	// Count dimension
+	Count Dimension = iota
+
+	// Mass dimension
+	Mass
+
+	// Length dimension
+	Length
+
+	// Volume dimension
+	Volume
+)
+
+func (d Dimension) String() string {
+	return [...]string{"C", "M", "L", "V"}[d]
+}
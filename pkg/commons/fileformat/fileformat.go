@@ -0,0 +1,19 @@
+package fileformat
+
+// FileFormat type
+type FileFormat int
+
+const (
+	// CSV file format
+	CSV FileFormat = iota
+
+	// NDJSON file format
+	NDJSON
+
+	// XLSX file format
+	XLSX
+)
+
+func (ff FileFormat) String() string {
+	return [...]string{"CSV", "NDJSON", "XLSX"}[ff]
+}
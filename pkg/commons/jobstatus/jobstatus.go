@@ -0,0 +1,22 @@
+package jobstatus
+
+// JobStatus type
+type JobStatus int
+
+const (
+	// Pending job status
+	Pending JobStatus = iota
+
+	// Running job status
+	Running
+
+	// Completed job status
+	Completed
+
+	// Failed job status
+	Failed
+)
+
+func (js JobStatus) String() string {
+	return [...]string{"P", "R", "C", "F"}[js]
+}
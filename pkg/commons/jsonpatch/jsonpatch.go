@@ -0,0 +1,425 @@
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MergePatchContentType - RFC 7396 merge patch content type
+	MergePatchContentType = "application/merge-patch+json"
+
+	// JSONPatchContentType - RFC 6902 json patch content type
+	JSONPatchContentType = "application/json-patch+json"
+)
+
+var (
+	// ErrUnsupportedContentType - Patch content type is neither merge patch nor json patch
+	ErrUnsupportedContentType = errors.New("Unsupported patch content type")
+)
+
+// Operation type
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply - Applies patch to document based on patch content type
+func Apply(contentType string, doc []byte, patch []byte) ([]byte, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedContentType
+	}
+
+	switch mediaType {
+	case MergePatchContentType:
+		return MergePatch(doc, patch)
+
+	case JSONPatchContentType:
+		return ApplyPatch(doc, patch)
+
+	}
+
+	return nil, ErrUnsupportedContentType
+}
+
+// MergePatch - Applies RFC 7396 merge patch to document
+func MergePatch(doc []byte, patch []byte) ([]byte, error) {
+	docValue, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid document, error: %v", err)
+	}
+
+	patchValue, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid merge patch, error: %v", err)
+	}
+
+	return json.Marshal(mergeValue(docValue, patchValue))
+}
+
+// ApplyPatch - Applies RFC 6902 json patch to document
+func ApplyPatch(doc []byte, patch []byte) ([]byte, error) {
+	docValue, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid document, error: %v", err)
+	}
+
+	var operations []Operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("Invalid json patch, error: %v", err)
+	}
+
+	for i, operation := range operations {
+		docValue, err = applyOperation(docValue, operation)
+		if err != nil {
+			return nil, fmt.Errorf("Failed applying operation %d '%s %s', error: %v", i, operation.Op, operation.Path, err)
+		}
+	}
+
+	return json.Marshal(docValue)
+}
+
+func decode(data []byte) (interface{}, error) {
+	var value interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func mergeValue(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+func applyOperation(doc interface{}, operation Operation) (interface{}, error) {
+	switch operation.Op {
+	case "add":
+		value, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, operation.Path, value)
+
+	case "remove":
+		doc, _, err := remove(doc, operation.Path)
+		return doc, err
+
+	case "replace":
+		value, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		doc, _, err = remove(doc, operation.Path)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, operation.Path, value)
+
+	case "move":
+		if strings.HasPrefix(operation.Path, operation.From+"/") {
+			return nil, fmt.Errorf("can not move a value into its own child")
+		}
+		doc, value, err := remove(doc, operation.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, operation.Path, value)
+
+	case "copy":
+		value, err := get(doc, operation.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, operation.Path, deepCopy(value))
+
+	case "test":
+		expected, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := get(doc, operation.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !isEqual(expected, actual) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return doc, nil
+
+	}
+
+	return nil, fmt.Errorf("unsupported operation")
+}
+
+func (operation Operation) value() (interface{}, error) {
+	if len(operation.Value) == 0 {
+		return nil, fmt.Errorf("missing value")
+	}
+	return decode(operation.Value)
+}
+
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid pointer '%s'", path)
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+
+	return tokens, nil
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index '%s'", token)
+	}
+
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if index > max {
+		return 0, fmt.Errorf("array index '%s' out of bounds", token)
+	}
+
+	return index, nil
+}
+
+func get(doc interface{}, path string) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", path)
+			}
+			current = value
+
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+
+		default:
+			return nil, fmt.Errorf("path '%s' does not exist", path)
+		}
+	}
+
+	return current, nil
+}
+
+// update walks to the parent of the pointer target and replaces the parent by the result of fn
+func update(doc interface{}, tokens []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[tokens[0]]
+		if !ok {
+			return nil, fmt.Errorf("path segment '%s' does not exist", tokens[0])
+		}
+		newChild, err := update(child, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[tokens[0]] = newChild
+		return node, nil
+
+	case []interface{}:
+		index, err := arrayIndex(tokens[0], len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		newChild, err := update(node[index], tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = newChild
+		return node, nil
+
+	}
+
+	return nil, fmt.Errorf("path segment '%s' does not exist", tokens[0])
+}
+
+func add(doc interface{}, path string, value interface{}) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	return update(doc, tokens, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+
+		}
+
+		return nil, fmt.Errorf("path '%s' does not exist", path)
+	})
+}
+
+func remove(doc interface{}, path string) (interface{}, interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, doc, nil
+	}
+
+	var removed interface{}
+	doc, err = update(doc, tokens, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", path)
+			}
+			removed = value
+			delete(node, token)
+			return node, nil
+
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[index]
+			return append(node[:index], node[index+1:]...), nil
+
+		}
+
+		return nil, fmt.Errorf("path '%s' does not exist", path)
+	})
+
+	return doc, removed, err
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(node))
+		for key, item := range node {
+			result[key] = deepCopy(item)
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(node))
+		for i, item := range node {
+			result[i] = deepCopy(item)
+		}
+		return result
+
+	}
+
+	return value
+}
+
+func isEqual(a interface{}, b interface{}) bool {
+	aNumber, aOk := a.(json.Number)
+	bNumber, bOk := b.(json.Number)
+	if aOk && bOk {
+		aFloat, _ := aNumber.Float64()
+		bFloat, _ := bNumber.Float64()
+		return aFloat == bFloat
+	}
+
+	switch aNode := a.(type) {
+	case map[string]interface{}:
+		bNode, ok := b.(map[string]interface{})
+		if !ok || len(aNode) != len(bNode) {
+			return false
+		}
+		for key, item := range aNode {
+			if !isEqual(item, bNode[key]) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		bNode, ok := b.([]interface{})
+		if !ok || len(aNode) != len(bNode) {
+			return false
+		}
+		for i := range aNode {
+			if !isEqual(aNode[i], bNode[i]) {
+				return false
+			}
+		}
+		return true
+
+	}
+
+	return reflect.DeepEqual(a, b)
+}
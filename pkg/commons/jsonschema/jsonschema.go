@@ -0,0 +1,188 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+)
+
+const (
+	// Draft - JSON Schema draft 2020-12 meta schema
+	Draft = "https://json-schema.org/draft/2020-12/schema"
+
+	// ContentType - JSON Schema content type
+	ContentType = "application/schema+json"
+)
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Schema type, only the keywords used to describe models
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              interface{}        `json:"minimum,omitempty"`
+	Maximum              interface{}        `json:"maximum,omitempty"`
+	MultipleOf           interface{}        `json:"multipleOf,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	Contains             *Schema            `json:"contains,omitempty"`
+	MinContains          *int               `json:"minContains,omitempty"`
+	MaxContains          *int               `json:"maxContains,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+	If                   *Schema            `json:"if,omitempty"`
+	Then                 *Schema            `json:"then,omitempty"`
+}
+
+// NewSchema - Creates schema of the given type
+func NewSchema(schemaType string) *Schema {
+	return &Schema{Type: schemaType}
+}
+
+// Int - Returns pointer to int keyword value
+func Int(value int) *int {
+	return &value
+}
+
+// FromModel - Creates object schema from model struct tags, mirroring validation rules
+func FromModel(model interface{}) *Schema {
+	modelType := reflect.TypeOf(model)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+
+	schema := NewSchema("object")
+	schema.Properties = make(map[string]*Schema)
+
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous || name == "" || name == "-" {
+			continue
+		}
+
+		property := fromType(field.Type)
+		if property == nil {
+			continue
+		}
+
+		schema.Properties[name] = property
+	}
+
+	for _, rule := range validation.Rules(modelType) {
+		property := schema.Properties[rule.JSONName]
+		if property == nil {
+			continue
+		}
+
+		if rule.Mandatory {
+			schema.Required = append(schema.Required, rule.JSONName)
+
+			switch property.Type {
+			case "string":
+				property.MinLength = Int(1)
+			case "array":
+				property.MinItems = Int(1)
+			case "integer", "number":
+				property.Not = &Schema{Const: 0}
+			}
+		}
+
+		// Validation skips length, pattern and enum of empty strings
+		rules := property
+		if property.Type == "string" && !rule.Mandatory && (rule.MinLength != nil || rule.Pattern != nil || rule.Values != nil) {
+			rules = &Schema{}
+			schema.Properties[rule.JSONName] = &Schema{Type: "string", AnyOf: []*Schema{{Const: ""}, rules}}
+		}
+
+		if rule.MinLength != nil && (rules.MinLength == nil || *rule.MinLength > *rules.MinLength) {
+			rules.MinLength = Int(*rule.MinLength)
+		}
+
+		if rule.MaxLength != nil {
+			schema.Properties[rule.JSONName].MaxLength = Int(*rule.MaxLength)
+		}
+
+		if rule.Pattern != nil {
+			rules.Pattern = rule.Pattern.String()
+		}
+
+		for _, value := range rule.Values {
+			if property.Type == "string" {
+				rules.Enum = append(rules.Enum, value)
+			} else {
+				rules.Enum = append(rules.Enum, json.Number(value))
+			}
+		}
+
+		if rule.Min != nil {
+			property.Minimum = *rule.Min
+		}
+
+		if rule.Max != nil {
+			property.Maximum = *rule.Max
+		}
+	}
+
+	return schema
+}
+
+// fromType - Returns schema of go type, nil for types which are not described
+func fromType(fieldType reflect.Type) *Schema {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.PkgPath() == "time" && fieldType.Name() == "Time" {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	if fieldType.Implements(marshalerType) {
+		return nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return NewSchema("string")
+
+	case reflect.Bool:
+		return NewSchema("boolean")
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewSchema("integer")
+
+	case reflect.Float32, reflect.Float64:
+		return NewSchema("number")
+
+	case reflect.Slice:
+		items := fromType(fieldType.Elem())
+		if items == nil {
+			return nil
+		}
+		return &Schema{Type: "array", Items: items}
+
+	case reflect.Struct:
+		return FromModel(reflect.New(fieldType).Interface())
+
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package lifecycle
+
+import "strings"
+
+// Lifecycle type, status of a product along its life
+type Lifecycle int
+
+const (
+	// Draft lifecycle, product is being prepared and not yet sold
+	Draft Lifecycle = iota
+
+	// Active lifecycle
+	Active
+
+	// Discontinued lifecycle, product is sold until stock runs out
+	Discontinued
+
+	// EndOfLife lifecycle, product is no longer sold
+	EndOfLife
+)
+
+func (l Lifecycle) String() string {
+	return [...]string{"D", "A", "X", "E"}[l]
+}
+
+// legacyInactive - Status of inactive products stored before products followed a lifecycle
+const legacyInactive = "I"
+
+// transitions - Statuses a product may change to from each status, end of life is final
+var transitions = map[string][]Lifecycle{
+	Draft.String():        {Active, EndOfLife},
+	Active.String():       {Discontinued},
+	Discontinued.String(): {Active, EndOfLife},
+	EndOfLife.String():    {},
+}
+
+// IsTransitionAllowed - Whether product may change from status to other status, keeping the same status is allowed
+func IsTransitionAllowed(from string, to string) bool {
+	if from == to {
+		return true
+	}
+
+	for _, allowed := range transitions[from] {
+		if allowed.String() == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsInitial - Whether product may be created with status
+func IsInitial(status string) bool {
+	return status == Draft.String() || status == Active.String()
+}
+
+// FromStored - Returns lifecycle status of stored status, legacy inactive status is read as discontinued
+func FromStored(status string) string {
+	if strings.ToUpper(status) == legacyInactive {
+		return Discontinued.String()
+	}
+
+	return status
+}
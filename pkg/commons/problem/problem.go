@@ -0,0 +1,159 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	// ContentType - Problem details content type, RFC 7807
+	ContentType = "application/problem+json"
+
+	// CorrelationIDHeader - Header carrying correlation id of request
+	CorrelationIDHeader = "X-Correlation-ID"
+
+	typePrefix = "/problems/"
+)
+
+// Code type, stable machine readable error code
+type Code string
+
+const (
+	// BadRequest code
+	BadRequest Code = "bad_request"
+
+	// ValidationFailed code, errors lists violations per field
+	ValidationFailed Code = "validation_failed"
+
+	// Unauthorized code
+	Unauthorized Code = "unauthorized"
+
+	// Forbidden code, user is authenticated but not allowed to perform request
+	Forbidden Code = "forbidden"
+
+	// NotFound code
+	NotFound Code = "not_found"
+
+	// Conflict code
+	Conflict Code = "conflict"
+
+	// Duplicate code, a resource with the same key already exists
+	Duplicate Code = "duplicate"
+
+	// ConcurrentModification code, resource has been modified by another user
+	ConcurrentModification Code = "concurrent_modification"
+
+	// SerializationFailure code, request conflicted with a concurrent transaction and can be retried
+	SerializationFailure Code = "serialization_failure"
+
+	// PreconditionFailed code
+	PreconditionFailed Code = "precondition_failed"
+
+	// UnsupportedMediaType code
+	UnsupportedMediaType Code = "unsupported_media_type"
+
+	// ReferenceViolation code, resource refers to, or is referred by, another resource
+	ReferenceViolation Code = "reference_violation"
+
+	// ConstraintViolation code, data is rejected by a database constraint
+	ConstraintViolation Code = "constraint_violation"
+
+	// UomNotConvertible code, quantity can not be converted between units of measure
+	UomNotConvertible Code = "uom_not_convertible"
+
+	// PriceNotFound code, no price of product applies to the requested quantity and date
+	PriceNotFound Code = "price_not_found"
+
+	// Internal code
+	Internal Code = "internal_error"
+)
+
+// Problem type, RFC 7807 problem details keeping success, message and data of the former envelope
+type Problem struct {
+	Type          string                `json:"type"`
+	Title         string                `json:"title"`
+	Status        int                   `json:"status"`
+	Detail        string                `json:"detail,omitempty"`
+	Code          Code                  `json:"code"`
+	CorrelationID string                `json:"correlation_id,omitempty"`
+	Errors        validation.Violations `json:"errors,omitempty"`
+	Success       bool                  `json:"success"`
+	Message       string                `json:"message"`
+	Data          interface{}           `json:"data"`
+}
+
+// NewProblem - Creates problem details of status and code
+func NewProblem(statusCode int, code Code, detail string) *Problem {
+	return &Problem{
+		Type:    typePrefix + string(code),
+		Title:   http.StatusText(statusCode),
+		Status:  statusCode,
+		Detail:  detail,
+		Code:    code,
+		Message: detail,
+	}
+}
+
+// CodeOf - Returns default code of http status
+func CodeOf(statusCode int) Code {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return BadRequest
+	case http.StatusUnauthorized:
+		return Unauthorized
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusPreconditionFailed:
+		return PreconditionFailed
+	case http.StatusUnsupportedMediaType:
+		return UnsupportedMediaType
+	case http.StatusUnprocessableEntity:
+		return ConstraintViolation
+	}
+	return Internal
+}
+
+// FromError - Creates problem details of error, database errors are mapped to their status
+func FromError(err error) *Problem {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return NewProblem(http.StatusInternalServerError, Internal, "An unexpected error occurred.")
+	}
+
+	switch pqErr.Code.Name() {
+	case "unique_violation":
+		return NewProblem(http.StatusConflict, Duplicate, "A resource with the same key already exists.")
+
+	case "foreign_key_violation":
+		return NewProblem(http.StatusUnprocessableEntity, ReferenceViolation, "Resource refers to, or is referred by, another resource.")
+
+	case "serialization_failure", "deadlock_detected":
+		return NewProblem(http.StatusConflict, SerializationFailure, "Request conflicted with a concurrent update, please retry.")
+
+	}
+
+	if pqErr.Code.Class() == "22" || pqErr.Code.Class() == "23" {
+		return NewProblem(http.StatusUnprocessableEntity, ConstraintViolation, "Data is rejected by a constraint.")
+	}
+
+	return NewProblem(http.StatusInternalServerError, Internal, "An unexpected error occurred.")
+}
+
+// Write - Writes problem details as http response
+func Write(w http.ResponseWriter, prob *Problem) {
+	if prob.CorrelationID == "" {
+		prob.CorrelationID = w.Header().Get(CorrelationIDHeader)
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(prob.Status)
+	json.NewEncoder(w).Encode(prob)
+}
@@ -0,0 +1,22 @@
+package relationtype
+
+// RelationType type, how related product relates to product
+type RelationType int
+
+const (
+	// Accessory relation type, related product is an accessory of product
+	Accessory RelationType = iota
+
+	// Substitute relation type, related product can be sold instead of product
+	Substitute
+
+	// Replacement relation type, related product replaces discontinued product
+	Replacement
+
+	// Bundle relation type, related product is a component of product in a quantity of its unit of measure
+	Bundle
+)
+
+func (rt RelationType) String() string {
+	return [...]string{"A", "S", "R", "B"}[rt]
+}
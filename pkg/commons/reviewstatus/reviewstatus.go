@@ -0,0 +1,19 @@
+package reviewstatus
+
+// ReviewStatus type
+type ReviewStatus int
+
+const (
+	// Pending review status, change awaits a reviewer
+	Pending ReviewStatus = iota
+
+	// Approved review status, change has been applied
+	Approved
+
+	// Rejected review status
+	Rejected
+)
+
+func (rs ReviewStatus) String() string {
+	return [...]string{"P", "A", "R"}[rs]
+}
@@ -0,0 +1,332 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Violation type
+type Violation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Violations type
+type Violations []*Violation
+
+// Add - Adds violation at json pointer path
+func (violations *Violations) Add(path string, message string) {
+	*violations = append(*violations, &Violation{Path: path, Message: message})
+}
+
+// Append - Adds violations of nested model, prefixing their path
+func (violations *Violations) Append(prefix string, others Violations) {
+	for _, other := range others {
+		violations.Add(prefix+other.Path, other.Message)
+	}
+}
+
+// IsValid - Whether there is no violation
+func (violations Violations) IsValid() bool {
+	return len(violations) == 0
+}
+
+// Error - Returns message of first violation
+func (violations Violations) Error() string {
+	if len(violations) == 0 {
+		return ""
+	}
+	return violations[0].Message
+}
+
+// Pointer - Returns json pointer of reference tokens, escaped as per RFC 6901
+func Pointer(tokens ...interface{}) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		text := fmt.Sprint(token)
+		text = strings.Replace(text, "~", "~0", -1)
+		text = strings.Replace(text, "/", "~1", -1)
+		pointer.WriteString("/")
+		pointer.WriteString(text)
+	}
+	return pointer.String()
+}
+
+// Rule type, constraints of a struct field read from its tags
+type Rule struct {
+	Index     int
+	Name      string
+	JSONName  string
+	Mandatory bool
+	MinLength *int
+	MaxLength *int
+	Min       *float64
+	Max       *float64
+	Pattern   *regexp.Regexp
+	Values    []string
+	Dive      bool
+}
+
+var rulesCache sync.Map
+
+// Rules - Returns constraints of struct type, parsed once per type
+func Rules(modelType reflect.Type) []*Rule {
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+
+	if rules, ok := rulesCache.Load(modelType); ok {
+		return rules.([]*Rule)
+	}
+
+	rules := make([]*Rule, 0)
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Anonymous {
+			continue
+		}
+
+		rule, err := parseRule(field)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid validation tag of %s.%s, error: %v", modelType.Name(), field.Name, err))
+		}
+
+		if rule != nil {
+			rule.Index = i
+			rules = append(rules, rule)
+		}
+	}
+
+	rulesCache.Store(modelType, rules)
+	return rules
+}
+
+func parseRule(field reflect.StructField) (*Rule, error) {
+	rule := &Rule{Name: field.Name, JSONName: strings.Split(field.Tag.Get("json"), ",")[0]}
+	if rule.JSONName == "" {
+		rule.JSONName = field.Name
+	}
+
+	hasRule := false
+	var err error
+
+	if tag, ok := field.Tag.Lookup("mandatory"); ok {
+		if rule.Mandatory, err = strconv.ParseBool(tag); err != nil {
+			return nil, fmt.Errorf("mandatory '%s' is not a boolean", tag)
+		}
+		if field.Type.Kind() == reflect.Bool {
+			return nil, fmt.Errorf("mandatory is not supported by boolean")
+		}
+		hasRule = true
+	}
+
+	for _, name := range []string{"min_length", "max_length"} {
+		if tag, ok := field.Tag.Lookup(name); ok {
+			if field.Type.Kind() != reflect.String {
+				return nil, fmt.Errorf("%s is only supported by string", name)
+			}
+
+			length, err := strconv.Atoi(tag)
+			if err != nil || length < 0 {
+				return nil, fmt.Errorf("%s '%s' is not a length", name, tag)
+			}
+
+			if name == "min_length" {
+				rule.MinLength = &length
+			} else {
+				rule.MaxLength = &length
+			}
+			hasRule = true
+		}
+	}
+
+	for _, name := range []string{"min", "max"} {
+		if tag, ok := field.Tag.Lookup(name); ok {
+			if !isNumber(field.Type.Kind()) {
+				return nil, fmt.Errorf("%s is only supported by number", name)
+			}
+
+			value, err := strconv.ParseFloat(tag, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s '%s' is not a number", name, tag)
+			}
+
+			if name == "min" {
+				rule.Min = &value
+			} else {
+				rule.Max = &value
+			}
+			hasRule = true
+		}
+	}
+
+	if tag, ok := field.Tag.Lookup("pattern"); ok {
+		if field.Type.Kind() != reflect.String {
+			return nil, fmt.Errorf("pattern is only supported by string")
+		}
+
+		if rule.Pattern, err = regexp.Compile(tag); err != nil {
+			return nil, fmt.Errorf("pattern '%s' is not a regular expression", tag)
+		}
+		hasRule = true
+	}
+
+	if tag, ok := field.Tag.Lookup("valid_value"); ok {
+		if tag == "" {
+			return nil, fmt.Errorf("valid_value is empty")
+		}
+
+		rule.Values = strings.Split(tag, ",")
+		hasRule = true
+	}
+
+	if tag, ok := field.Tag.Lookup("dive"); ok {
+		if rule.Dive, err = strconv.ParseBool(tag); err != nil {
+			return nil, fmt.Errorf("dive '%s' is not a boolean", tag)
+		}
+
+		if elemType := elemType(field.Type); elemType.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("dive is only supported by struct or slice of struct")
+		}
+		hasRule = true
+	}
+
+	if !hasRule {
+		return nil, nil
+	}
+
+	return rule, nil
+}
+
+// Validate - Validate model against its field tags, returning all violations
+func Validate(model interface{}) Violations {
+	violations := make(Violations, 0)
+
+	modelValue := reflect.ValueOf(model)
+	for modelValue.Kind() == reflect.Ptr {
+		if modelValue.IsNil() {
+			return violations
+		}
+		modelValue = modelValue.Elem()
+	}
+
+	for _, rule := range Rules(modelValue.Type()) {
+		value := modelValue.Field(rule.Index)
+		path := Pointer(rule.JSONName)
+
+		if rule.Mandatory && value.IsZero() {
+			violations.Add(path, fmt.Sprintf("%s must be specified", rule.Name))
+			continue
+		}
+
+		switch {
+		case value.Kind() == reflect.String:
+			validateString(&violations, path, rule, value.String())
+
+		case isNumber(value.Kind()):
+			validateNumber(&violations, path, rule, value)
+
+		}
+
+		if rule.Dive {
+			validateNested(&violations, path, value)
+		}
+	}
+
+	return violations
+}
+
+func validateString(violations *Violations, path string, rule *Rule, value string) {
+	length := utf8.RuneCountInString(value)
+
+	if rule.MinLength != nil && value != "" && length < *rule.MinLength {
+		violations.Add(path, fmt.Sprintf("%s can not less than %d chars", rule.Name, *rule.MinLength))
+	}
+
+	if rule.MaxLength != nil && length > *rule.MaxLength {
+		violations.Add(path, fmt.Sprintf("%s can not more than %d chars", rule.Name, *rule.MaxLength))
+	}
+
+	if rule.Pattern != nil && value != "" && !rule.Pattern.MatchString(value) {
+		violations.Add(path, fmt.Sprintf("%s does not match pattern '%s'", rule.Name, rule.Pattern.String()))
+	}
+
+	if rule.Values != nil && value != "" && !isValidValue(value, rule.Values) {
+		violations.Add(path, fmt.Sprintf("%s '%s' is not valid", rule.Name, value))
+	}
+}
+
+func validateNumber(violations *Violations, path string, rule *Rule, value reflect.Value) {
+	var number float64
+	var text string
+
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		number = value.Float()
+		text = strconv.FormatFloat(number, 'f', -1, 64)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number = float64(value.Uint())
+		text = strconv.FormatUint(value.Uint(), 10)
+
+	default:
+		number = float64(value.Int())
+		text = strconv.FormatInt(value.Int(), 10)
+
+	}
+
+	if rule.Min != nil && number < *rule.Min {
+		violations.Add(path, fmt.Sprintf("%s can not be less than %s", rule.Name, strconv.FormatFloat(*rule.Min, 'f', -1, 64)))
+	}
+
+	if rule.Max != nil && number > *rule.Max {
+		violations.Add(path, fmt.Sprintf("%s can not be more than %s", rule.Name, strconv.FormatFloat(*rule.Max, 'f', -1, 64)))
+	}
+
+	if rule.Values != nil && !isValidValue(text, rule.Values) {
+		violations.Add(path, fmt.Sprintf("%s '%s' is not valid", rule.Name, text))
+	}
+}
+
+// validateNested - Validate struct, or each struct of slice, under path
+func validateNested(violations *Violations, path string, value reflect.Value) {
+	if value.Kind() != reflect.Slice {
+		violations.Append(path, Validate(value.Interface()))
+		return
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		violations.Append(path+Pointer(i), Validate(value.Index(i).Interface()))
+	}
+}
+
+func isValidValue(value string, values []string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func elemType(fieldType reflect.Type) reflect.Type {
+	for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType
+}
@@ -0,0 +1,143 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const (
+	// ContentType - Xlsx workbook content type
+	ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Writer type, streams rows of a single worksheet workbook
+type Writer struct {
+	zipWriter   *zip.Writer
+	sheetWriter *bufio.Writer
+	rowNo       int
+}
+
+// NewWriter - Creates xlsx writer with a single worksheet
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zipWriter := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", relsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, escape(sheetName))},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+	}
+
+	for _, part := range parts {
+		partWriter, err := zipWriter.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := io.WriteString(partWriter, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetPartWriter, err := zipWriter.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	sheetWriter := bufio.NewWriter(sheetPartWriter)
+	if _, err := sheetWriter.WriteString(sheetHeaderXML); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		zipWriter:   zipWriter,
+		sheetWriter: sheetWriter,
+	}, nil
+}
+
+// Write - Writes a row of inline string cells
+func (xw *Writer) Write(record []string) error {
+	xw.rowNo++
+
+	if _, err := fmt.Fprintf(xw.sheetWriter, `<row r="%d">`, xw.rowNo); err != nil {
+		return err
+	}
+
+	for i, value := range record {
+		if _, err := fmt.Fprintf(xw.sheetWriter, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, columnName(i), xw.rowNo, escape(value)); err != nil {
+			return err
+		}
+	}
+
+	_, err := xw.sheetWriter.WriteString(`</row>`)
+	return err
+}
+
+// Flush - Flushes buffered rows
+func (xw *Writer) Flush() error {
+	return xw.sheetWriter.Flush()
+}
+
+// Close - Completes worksheet and workbook
+func (xw *Writer) Close() error {
+	if _, err := xw.sheetWriter.WriteString(sheetFooterXML); err != nil {
+		return err
+	}
+
+	if err := xw.sheetWriter.Flush(); err != nil {
+		return err
+	}
+
+	return xw.zipWriter.Close()
+}
+
+// columnName converts zero based column index into spreadsheet column name, e.g. 0 -> A, 26 -> AA
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func escape(value string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(value))
+	return buffer.String()
+}
+
+const contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+const relsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+const sheetHeaderXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetFooterXML = `</sheetData></worksheet>`
@@ -30,4 +30,34 @@ const (
 
 	// TOKENSIGNKEY - Jwt token sign in key
 	TOKENSIGNKEY = "secret"
+
+	// IMPORTMAXSIZE - Maximum size of import file in bytes
+	IMPORTMAXSIZE = 32 << 20
+
+	// IMPORTPROGRESSINTERVAL - Number of rows processed between import progress updates
+	IMPORTPROGRESSINTERVAL = 100
+
+	// BACKFILLPROGRESSINTERVAL - Number of products processed between backfill progress updates
+	BACKFILLPROGRESSINTERVAL = 100
+
+	// TYPECHANGEMAXBLOCKERS - Maximum number of products reported as blocking a custom field type change
+	TYPECHANGEMAXBLOCKERS = 100
+
+	// EXPORTFLUSHINTERVAL - Number of rows written between export flushes
+	EXPORTFLUSHINTERVAL = 100
+
+	// STREAMFLUSHINTERVAL - Number of items written between streamed response flushes
+	STREAMFLUSHINTERVAL = 100
+
+	// MAXDECIMALPRECISION - Maximum number of decimal places of decimal custom fields
+	MAXDECIMALPRECISION = 10
+
+	// LIFECYCLEINTERVAL - Seconds between runs of the product lifecycle scheduler
+	LIFECYCLEINTERVAL = 60
+
+	// SCHEDULERUSER - User recorded as modifier of changes made by schedulers
+	SCHEDULERUSER = "SCHEDULER"
+
+	// MULTIVALUESEPARATOR - Separator of multi-select values in text representation
+	MULTIVALUESEPARATOR = "|"
 )
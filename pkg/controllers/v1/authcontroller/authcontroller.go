@@ -40,13 +40,11 @@ func (authCtl *AuthController) GetAll(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Retrieving all users.\n")
 
 	usrRepo := userrepository.NewUserRepository()
-	result, err := usrRepo.GetAll(r.Context())
-	if err != nil {
-		authCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-		return
-	}
-
-	authCtl.WriteResponse(w, http.StatusOK, true, result, "")
+	authCtl.WriteStream(w, r, func(write func(interface{}) error) error {
+		return usrRepo.ForEachAll(r.Context(), func(user *usermodel.User) error {
+			return write(user)
+		})
+	})
 }
 
 // SignIn - Sign in user and return access token
@@ -63,7 +61,7 @@ func (authCtl *AuthController) SignIn(w http.ResponseWriter, r *http.Request) {
 	usrRepo := userrepository.NewUserRepository()
 	result, err := usrRepo.GetByUsername(r.Context(), user.GetUsername())
 	if err != nil {
-		authCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		authCtl.WriteError(w, err)
 		return
 	}
 
@@ -119,9 +117,9 @@ func (authCtl *AuthController) Register(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	valid, message := newUsr.DoValidate()
-	if !valid {
-		authCtl.WriteResponse(w, http.StatusBadRequest, false, nil, message)
+	violations := newUsr.DoValidate()
+	if !violations.IsValid() {
+		authCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
 		return
 	}
 
@@ -140,7 +138,7 @@ func (authCtl *AuthController) Register(w http.ResponseWriter, r *http.Request)
 	usrRepo := userrepository.NewUserRepository()
 	nbrRows, err := usrRepo.Create(r.Context(), newUsr)
 	if err != nil {
-		authCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		authCtl.WriteError(w, err)
 		return
 	}
 
@@ -151,7 +149,7 @@ func (authCtl *AuthController) Register(w http.ResponseWriter, r *http.Request)
 
 	result, err := usrRepo.GetByUsername(r.Context(), newUsr.GetUsername())
 	if err != nil {
-		authCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		authCtl.WriteError(w, err)
 		return
 	}
 
@@ -0,0 +1,104 @@
+package backfillcontroller
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
+	backfilljobmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/backfilljob"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/backfilljobrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfielddefinitionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/backfillservice"
+	"github.com/gorilla/mux"
+)
+
+// BackfillController type
+type BackfillController struct {
+	basecontroller.BaseResource
+}
+
+// NewBackfillController - Creates backfill controller
+func NewBackfillController() *BackfillController {
+	return &BackfillController{}
+}
+
+// Create - Submit backfill job applying default value of custom field definition to existing products
+func (backfillCtl *BackfillController) Create(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+	fieldID, _ := strconv.ParseInt(params["field_id"], 10, 64)
+
+	log.Printf("Submitting Backfill Job for Custom Field '%v'.\n", fieldID)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	fieldDefRepo := customfielddefinitionrepository.NewCustomFieldDefinitionRepository()
+	fieldDef, err := fieldDefRepo.GetByID(r.Context(), fieldID)
+	if err != nil {
+		backfillCtl.WriteError(w, err)
+		return
+	}
+
+	if fieldDef == nil || fieldDef.GetCatalogueCode() != strings.ToUpper(clgCode) {
+		backfillCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Custom Field Definition does not exist.")
+		return
+	}
+
+	if !fieldDef.HasDefaultValue() {
+		backfillCtl.WriteResponse(w, http.StatusBadRequest, false, nil, fmt.Sprintf("Custom Field '%s' has no default value.", fieldDef.GetCaption()))
+		return
+	}
+
+	backfillSvc := backfillservice.NewBackfillService()
+	newJob, err := backfillSvc.Submit(r.Context(), fieldDef, authClaims.GetUsername())
+	if err != nil {
+		backfillCtl.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/v1/catalogues/%s/backfills/%d", fieldDef.GetCatalogueCode(), newJob.GetID()))
+	backfillCtl.WriteResponse(w, http.StatusAccepted, true, newJob, "Backfill Job has been submitted.")
+}
+
+// GetByCatalogue - Return all backfill jobs of catalogue
+func (backfillCtl *BackfillController) GetByCatalogue(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	log.Printf("Retrieving Backfill Jobs of Catalogue '%v'.\n", clgCode)
+
+	jobRepo := backfilljobrepository.NewBackfillJobRepository()
+	backfillCtl.WriteStream(w, r, func(write func(interface{}) error) error {
+		return jobRepo.ForEachByCatalogue(r.Context(), strings.ToUpper(clgCode), func(job *backfilljobmodel.BackfillJob) error {
+			return write(job)
+		})
+	})
+}
+
+// GetByID - Return backfill job progress
+func (backfillCtl *BackfillController) GetByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+	jobID, _ := strconv.ParseInt(params["job_id"], 10, 64)
+
+	log.Printf("Retrieving Backfill Job '%v'.\n", jobID)
+
+	jobRepo := backfilljobrepository.NewBackfillJobRepository()
+	result, err := jobRepo.GetByID(r.Context(), jobID)
+	if err != nil {
+		backfillCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil || result.GetCatalogueCode() != strings.ToUpper(clgCode) {
+		backfillCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Backfill Job does not exist.")
+		return
+	}
+
+	backfillCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
@@ -2,7 +2,21 @@ package basecontroller
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
 	"net/http"
+	"strings"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+)
+
+const (
+	// NDJSONContentType - Newline delimited json content type
+	NDJSONContentType = "application/x-ndjson"
 )
 
 // BaseResource type
@@ -14,8 +28,18 @@ func NewBaseController() *BaseResource {
 	return &BaseResource{}
 }
 
-// WriteResponse - Writes http response
+// WriteResponse - Writes http response, a failure is written as problem details
 func (resource *BaseResource) WriteResponse(w http.ResponseWriter, statusCode int, success bool, data interface{}, message string) {
+	if !success {
+		code := problem.CodeOf(statusCode)
+		if _, ok := data.(validation.Violations); ok {
+			code = problem.ValidationFailed
+		}
+
+		resource.WriteProblem(w, statusCode, code, data, message)
+		return
+	}
+
 	resp := map[string]interface{}{
 		"success": success,
 		"message": message,
@@ -25,3 +49,141 @@ func (resource *BaseResource) WriteResponse(w http.ResponseWriter, statusCode in
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(resp)
 }
+
+// WriteProblem - Writes failure as problem details with the given code
+func (resource *BaseResource) WriteProblem(w http.ResponseWriter, statusCode int, code problem.Code, data interface{}, message string) {
+	prob := problem.NewProblem(statusCode, code, message)
+	if violations, ok := data.(validation.Violations); ok {
+		prob.Errors = violations
+	}
+	prob.Data = data
+
+	problem.Write(w, prob)
+}
+
+// WriteError - Writes unexpected error as problem details, database errors are mapped to their status
+func (resource *BaseResource) WriteError(w http.ResponseWriter, err error) {
+	prob := problem.FromError(err)
+	prob.CorrelationID = w.Header().Get(problem.CorrelationIDHeader)
+
+	log.Printf("Failed processing request '%s', error: %v.\n", prob.CorrelationID, err)
+
+	problem.Write(w, prob)
+}
+
+// WriteETag - Writes entity tag header for the given version
+func (resource *BaseResource) WriteETag(w http.ResponseWriter, vers int64) {
+	w.Header().Set("ETag", fmt.Sprintf("\"%d\"", vers))
+}
+
+// HasIfMatch - Whether request has If-Match header
+func (resource *BaseResource) HasIfMatch(r *http.Request) bool {
+	return strings.TrimSpace(r.Header.Get("If-Match")) != ""
+}
+
+// IsIfMatchMet - Whether If-Match header matches the given version
+func (resource *BaseResource) IsIfMatchMet(r *http.Request, vers int64) bool {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+
+	etag := fmt.Sprintf("\"%d\"", vers)
+	for _, item := range strings.Split(ifMatch, ",") {
+		if strings.TrimSpace(item) == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsNDJSONAccepted - Whether request accepts newline delimited json
+func (resource *BaseResource) IsNDJSONAccepted(r *http.Request) bool {
+	for _, item := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err == nil && mediaType == NDJSONContentType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteStream - Writes http response while iterating items, as json envelope or newline delimited json
+func (resource *BaseResource) WriteStream(w http.ResponseWriter, r *http.Request, iterate func(func(interface{}) error) error) {
+	ctx := r.Context()
+	ndjson := resource.IsNDJSONAccepted(r)
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+
+	var nbrItems int
+	err := iterate(func(item interface{}) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if nbrItems == 0 {
+			resource.writeStreamHeader(w, ndjson)
+		}
+
+		if !ndjson && nbrItems > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+
+		nbrItems++
+		if flusher != nil && nbrItems%configs.STREAMFLUSHINTERVAL == 0 {
+			flusher.Flush()
+		}
+
+		return nil
+	})
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		log.Printf("Stopped streaming response, error: %v.\n", ctxErr)
+		return
+	}
+
+	// Nothing has been sent yet, the failure can still be reported with proper status
+	if err != nil && nbrItems == 0 {
+		resource.WriteError(w, err)
+		return
+	}
+
+	if err != nil {
+		log.Printf("Failed streaming response, error: %v.\n", err)
+	}
+
+	if nbrItems == 0 {
+		resource.writeStreamHeader(w, ndjson)
+	}
+
+	if ndjson {
+		return
+	}
+
+	message := ""
+	if err != nil {
+		message = problem.FromError(err).Detail
+	}
+
+	trailer, _ := json.Marshal(message)
+	fmt.Fprintf(w, "],\"success\":%t,\"message\":%s}\n", err == nil, trailer)
+}
+
+func (resource *BaseResource) writeStreamHeader(w http.ResponseWriter, ndjson bool) {
+	if ndjson {
+		w.Header().Set("Content-Type", NDJSONContentType)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, `{"data":[`)
+}
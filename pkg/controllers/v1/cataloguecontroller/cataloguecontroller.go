@@ -1,19 +1,42 @@
 package cataloguecontroller
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
 	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/commons/jsonpatch"
+	"github.com/bungysheep/catalogue-api/pkg/commons/jsonschema"
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
 	"github.com/bungysheep/catalogue-api/pkg/commons/status"
 	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
 	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	customfielddefinitionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfielddefinition"
+	customfieldgroupmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfieldgroup"
+	customfieldoptionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfieldoption"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
 	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/categoryrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/changerequestrepository"
 	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfielddefinitionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfieldgrouprepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfieldoptionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcategoryrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcustomfieldrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productpricerepository"
 	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/revisionproductrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/revisionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/backfillservice"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/cloneservice"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/customfieldservice"
 	"github.com/gorilla/mux"
 )
 
@@ -32,13 +55,11 @@ func (clgCtl *CatalogueController) GetAll(w http.ResponseWriter, r *http.Request
 	log.Printf("Retrieving all Catalogues.\n")
 
 	clgRepo := cataloguerepository.NewCatalogueRepository()
-	result, err := clgRepo.GetAll(r.Context())
-	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-		return
-	}
-
-	clgCtl.WriteResponse(w, http.StatusOK, true, result, "")
+	clgCtl.WriteStream(w, r, func(write func(interface{}) error) error {
+		return clgRepo.ForEachAll(r.Context(), func(catalogue *cataloguemodel.Catalogue) error {
+			return write(catalogue)
+		})
+	})
 }
 
 // GetByID - Return a catalogue
@@ -51,13 +72,42 @@ func (clgCtl *CatalogueController) GetByID(w http.ResponseWriter, r *http.Reques
 	clgRepo := cataloguerepository.NewCatalogueRepository()
 	result, err := clgRepo.GetByID(r.Context(), code)
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
 		return
 	}
 
+	if result != nil {
+		clgCtl.WriteETag(w, result.GetVers())
+	}
+
 	clgCtl.WriteResponse(w, http.StatusOK, true, result, "")
 }
 
+// GetSchema - Return json schema of product in catalogue
+func (clgCtl *CatalogueController) GetSchema(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["id"]
+
+	log.Printf("Retrieving Product Schema of Catalogue '%v'.\n", code)
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil {
+		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	clgCtl.WriteETag(w, clg.GetVers())
+	w.Header().Set("Content-Type", jsonschema.ContentType)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(productmodel.NewSchema(clg))
+}
+
 // Create - Create new catalogue
 func (clgCtl *CatalogueController) Create(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Creating Catalogue.\n")
@@ -70,10 +120,11 @@ func (clgCtl *CatalogueController) Create(w http.ResponseWriter, r *http.Request
 		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid create catalogue request.")
 		return
 	}
+	newClg.Categories = nil
 
-	valid, message := newClg.DoValidate()
-	if !valid {
-		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, message)
+	violations := newClg.DoValidate(nil)
+	if !violations.IsValid() {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
 		return
 	}
 
@@ -85,7 +136,7 @@ func (clgCtl *CatalogueController) Create(w http.ResponseWriter, r *http.Request
 	clgRepo := cataloguerepository.NewCatalogueRepository()
 	nbrRows, err := clgRepo.Create(r.Context(), newClg)
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
 		return
 	}
 
@@ -96,11 +147,15 @@ func (clgCtl *CatalogueController) Create(w http.ResponseWriter, r *http.Request
 
 	result, err := clgRepo.GetByID(r.Context(), newClg.GetCode())
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
 		return
 	}
 
 	if result != nil {
+		if !clgCtl.saveGroups(w, r, newClg, nil) {
+			return
+		}
+
 		fieldDefRepo := customfielddefinitionrepository.NewCustomFieldDefinitionRepository()
 		for _, newFieldDef := range newClg.GetAllCustomFieldDefinitions() {
 			if newFieldDef.GetChangeMode() == changemode.Add {
@@ -111,30 +166,103 @@ func (clgCtl *CatalogueController) Create(w http.ResponseWriter, r *http.Request
 				newFieldDef.ModifiedAt = newClg.GetModifiedAt()
 				newFieldDef.Vers = 1
 
-				nbrRows, err := fieldDefRepo.Create(r.Context(), newFieldDef)
+				lastFieldDefID, err := fieldDefRepo.Create(r.Context(), newFieldDef)
 				if err != nil {
-					clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+					clgCtl.WriteError(w, err)
 					return
 				}
 
-				if nbrRows == 0 {
+				if lastFieldDefID == 0 {
 					clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Custom Field Definition was not created.")
 					return
 				}
+
+				newFieldDef.ID = lastFieldDefID
+				if !clgCtl.saveOptions(w, r, newFieldDef, nil) {
+					return
+				}
 			}
 		}
 
 		fieldDefs, err := fieldDefRepo.GetByCatalogue(r.Context(), newClg.GetCode())
 		if err != nil {
-			clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+			clgCtl.WriteError(w, err)
 			return
 		}
 		result.CustomFieldDefinitions = fieldDefs
+
+		groups, err := customfieldgrouprepository.NewCustomFieldGroupRepository().GetByCatalogue(r.Context(), newClg.GetCode())
+		if err != nil {
+			clgCtl.WriteError(w, err)
+			return
+		}
+		result.FieldGroups = groups
 	}
 
 	clgCtl.WriteResponse(w, http.StatusAccepted, true, result, "Catalogue has been created.")
 }
 
+// Clone - Create new catalogue as a copy of catalogue, along with its products when request includes them
+func (clgCtl *CatalogueController) Clone(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["id"]
+
+	log.Printf("Cloning Catalogue '%v'.\n", code)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	clone := &cataloguemodel.Clone{}
+	err := json.NewDecoder(r.Body).Decode(clone)
+	if err != nil {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid clone catalogue request.")
+		return
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil {
+		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	newClg := clg.NewClone(clone)
+	violations := newClg.DoValidate(nil)
+	if !violations.IsValid() {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	existing, err := clgRepo.GetByID(r.Context(), newClg.GetCode())
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if existing != nil {
+		clgCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, fmt.Sprintf("Catalogue '%s' already exists.", newClg.GetCode()))
+		return
+	}
+
+	cloneSvc := cloneservice.NewCloneService()
+	result, err := cloneSvc.Clone(r.Context(), clg, newClg, clone.IncludeProducts, authClaims.GetUsername())
+	if err == cloneservice.ErrCatalogueNotCreated {
+		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, err.Error())
+		return
+	} else if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	clgCtl.WriteETag(w, result.GetVers())
+	w.Header().Set("Location", fmt.Sprintf("/v1/catalogues/%s", result.GetCode()))
+	clgCtl.WriteResponse(w, http.StatusAccepted, true, result, "Catalogue has been cloned.")
+}
+
 // Update - Update catalogue
 func (clgCtl *CatalogueController) Update(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
@@ -142,12 +270,21 @@ func (clgCtl *CatalogueController) Update(w http.ResponseWriter, r *http.Request
 
 	log.Printf("Updating Catalogue '%v'.\n", code)
 
-	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
-
 	clgRepo := cataloguerepository.NewCatalogueRepository()
 	oldClg, err := clgRepo.GetByID(r.Context(), code)
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if oldClg == nil {
+		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	if !clgCtl.IsIfMatchMet(r, oldClg.GetVers()) {
+		clgCtl.WriteETag(w, oldClg.GetVers())
+		clgCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldClg, "Catalogue version does not match.")
 		return
 	}
 
@@ -158,41 +295,168 @@ func (clgCtl *CatalogueController) Update(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if oldClg.GetVers() != updClg.GetVers() {
-		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid catalogue version.")
+	clgCtl.doUpdate(w, r, oldClg, updClg)
+}
+
+// Patch - Patch catalogue using merge patch or json patch
+func (clgCtl *CatalogueController) Patch(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["id"]
+
+	log.Printf("Patching Catalogue '%v'.\n", code)
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	oldClg, err := clgRepo.GetByID(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if oldClg == nil {
+		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	if !clgCtl.IsIfMatchMet(r, oldClg.GetVers()) {
+		clgCtl.WriteETag(w, oldClg.GetVers())
+		clgCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldClg, "Catalogue version does not match.")
+		return
+	}
+
+	patch, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid patch catalogue request.")
+		return
+	}
+
+	doc, err := json.Marshal(oldClg)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	patchedDoc, err := jsonpatch.Apply(r.Header.Get("Content-Type"), doc, patch)
+	if err == jsonpatch.ErrUnsupportedContentType {
+		clgCtl.WriteResponse(w, http.StatusUnsupportedMediaType, false, nil, err.Error())
+		return
+	} else if err != nil {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, err.Error())
+		return
+	}
+
+	updClg := cataloguemodel.NewCatalogue()
+	err = json.Unmarshal(patchedDoc, updClg)
+	if err != nil {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid patch catalogue request.")
+		return
+	}
+
+	updClg.MarkChanges(oldClg)
+
+	clgCtl.doUpdate(w, r, oldClg, updClg)
+}
+
+func (clgCtl *CatalogueController) doUpdate(w http.ResponseWriter, r *http.Request, oldClg *cataloguemodel.Catalogue, updClg *cataloguemodel.Catalogue) {
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+
+	// If-Match takes precedence over version in request body
+	if !clgCtl.HasIfMatch(r) && oldClg.GetVers() != updClg.GetVers() {
+		clgCtl.WriteETag(w, oldClg.GetVers())
+		clgCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, oldClg, "Catalogue has been modified by another user.")
+		return
+	}
+
+	updClg.Categories = oldClg.GetAllCategories()
+
+	// Reviewers left out of request are kept
+	if updClg.Reviewers == nil {
+		updClg.Reviewers = oldClg.GetAllReviewers()
+	}
+
+	// Approval can not be lifted by the very users it applies to
+	if oldClg.GetApprovalRequired() && !oldClg.IsReviewer(authClaims.GetUsername()) &&
+		(!updClg.GetApprovalRequired() || strings.Join(updClg.GetAllReviewers(), ",") != strings.Join(oldClg.GetAllReviewers(), ",")) {
+		clgCtl.WriteResponse(w, http.StatusForbidden, false, nil, "Approval settings can only be changed by a reviewer of catalogue.")
 		return
 	}
 
-	valid, message := updClg.DoValidate()
-	if !valid {
-		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, message)
+	violations := updClg.DoValidate(oldClg)
+	if !violations.IsValid() {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
 		return
 	}
 
+	message, err := clgCtl.checkOptionsInUse(r.Context(), oldClg, updClg)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if message != "" {
+		clgCtl.WriteResponse(w, http.StatusConflict, false, nil, message)
+		return
+	}
+
+	nbrProducts, err := productrepository.NewProductRepository().CountByCatalogue(r.Context(), oldClg.GetCode())
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrProducts > 0 {
+		if message := checkDefaultsRequired(oldClg, updClg); message != "" {
+			clgCtl.WriteResponse(w, http.StatusConflict, false, nil, message)
+			return
+		}
+
+		blockers, message, err := clgCtl.checkTypeChanges(r.Context(), oldClg, updClg)
+		if err != nil {
+			clgCtl.WriteError(w, err)
+			return
+		}
+
+		if message != "" {
+			clgCtl.WriteResponse(w, http.StatusConflict, false, blockers, message)
+			return
+		}
+	}
+
 	oldClg.Description = updClg.GetDescription()
 	oldClg.Details = updClg.GetDetails()
 	oldClg.Status = updClg.GetStatus()
+	oldClg.ApprovalRequired = updClg.GetApprovalRequired()
+	oldClg.Reviewers = updClg.GetAllReviewers()
 	oldClg.ModifiedBy = authClaims.GetUsername()
 
 	nbrRows, err := clgRepo.Update(r.Context(), oldClg)
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
 		return
 	}
 
 	if nbrRows == 0 {
-		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue was not updated.")
+		clgCtl.writeUpdateConflict(w, r, oldClg.GetCode())
 		return
 	}
 
 	result, err := clgRepo.GetByID(r.Context(), oldClg.GetCode())
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
 		return
 	}
 
 	if result != nil {
+		// Definitions whose default value is to be applied to existing products
+		backfillFieldDefs := make([]*customfielddefinitionmodel.CustomFieldDefinition, 0)
+
+		if !clgCtl.saveGroups(w, r, updClg, oldClg) {
+			return
+		}
+
 		fieldDefRepo := customfielddefinitionrepository.NewCustomFieldDefinitionRepository()
+		fieldSvc := customfieldservice.NewCustomFieldService()
 		for _, updFieldDef := range updClg.GetAllCustomFieldDefinitions() {
 			oldFieldDef := oldClg.GetCustomFieldDefinition(updFieldDef.GetID())
 
@@ -205,37 +469,84 @@ func (clgCtl *CatalogueController) Update(w http.ResponseWriter, r *http.Request
 					updFieldDef.ModifiedAt = oldClg.GetModifiedAt()
 					updFieldDef.Vers = 1
 
-					lastUomID, err := fieldDefRepo.Create(r.Context(), updFieldDef)
+					lastFieldDefID, err := fieldDefRepo.Create(r.Context(), updFieldDef)
 					if err != nil {
-						clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+						clgCtl.WriteError(w, err)
 						return
 					}
 
-					if lastUomID == 0 {
+					if lastFieldDefID == 0 {
 						clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Custom Field Definition was not created.")
 						return
 					}
+
+					updFieldDef.ID = lastFieldDefID
+					if !clgCtl.saveOptions(w, r, updFieldDef, nil) {
+						return
+					}
+
+					if updFieldDef.HasDefaultValue() {
+						backfillFieldDefs = append(backfillFieldDefs, updFieldDef)
+					}
 				}
 
 			} else if updFieldDef.GetChangeMode() == changemode.Update {
+				prevFieldDef := *oldFieldDef
+
 				if !updFieldDef.IsEqual(oldFieldDef) {
 					oldFieldDef.Caption = updFieldDef.GetCaption()
 					oldFieldDef.Type = updFieldDef.GetType()
 					oldFieldDef.Mandatory = updFieldDef.GetMandatory()
+					oldFieldDef.Precision = updFieldDef.GetPrecision()
+					oldFieldDef.MinLength = updFieldDef.GetMinLength()
+					oldFieldDef.MaxLength = updFieldDef.GetMaxLength()
+					oldFieldDef.Pattern = updFieldDef.GetPattern()
+					oldFieldDef.MinValue = updFieldDef.GetMinValue()
+					oldFieldDef.MaxValue = updFieldDef.GetMaxValue()
+					oldFieldDef.Step = updFieldDef.GetStep()
+					oldFieldDef.MinDate = updFieldDef.GetMinDate()
+					oldFieldDef.MaxDate = updFieldDef.GetMaxDate()
+					oldFieldDef.Unique = updFieldDef.GetUnique()
+					oldFieldDef.DefaultValue = updFieldDef.GetDefaultValue()
+					oldFieldDef.GroupCode = updFieldDef.GetGroupCode()
+					oldFieldDef.SortOrder = updFieldDef.GetSortOrder()
+					oldFieldDef.HelpText = updFieldDef.GetHelpText()
+					oldFieldDef.Placeholder = updFieldDef.GetPlaceholder()
+					oldFieldDef.UnitLabel = updFieldDef.GetUnitLabel()
+					oldFieldDef.Hidden = updFieldDef.GetHidden()
+					oldFieldDef.ReadOnly = updFieldDef.GetReadOnly()
+					oldFieldDef.VariantAxis = updFieldDef.GetVariantAxis()
+					oldFieldDef.CategoryID = updFieldDef.GetCategoryID()
 					oldFieldDef.ModifiedBy = oldClg.GetModifiedBy()
 					oldFieldDef.ModifiedAt = oldClg.GetModifiedAt()
 
 					_, err := fieldDefRepo.Update(r.Context(), oldFieldDef)
 					if err != nil {
-						clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+						clgCtl.WriteError(w, err)
 						return
 					}
 				}
 
+				if !clgCtl.saveOptions(w, r, updFieldDef, oldFieldDef) {
+					return
+				}
+
+				if prevFieldDef.GetType() != updFieldDef.GetType() {
+					err := fieldSvc.MigrateType(r.Context(), &prevFieldDef, updFieldDef)
+					if err != nil {
+						clgCtl.WriteError(w, err)
+						return
+					}
+				}
+
+				if isBackfillRequired(&prevFieldDef, updFieldDef) {
+					backfillFieldDefs = append(backfillFieldDefs, oldFieldDef)
+				}
+
 			} else if updFieldDef.GetChangeMode() == changemode.Delete {
 				nbrRow, err := fieldDefRepo.Delete(r.Context(), updFieldDef.GetID())
 				if err != nil {
-					clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+					clgCtl.WriteError(w, err)
 					return
 				}
 
@@ -244,21 +555,145 @@ func (clgCtl *CatalogueController) Update(w http.ResponseWriter, r *http.Request
 					return
 				}
 
+				optRepo := customfieldoptionrepository.NewCustomFieldOptionRepository()
+				err = optRepo.DeleteByField(r.Context(), updFieldDef.GetID())
+				if err != nil {
+					clgCtl.WriteError(w, err)
+					return
+				}
+
+			} else if oldFieldDef != nil {
+				if !clgCtl.saveOptions(w, r, updFieldDef, oldFieldDef) {
+					return
+				}
+
+			}
+		}
+
+		if nbrProducts > 0 {
+			backfillSvc := backfillservice.NewBackfillService()
+			for _, fieldDef := range backfillFieldDefs {
+				_, err := backfillSvc.Submit(r.Context(), fieldDef, authClaims.GetUsername())
+				if err != nil {
+					clgCtl.WriteError(w, err)
+					return
+				}
 			}
 		}
 
 		fieldDefs, err := fieldDefRepo.GetByCatalogue(r.Context(), oldClg.GetCode())
 		if err != nil {
-			clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+			clgCtl.WriteError(w, err)
 			return
 		}
 
 		result.CustomFieldDefinitions = fieldDefs
+
+		groups, err := customfieldgrouprepository.NewCustomFieldGroupRepository().GetByCatalogue(r.Context(), oldClg.GetCode())
+		if err != nil {
+			clgCtl.WriteError(w, err)
+			return
+		}
+
+		result.FieldGroups = groups
+	}
+
+	if result != nil {
+		clgCtl.WriteETag(w, result.GetVers())
 	}
 
 	clgCtl.WriteResponse(w, http.StatusAccepted, true, result, "Catalogue has been updated.")
 }
 
+// ReorderFieldDefinitions - Reorder custom field definitions of catalogue
+func (clgCtl *CatalogueController) ReorderFieldDefinitions(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["id"]
+
+	log.Printf("Reordering Custom Field Definitions of Catalogue '%v'.\n", code)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	oldClg, err := clgRepo.GetByID(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if oldClg == nil {
+		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	if !clgCtl.IsIfMatchMet(r, oldClg.GetVers()) {
+		clgCtl.WriteETag(w, oldClg.GetVers())
+		clgCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldClg, "Catalogue version does not match.")
+		return
+	}
+
+	var order struct {
+		FieldIDs []int64 `json:"field_ids"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&order)
+	if err != nil {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid reorder custom field definitions request.")
+		return
+	}
+
+	seen := make(map[int64]bool)
+	for _, fieldID := range order.FieldIDs {
+		if oldClg.GetCustomFieldDefinition(fieldID) == nil || seen[fieldID] {
+			clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, fmt.Sprintf("Custom Field Definition '%d' is unknown or duplicated.", fieldID))
+			return
+		}
+		seen[fieldID] = true
+	}
+
+	if len(order.FieldIDs) != len(oldClg.GetAllCustomFieldDefinitions()) {
+		clgCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "All Custom Field Definitions of catalogue must be ordered.")
+		return
+	}
+
+	oldClg.ModifiedBy = authClaims.GetUsername()
+
+	nbrRows, err := clgRepo.Update(r.Context(), oldClg)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 {
+		clgCtl.writeUpdateConflict(w, r, oldClg.GetCode())
+		return
+	}
+
+	fieldDefRepo := customfielddefinitionrepository.NewCustomFieldDefinitionRepository()
+	for i, fieldID := range order.FieldIDs {
+		if oldClg.GetCustomFieldDefinition(fieldID).GetSortOrder() == i+1 {
+			continue
+		}
+
+		_, err := fieldDefRepo.UpdateSortOrder(r.Context(), fieldID, i+1)
+		if err != nil {
+			clgCtl.WriteError(w, err)
+			return
+		}
+	}
+
+	result, err := clgRepo.GetByID(r.Context(), oldClg.GetCode())
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if result != nil {
+		clgCtl.WriteETag(w, result.GetVers())
+	}
+
+	clgCtl.WriteResponse(w, http.StatusAccepted, true, result, "Custom Field Definitions have been reordered.")
+}
+
 // Delete - Delete catalogue
 func (clgCtl *CatalogueController) Delete(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
@@ -266,10 +701,38 @@ func (clgCtl *CatalogueController) Delete(w http.ResponseWriter, r *http.Request
 
 	log.Printf("Deleting Catalogue '%v'.\n", code)
 
+	// Catalogue is deleted only while it still has the version matched by If-Match
+	var vers int64
 	clgRepo := cataloguerepository.NewCatalogueRepository()
-	nbrRows, err := clgRepo.Delete(r.Context(), code)
+	if clgCtl.HasIfMatch(r) {
+		oldClg, err := clgRepo.GetByID(r.Context(), code)
+		if err != nil {
+			clgCtl.WriteError(w, err)
+			return
+		}
+
+		if oldClg == nil {
+			clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+			return
+		}
+
+		if !clgCtl.IsIfMatchMet(r, oldClg.GetVers()) {
+			clgCtl.WriteETag(w, oldClg.GetVers())
+			clgCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldClg, "Catalogue version does not match.")
+			return
+		}
+
+		vers = oldClg.GetVers()
+	}
+
+	nbrRows, err := clgRepo.Delete(r.Context(), code, vers)
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 && vers > 0 {
+		clgCtl.writeDeleteConflict(w, r, code)
 		return
 	}
 
@@ -278,11 +741,73 @@ func (clgCtl *CatalogueController) Delete(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Also delete all related custom field options
+	optRepo := customfieldoptionrepository.NewCustomFieldOptionRepository()
+	err = optRepo.DeleteByCatalogue(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
 	// Also delete all related custom field definitions
 	fieldDefRepo := customfielddefinitionrepository.NewCustomFieldDefinitionRepository()
 	err = fieldDefRepo.DeleteByCatalogue(r.Context(), code)
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	// Also delete all related custom field groups
+	grpRepo := customfieldgrouprepository.NewCustomFieldGroupRepository()
+	err = grpRepo.DeleteByCatalogue(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	// Also delete all related prices, before their products
+	priceRepo := productpricerepository.NewProductPriceRepository()
+	err = priceRepo.DeleteByCatalogue(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	// Also delete all related category assignments and categories
+	pcRepo := productcategoryrepository.NewProductCategoryRepository()
+	err = pcRepo.DeleteByCatalogue(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	err = catRepo.DeleteByCatalogue(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	// Also delete all published revisions along with their products
+	revProdRepo := revisionproductrepository.NewRevisionProductRepository()
+	err = revProdRepo.DeleteByCatalogue(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	revRepo := revisionrepository.NewRevisionRepository()
+	err = revRepo.DeleteByCatalogue(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	// Also delete all change requests
+	changeRepo := changerequestrepository.NewChangeRequestRepository()
+	err = changeRepo.DeleteByCatalogue(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
 		return
 	}
 
@@ -290,9 +815,233 @@ func (clgCtl *CatalogueController) Delete(w http.ResponseWriter, r *http.Request
 	prodRepo := productrepository.NewProductRepository()
 	err = prodRepo.DeleteByCatalogue(r.Context(), code)
 	if err != nil {
-		clgCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		clgCtl.WriteError(w, err)
 		return
 	}
 
 	clgCtl.WriteResponse(w, http.StatusOK, true, nil, "Catalogue has been deleted.")
 }
+
+func (clgCtl *CatalogueController) writeUpdateConflict(w http.ResponseWriter, r *http.Request, code string) {
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	current, err := clgRepo.GetByID(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if current == nil {
+		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	clgCtl.WriteETag(w, current.GetVers())
+	clgCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, current, "Catalogue has been modified by another user.")
+}
+
+func (clgCtl *CatalogueController) writeDeleteConflict(w http.ResponseWriter, r *http.Request, code string) {
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	current, err := clgRepo.GetByID(r.Context(), code)
+	if err != nil {
+		clgCtl.WriteError(w, err)
+		return
+	}
+
+	if current == nil {
+		clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	clgCtl.WriteETag(w, current.GetVers())
+	clgCtl.WriteResponse(w, http.StatusPreconditionFailed, false, current, "Catalogue version does not match.")
+}
+
+// saveOptions - Persists options of custom field definition by their change mode, all options when definition is new
+func (clgCtl *CatalogueController) saveOptions(w http.ResponseWriter, r *http.Request, fieldDef *customfielddefinitionmodel.CustomFieldDefinition, oldFieldDef *customfielddefinitionmodel.CustomFieldDefinition) bool {
+	optRepo := customfieldoptionrepository.NewCustomFieldOptionRepository()
+
+	for _, option := range fieldDef.GetAllOptions() {
+		option.FieldID = fieldDef.GetID()
+
+		var oldOption *customfieldoptionmodel.CustomFieldOption
+		if oldFieldDef != nil {
+			oldOption = oldFieldDef.GetOption(option.GetID())
+		}
+
+		if oldFieldDef == nil || option.GetChangeMode() == changemode.Add {
+			if option.GetChangeMode() == changemode.Delete || oldOption != nil {
+				continue
+			}
+
+			lastOptionID, err := optRepo.Create(r.Context(), option)
+			if err != nil {
+				clgCtl.WriteError(w, err)
+				return false
+			}
+
+			if lastOptionID == 0 {
+				clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Custom Field Option was not created.")
+				return false
+			}
+
+		} else if option.GetChangeMode() == changemode.Update {
+			if oldOption != nil && !option.IsEqual(oldOption) {
+				_, err := optRepo.Update(r.Context(), option)
+				if err != nil {
+					clgCtl.WriteError(w, err)
+					return false
+				}
+			}
+
+		} else if option.GetChangeMode() == changemode.Delete {
+			if oldOption != nil {
+				_, err := optRepo.Delete(r.Context(), option.GetID())
+				if err != nil {
+					clgCtl.WriteError(w, err)
+					return false
+				}
+			}
+
+		}
+	}
+
+	return true
+}
+
+// saveGroups - Persists custom field groups of catalogue by their change mode, all groups when catalogue is new
+func (clgCtl *CatalogueController) saveGroups(w http.ResponseWriter, r *http.Request, clg *cataloguemodel.Catalogue, oldClg *cataloguemodel.Catalogue) bool {
+	grpRepo := customfieldgrouprepository.NewCustomFieldGroupRepository()
+
+	for _, group := range clg.GetAllFieldGroups() {
+		var oldGroup *customfieldgroupmodel.CustomFieldGroup
+		if oldClg != nil {
+			oldGroup = oldClg.GetFieldGroup(group.GetID())
+			group.CatalogueCode = oldClg.GetCode()
+		} else {
+			group.CatalogueCode = clg.GetCode()
+		}
+
+		if oldClg == nil || group.GetChangeMode() == changemode.Add {
+			if group.GetChangeMode() == changemode.Delete || oldGroup != nil {
+				continue
+			}
+
+			lastGroupID, err := grpRepo.Create(r.Context(), group)
+			if err != nil {
+				clgCtl.WriteError(w, err)
+				return false
+			}
+
+			if lastGroupID == 0 {
+				clgCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Custom Field Group was not created.")
+				return false
+			}
+
+		} else if group.GetChangeMode() == changemode.Update {
+			if oldGroup != nil && !group.IsEqual(oldGroup) {
+				_, err := grpRepo.Update(r.Context(), group)
+				if err != nil {
+					clgCtl.WriteError(w, err)
+					return false
+				}
+			}
+
+		} else if group.GetChangeMode() == changemode.Delete {
+			if oldGroup != nil {
+				_, err := grpRepo.Delete(r.Context(), group.GetID())
+				if err != nil {
+					clgCtl.WriteError(w, err)
+					return false
+				}
+			}
+
+		}
+	}
+
+	return true
+}
+
+// checkOptionsInUse - Returns message when an option still used by products would be deleted or recoded
+func (clgCtl *CatalogueController) checkOptionsInUse(ctx context.Context, oldClg *cataloguemodel.Catalogue, updClg *cataloguemodel.Catalogue) (string, error) {
+	pcfRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+
+	for _, updFieldDef := range updClg.GetAllCustomFieldDefinitions() {
+		oldFieldDef := oldClg.GetCustomFieldDefinition(updFieldDef.GetID())
+		if oldFieldDef == nil || updFieldDef.GetChangeMode() == changemode.Delete {
+			continue
+		}
+
+		for _, option := range updFieldDef.GetAllOptions() {
+			oldOption := oldFieldDef.GetOption(option.GetID())
+			if oldOption == nil {
+				continue
+			}
+
+			isDeleted := option.GetChangeMode() == changemode.Delete
+			isRecoded := option.GetChangeMode() == changemode.Update && option.GetCode() != oldOption.GetCode()
+			if !isDeleted && !isRecoded {
+				continue
+			}
+
+			count, err := pcfRepo.CountByOption(ctx, oldFieldDef.GetID(), oldOption.GetCode())
+			if err != nil {
+				return "", err
+			}
+
+			if count > 0 {
+				return fmt.Sprintf("Option '%s' of Custom Field '%s' is used by %d products, deactivate it instead.", oldOption.GetCode(), oldFieldDef.GetCaption(), count), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// checkDefaultsRequired - Returns message when a definition becomes mandatory without default value for existing products
+func checkDefaultsRequired(oldClg *cataloguemodel.Catalogue, updClg *cataloguemodel.Catalogue) string {
+	for _, updFieldDef := range updClg.GetAllCustomFieldDefinitions() {
+		if updFieldDef.GetChangeMode() != changemode.Add && updFieldDef.GetChangeMode() != changemode.Update {
+			continue
+		}
+
+		oldFieldDef := oldClg.GetCustomFieldDefinition(updFieldDef.GetID())
+		becomesMandatory := updFieldDef.GetMandatory() && (oldFieldDef == nil || !oldFieldDef.GetMandatory())
+		if becomesMandatory && !updFieldDef.HasDefaultValue() {
+			return fmt.Sprintf("Custom Field '%s' must have default value to become mandatory, catalogue already has products.", updFieldDef.GetCaption())
+		}
+	}
+
+	return ""
+}
+
+// checkTypeChanges - Returns products blocking a type change of definition along with the message
+func (clgCtl *CatalogueController) checkTypeChanges(ctx context.Context, oldClg *cataloguemodel.Catalogue, updClg *cataloguemodel.Catalogue) ([]*customfieldservice.TypeChangeBlocker, string, error) {
+	fieldSvc := customfieldservice.NewCustomFieldService()
+
+	for _, updFieldDef := range updClg.GetAllCustomFieldDefinitions() {
+		oldFieldDef := oldClg.GetCustomFieldDefinition(updFieldDef.GetID())
+		if oldFieldDef == nil || updFieldDef.GetChangeMode() != changemode.Update || oldFieldDef.GetType() == updFieldDef.GetType() {
+			continue
+		}
+
+		blockers, err := fieldSvc.CheckTypeChange(ctx, oldFieldDef, updFieldDef)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(blockers) > 0 {
+			return blockers, fmt.Sprintf("Custom Field '%s' can not be changed to type '%s', values of products can not be converted.", oldFieldDef.GetCaption(), updFieldDef.GetType()), nil
+		}
+	}
+
+	return nil, "", nil
+}
+
+// isBackfillRequired - Whether updated definition has a default value which existing products may be missing
+func isBackfillRequired(oldFieldDef *customfielddefinitionmodel.CustomFieldDefinition, updFieldDef *customfielddefinitionmodel.CustomFieldDefinition) bool {
+	if !updFieldDef.HasDefaultValue() {
+		return false
+	}
+
+	return updFieldDef.GetDefaultValue() != oldFieldDef.GetDefaultValue() || (updFieldDef.GetMandatory() && !oldFieldDef.GetMandatory())
+}
@@ -0,0 +1,479 @@
+package categorycontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
+	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
+	categorymodel "github.com/bungysheep/catalogue-api/pkg/models/v1/category"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/categoryrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfielddefinitionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcategoryrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/categoryservice"
+	"github.com/gorilla/mux"
+)
+
+// CategoryController type
+type CategoryController struct {
+	basecontroller.BaseResource
+}
+
+// NewCategoryController - Creates category controller
+func NewCategoryController() *CategoryController {
+	return &CategoryController{}
+}
+
+// GetByCatalogue - Return categories of catalogue ordered by path, nested beneath their parents by tree=true
+func (catCtl *CategoryController) GetByCatalogue(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+	tree := r.URL.Query().Get("tree") == "true"
+
+	log.Printf("Retrieving Categories by Catalogue '%v'.\n", clgCode)
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), clgCode)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	if tree {
+		catCtl.WriteResponse(w, http.StatusOK, true, categorymodel.NewTree(clg.GetAllCategories()), "")
+		return
+	}
+
+	catCtl.WriteResponse(w, http.StatusOK, true, clg.GetAllCategories(), "")
+}
+
+// GetByID - Return a category
+func (catCtl *CategoryController) GetByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Retrieving Category '%v'.\n", id)
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	result, err := catRepo.GetByID(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Category does not exist.")
+		return
+	}
+
+	catCtl.WriteETag(w, result.GetVers())
+	catCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// GetBreadcrumb - Return categories from the root down to the category
+func (catCtl *CategoryController) GetBreadcrumb(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Retrieving Breadcrumb of Category '%v'.\n", id)
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	cat, err := catRepo.GetByID(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if cat == nil {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Category does not exist.")
+		return
+	}
+
+	result, err := catRepo.GetAncestors(r.Context(), cat)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	catCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// GetProducts - Return products assigned to the category or any of its descendants
+func (catCtl *CategoryController) GetProducts(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Retrieving Products of Category '%v'.\n", id)
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	cat, err := catRepo.GetByID(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if cat == nil {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Category does not exist.")
+		return
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	catCtl.WriteStream(w, r, func(write func(interface{}) error) error {
+		return prodRepo.ForEachByCategoryPath(r.Context(), cat.GetPath(), func(product *productmodel.Product) error {
+			return write(product)
+		})
+	})
+}
+
+// Create - Create new category of catalogue, beneath parent category if any
+func (catCtl *CategoryController) Create(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	log.Printf("Creating Category of Catalogue '%v'.\n", clgCode)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	newCat := categorymodel.NewCategory()
+	err := json.NewDecoder(r.Body).Decode(newCat)
+	if err != nil {
+		catCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid create category request.")
+		return
+	}
+	newCat.CatalogueCode = clgCode
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), newCat.GetCatalogueCode())
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	parent, ok := catCtl.getParent(w, r, newCat)
+	if !ok {
+		return
+	}
+
+	violations := newCat.DoValidate()
+	violations.Append("", newCat.DoValidateParent(parent))
+	if !violations.IsValid() {
+		catCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	if !catCtl.validateUnique(w, r, newCat) {
+		return
+	}
+
+	newCat.CreatedBy = authClaims.GetUsername()
+	newCat.CreatedAt = time.Now()
+	newCat.ModifiedBy = authClaims.GetUsername()
+	newCat.ModifiedAt = time.Now()
+
+	catSvc := categoryservice.NewCategoryService()
+	result, err := catSvc.Create(r.Context(), newCat, parent)
+	if err == categoryservice.ErrCategoryNotCreated {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, err.Error())
+		return
+	} else if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if result != nil {
+		catCtl.WriteETag(w, result.GetVers())
+	}
+
+	catCtl.WriteResponse(w, http.StatusAccepted, true, result, "Category has been created.")
+}
+
+// Update - Update code and description of category, its parent is changed by Move
+func (catCtl *CategoryController) Update(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Updating Category '%v'.\n", id)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	updCat := categorymodel.NewCategory()
+	err = json.NewDecoder(r.Body).Decode(updCat)
+	if err != nil {
+		catCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid update category request.")
+		return
+	}
+
+	oldCat, ok := catCtl.getCategory(w, r, id)
+	if !ok {
+		return
+	}
+
+	// If-Match takes precedence over version in request body
+	if !catCtl.HasIfMatch(r) && oldCat.GetVers() != updCat.GetVers() {
+		catCtl.WriteETag(w, oldCat.GetVers())
+		catCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, oldCat, "Category has been modified by another user.")
+		return
+	}
+
+	updCat.ID = oldCat.GetID()
+	updCat.CatalogueCode = oldCat.GetCatalogueCode()
+
+	violations := updCat.DoValidate()
+	if !violations.IsValid() {
+		catCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	if !catCtl.validateUnique(w, r, updCat) {
+		return
+	}
+
+	oldCat.Code = updCat.GetCode()
+	oldCat.Description = updCat.GetDescription()
+	oldCat.ModifiedBy = authClaims.GetUsername()
+	oldCat.ModifiedAt = time.Now()
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	nbrRows, err := catRepo.Update(r.Context(), oldCat)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 {
+		catCtl.writeUpdateConflict(w, r, id)
+		return
+	}
+
+	catCtl.writeResult(w, r, id, "Category has been updated.")
+}
+
+// Move - Move category along with its descendants beneath another parent, or to the root
+func (catCtl *CategoryController) Move(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Moving Category '%v'.\n", id)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	move := &categorymodel.Move{}
+	err = json.NewDecoder(r.Body).Decode(move)
+	if err != nil {
+		catCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid move category request.")
+		return
+	}
+
+	// Category is checked against If-Match, the move itself only applies to the version read
+	oldCat, ok := catCtl.getCategory(w, r, id)
+	if !ok {
+		return
+	}
+
+	movedCat := *oldCat
+	movedCat.ParentID = move.ParentID
+
+	parent, ok := catCtl.getParent(w, r, &movedCat)
+	if !ok {
+		return
+	}
+
+	violations := movedCat.DoValidateParent(parent)
+	if !violations.IsValid() {
+		catCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	movedCat.ModifiedBy = authClaims.GetUsername()
+	movedCat.ModifiedAt = time.Now()
+
+	catSvc := categoryservice.NewCategoryService()
+	result, err := catSvc.Move(r.Context(), &movedCat, parent)
+	if err == categoryservice.ErrCategoryNotMoved {
+		catCtl.writeUpdateConflict(w, r, id)
+		return
+	} else if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if result != nil {
+		catCtl.WriteETag(w, result.GetVers())
+	}
+
+	catCtl.WriteResponse(w, http.StatusAccepted, true, result, "Category has been moved.")
+}
+
+// Delete - Delete category which has neither child categories, products nor custom field definitions
+func (catCtl *CategoryController) Delete(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Deleting Category '%v'.\n", id)
+
+	oldCat, ok := catCtl.getCategory(w, r, id)
+	if !ok {
+		return
+	}
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	nbrChildren, err := catRepo.CountByParent(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrChildren > 0 {
+		catCtl.WriteResponse(w, http.StatusConflict, false, nil, "Category has child categories, they must be moved or deleted first.")
+		return
+	}
+
+	nbrProducts, err := productcategoryrepository.NewProductCategoryRepository().CountByCategory(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrProducts > 0 {
+		catCtl.WriteResponse(w, http.StatusConflict, false, nil, "Category has products assigned to it.")
+		return
+	}
+
+	fieldDefs, err := customfielddefinitionrepository.NewCustomFieldDefinitionRepository().GetByCatalogue(r.Context(), oldCat.GetCatalogueCode())
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	for _, fieldDef := range fieldDefs {
+		if fieldDef.GetCategoryID() == id {
+			catCtl.WriteResponse(w, http.StatusConflict, false, nil, fmt.Sprintf("Category is used by Custom Field '%s'.", fieldDef.GetCaption()))
+			return
+		}
+	}
+
+	nbrRows, err := catRepo.Delete(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Category does not exist.")
+		return
+	}
+
+	catCtl.WriteResponse(w, http.StatusOK, true, nil, "Category has been deleted.")
+}
+
+// getCategory - Returns stored category checked against If-Match, false once failure response is written
+func (catCtl *CategoryController) getCategory(w http.ResponseWriter, r *http.Request, id int64) (*categorymodel.Category, bool) {
+	catRepo := categoryrepository.NewCategoryRepository()
+	oldCat, err := catRepo.GetByID(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return nil, false
+	}
+
+	if oldCat == nil {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Category does not exist.")
+		return nil, false
+	}
+
+	if !catCtl.IsIfMatchMet(r, oldCat.GetVers()) {
+		catCtl.WriteETag(w, oldCat.GetVers())
+		catCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldCat, "Category version does not match.")
+		return nil, false
+	}
+
+	return oldCat, true
+}
+
+// getParent - Returns parent of category, nil for a root category, false once failure response is written
+func (catCtl *CategoryController) getParent(w http.ResponseWriter, r *http.Request, cat *categorymodel.Category) (*categorymodel.Category, bool) {
+	if cat.IsRoot() {
+		return nil, true
+	}
+
+	catSvc := categoryservice.NewCategoryService()
+	parent, err := catSvc.GetParent(r.Context(), cat)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return nil, false
+	}
+
+	if parent == nil {
+		catCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Parent category does not exist.")
+		return nil, false
+	}
+
+	return parent, true
+}
+
+// validateUnique - Validate code of category is not used by another category of catalogue, writing the failure response
+func (catCtl *CategoryController) validateUnique(w http.ResponseWriter, r *http.Request, cat *categorymodel.Category) bool {
+	catRepo := categoryrepository.NewCategoryRepository()
+	other, err := catRepo.GetByCode(r.Context(), cat.GetCatalogueCode(), cat.GetCode())
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return false
+	}
+
+	if other != nil && other.GetID() != cat.GetID() {
+		catCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, fmt.Sprintf("Category '%s' already exists.", cat.GetCode()))
+		return false
+	}
+
+	return true
+}
+
+func (catCtl *CategoryController) writeResult(w http.ResponseWriter, r *http.Request, id int64, message string) {
+	catRepo := categoryrepository.NewCategoryRepository()
+	result, err := catRepo.GetByID(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if result != nil {
+		catCtl.WriteETag(w, result.GetVers())
+	}
+
+	catCtl.WriteResponse(w, http.StatusAccepted, true, result, message)
+}
+
+func (catCtl *CategoryController) writeUpdateConflict(w http.ResponseWriter, r *http.Request, id int64) {
+	catRepo := categoryrepository.NewCategoryRepository()
+	current, err := catRepo.GetByID(r.Context(), id)
+	if err != nil {
+		catCtl.WriteError(w, err)
+		return
+	}
+
+	if current == nil {
+		catCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Category does not exist.")
+		return
+	}
+
+	catCtl.WriteETag(w, current.GetVers())
+	catCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, current, "Category has been modified by another user.")
+}
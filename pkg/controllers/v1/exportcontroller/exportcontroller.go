@@ -0,0 +1,91 @@
+package exportcontroller
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/fileformat"
+	"github.com/bungysheep/catalogue-api/pkg/commons/xlsx"
+	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/exportservice"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/revisionservice"
+	"github.com/gorilla/mux"
+)
+
+// ExportController type
+type ExportController struct {
+	basecontroller.BaseResource
+}
+
+// NewExportController - Creates export controller
+func NewExportController() *ExportController {
+	return &ExportController{}
+}
+
+// Export - Stream catalogue products as csv, ndjson or xlsx, as published in revision or as drafted
+func (exportCtl *ExportController) Export(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	format := strings.ToUpper(r.URL.Query().Get("format"))
+	if format == "" {
+		format = fileformat.CSV.String()
+	}
+
+	log.Printf("Exporting Catalogue '%v' as %v.\n", clgCode, format)
+
+	var contentType string
+	switch format {
+	case fileformat.CSV.String():
+		contentType = "text/csv"
+
+	case fileformat.NDJSON.String():
+		contentType = basecontroller.NDJSONContentType
+
+	case fileformat.XLSX.String():
+		contentType = xlsx.ContentType
+
+	default:
+		exportCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid export format.")
+		return
+
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), clgCode)
+	if err != nil {
+		exportCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil {
+		exportCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	revSvc := revisionservice.NewRevisionService()
+	rev, err := revSvc.GetForReading(r.Context(), clg.GetCode(), r.URL.Query().Get("revision"))
+	if err == revisionservice.ErrInvalidRevision {
+		exportCtl.WriteResponse(w, http.StatusBadRequest, false, nil, err.Error())
+		return
+	} else if err == revisionservice.ErrRevisionNotFound {
+		exportCtl.WriteResponse(w, http.StatusNotFound, false, nil, err.Error())
+		return
+	} else if err != nil {
+		exportCtl.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.%s\"", clg.GetCode(), strings.ToLower(format)))
+	w.WriteHeader(http.StatusOK)
+
+	// Status has been sent, failure can only be logged and the stream cut short
+	exportSvc := exportservice.NewExportService()
+	if err := exportSvc.Export(r.Context(), clg, rev, format, w); err != nil {
+		log.Printf("Failed exporting Catalogue '%v', error: %v.\n", clgCode, err)
+	}
+}
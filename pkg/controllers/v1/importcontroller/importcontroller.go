@@ -0,0 +1,200 @@
+package importcontroller
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/commons/fileformat"
+	"github.com/bungysheep/catalogue-api/pkg/commons/jobstatus"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
+	importjobmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/importjob"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/importjobrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/importservice"
+	"github.com/gorilla/mux"
+)
+
+// ImportController type
+type ImportController struct {
+	basecontroller.BaseResource
+}
+
+// NewImportController - Creates import controller
+func NewImportController() *ImportController {
+	return &ImportController{}
+}
+
+// Create - Submit new product import job for catalogue
+func (importCtl *ImportController) Create(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	log.Printf("Submitting Import Job for Catalogue '%v'.\n", clgCode)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), clgCode)
+	if err != nil {
+		importCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil {
+		importCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, configs.IMPORTMAXSIZE)
+	if err := r.ParseMultipartForm(configs.IMPORTMAXSIZE); err != nil {
+		importCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid import request.")
+		return
+	}
+
+	opts := importjobmodel.NewImportOptions()
+	if options := r.FormValue("options"); options != "" {
+		if err := json.Unmarshal([]byte(options), opts); err != nil {
+			importCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid import options.")
+			return
+		}
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		importCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Import file must be specified.")
+		return
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		importCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid import file.")
+		return
+	}
+
+	newJob := importjobmodel.NewImportJob()
+	newJob.CatalogueCode = clg.GetCode()
+	newJob.Format = opts.GetFormat()
+	if newJob.Format == "" {
+		newJob.Format = formatFromFilename(fileHeader.Filename)
+	}
+	newJob.DryRun = opts.DryRun
+
+	violations := newJob.DoValidate()
+	if !violations.IsValid() {
+		importCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	newJob.Status = jobstatus.Pending.String()
+	newJob.CreatedBy = authClaims.GetUsername()
+	newJob.CreatedAt = time.Now()
+	newJob.ModifiedAt = time.Now()
+
+	jobRepo := importjobrepository.NewImportJobRepository()
+	lastID, err := jobRepo.Create(r.Context(), newJob)
+	if err != nil {
+		importCtl.WriteError(w, err)
+		return
+	}
+
+	if lastID == 0 {
+		importCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Import Job was not created.")
+		return
+	}
+
+	newJob.ID = lastID
+
+	// Request context is cancelled as soon as response is written
+	importSvc := importservice.NewImportService()
+	go importSvc.Run(context.Background(), newJob, opts, data)
+
+	w.Header().Set("Location", fmt.Sprintf("/v1/catalogues/%s/imports/%d", clg.GetCode(), lastID))
+	importCtl.WriteResponse(w, http.StatusAccepted, true, newJob, "Import Job has been submitted.")
+}
+
+// GetByID - Return import job progress
+func (importCtl *ImportController) GetByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+	jobID, _ := strconv.ParseInt(params["job_id"], 10, 64)
+
+	log.Printf("Retrieving Import Job '%v'.\n", jobID)
+
+	jobRepo := importjobrepository.NewImportJobRepository()
+	result, err := jobRepo.GetByID(r.Context(), jobID)
+	if err != nil {
+		importCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil || result.GetCatalogueCode() != strings.ToUpper(clgCode) {
+		importCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Import Job does not exist.")
+		return
+	}
+
+	importCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// GetErrors - Download import job error report as csv
+func (importCtl *ImportController) GetErrors(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+	jobID, _ := strconv.ParseInt(params["job_id"], 10, 64)
+
+	log.Printf("Retrieving Import Job '%v' errors.\n", jobID)
+
+	jobRepo := importjobrepository.NewImportJobRepository()
+	job, err := jobRepo.GetByID(r.Context(), jobID)
+	if err != nil {
+		importCtl.WriteError(w, err)
+		return
+	}
+
+	if job == nil || job.GetCatalogueCode() != strings.ToUpper(clgCode) {
+		importCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Import Job does not exist.")
+		return
+	}
+
+	result, err := jobRepo.GetErrors(r.Context(), jobID)
+	if err != nil {
+		importCtl.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"import-%d-errors.csv\"", jobID))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"row_no", "code", "message"})
+	for _, importError := range result {
+		writer.Write([]string{strconv.FormatInt(importError.GetRowNo(), 10), importError.GetCode(), importError.GetMessage()})
+	}
+	writer.Flush()
+}
+
+func formatFromFilename(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return fileformat.CSV.String()
+
+	case ".ndjson", ".jsonl":
+		return fileformat.NDJSON.String()
+
+	}
+
+	return ""
+}
@@ -0,0 +1,355 @@
+package pricelistcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
+	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
+	pricelistmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/pricelist"
+	productpricemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/productprice"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/pricelistrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productpricerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/priceservice"
+	"github.com/gorilla/mux"
+)
+
+// PriceListController type
+type PriceListController struct {
+	basecontroller.BaseResource
+}
+
+// NewPriceListController - Creates price list controller
+func NewPriceListController() *PriceListController {
+	return &PriceListController{}
+}
+
+// GetAll - Return all price lists, optionally of currency and customer segment
+func (listCtl *PriceListController) GetAll(w http.ResponseWriter, r *http.Request) {
+	currency := strings.ToUpper(r.URL.Query().Get("currency"))
+	segment := strings.ToUpper(r.URL.Query().Get("segment"))
+
+	log.Printf("Retrieving all Price Lists.\n")
+
+	listRepo := pricelistrepository.NewPriceListRepository()
+	lists, err := listRepo.GetAll(r.Context())
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	result := make([]*pricelistmodel.PriceList, 0, len(lists))
+	for _, list := range lists {
+		if (currency == "" || list.GetCurrency() == currency) && (segment == "" || list.GetSegment() == segment) {
+			result = append(result, list)
+		}
+	}
+
+	listCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// GetByID - Return a price list
+func (listCtl *PriceListController) GetByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["code"]
+
+	log.Printf("Retrieving Price List '%v'.\n", code)
+
+	listRepo := pricelistrepository.NewPriceListRepository()
+	result, err := listRepo.GetByID(r.Context(), code)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List does not exist.")
+		return
+	}
+
+	listCtl.WriteETag(w, result.GetVers())
+	listCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// Create - Create new price list
+func (listCtl *PriceListController) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Creating Price List.\n")
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	newList := pricelistmodel.NewPriceList()
+	err := json.NewDecoder(r.Body).Decode(newList)
+	if err != nil {
+		listCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid create price list request.")
+		return
+	}
+
+	violations := newList.DoValidate()
+	if !violations.IsValid() {
+		listCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	listRepo := pricelistrepository.NewPriceListRepository()
+	oldList, err := listRepo.GetByID(r.Context(), newList.GetCode())
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if oldList != nil {
+		listCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, fmt.Sprintf("Price List '%s' already exists.", newList.GetCode()))
+		return
+	}
+
+	newList.CreatedBy = authClaims.GetUsername()
+	newList.CreatedAt = time.Now()
+	newList.ModifiedBy = authClaims.GetUsername()
+	newList.ModifiedAt = time.Now()
+
+	nbrRows, err := listRepo.Create(r.Context(), newList)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List was not created.")
+		return
+	}
+
+	result, err := listRepo.GetByID(r.Context(), newList.GetCode())
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if result != nil {
+		listCtl.WriteETag(w, result.GetVers())
+	}
+
+	listCtl.WriteResponse(w, http.StatusAccepted, true, result, "Price List has been created.")
+}
+
+// Update - Update price list
+func (listCtl *PriceListController) Update(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["code"]
+
+	log.Printf("Updating Price List '%v'.\n", code)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	listRepo := pricelistrepository.NewPriceListRepository()
+	oldList, err := listRepo.GetByID(r.Context(), code)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if oldList == nil {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List does not exist.")
+		return
+	}
+
+	if !listCtl.IsIfMatchMet(r, oldList.GetVers()) {
+		listCtl.WriteETag(w, oldList.GetVers())
+		listCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldList, "Price List version does not match.")
+		return
+	}
+
+	updList := pricelistmodel.NewPriceList()
+	err = json.NewDecoder(r.Body).Decode(updList)
+	if err != nil {
+		listCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid update price list request.")
+		return
+	}
+	updList.Code = oldList.GetCode()
+
+	// If-Match takes precedence over version in request body
+	if !listCtl.HasIfMatch(r) && oldList.GetVers() != updList.GetVers() {
+		listCtl.WriteETag(w, oldList.GetVers())
+		listCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, oldList, "Price List has been modified by another user.")
+		return
+	}
+
+	violations := updList.DoValidate()
+	if !violations.IsValid() {
+		listCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	updList.Vers = oldList.GetVers()
+	updList.ModifiedBy = authClaims.GetUsername()
+	updList.ModifiedAt = time.Now()
+
+	nbrRows, err := listRepo.Update(r.Context(), updList)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	result, err := listRepo.GetByID(r.Context(), code)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List does not exist.")
+		return
+	}
+
+	listCtl.WriteETag(w, result.GetVers())
+
+	if nbrRows == 0 {
+		listCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, result, "Price List has been modified by another user.")
+		return
+	}
+
+	listCtl.WriteResponse(w, http.StatusAccepted, true, result, "Price List has been updated.")
+}
+
+// Delete - Delete price list along with its prices
+func (listCtl *PriceListController) Delete(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["code"]
+
+	log.Printf("Deleting Price List '%v'.\n", code)
+
+	listRepo := pricelistrepository.NewPriceListRepository()
+	if listCtl.HasIfMatch(r) {
+		oldList, err := listRepo.GetByID(r.Context(), code)
+		if err != nil {
+			listCtl.WriteError(w, err)
+			return
+		}
+
+		if oldList == nil {
+			listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List does not exist.")
+			return
+		}
+
+		if !listCtl.IsIfMatchMet(r, oldList.GetVers()) {
+			listCtl.WriteETag(w, oldList.GetVers())
+			listCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldList, "Price List version does not match.")
+			return
+		}
+	}
+
+	nbrRows, err := listRepo.Delete(r.Context(), code)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List does not exist.")
+		return
+	}
+
+	// Also delete all related prices
+	priceRepo := productpricerepository.NewProductPriceRepository()
+	err = priceRepo.DeleteByList(r.Context(), strings.ToUpper(code))
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	listCtl.WriteResponse(w, http.StatusOK, true, nil, "Price List has been deleted.")
+}
+
+// GetPrices - Return all prices of price list
+func (listCtl *PriceListController) GetPrices(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["code"]
+
+	log.Printf("Retrieving Prices of Price List '%v'.\n", code)
+
+	listRepo := pricelistrepository.NewPriceListRepository()
+	list, err := listRepo.GetByID(r.Context(), code)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if list == nil {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List does not exist.")
+		return
+	}
+
+	priceRepo := productpricerepository.NewProductPriceRepository()
+	result, err := priceRepo.GetByList(r.Context(), list.GetCode())
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	listCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// SetProductPrices - Replace all prices of product in price list
+func (listCtl *PriceListController) SetProductPrices(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["code"]
+	prodID, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Setting Prices of Product '%v' in Price List '%v'.\n", prodID, code)
+
+	prices := make([]*productpricemodel.ProductPrice, 0)
+	err = json.NewDecoder(r.Body).Decode(&prices)
+	if err != nil {
+		listCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid set prices request.")
+		return
+	}
+
+	listRepo := pricelistrepository.NewPriceListRepository()
+	list, err := listRepo.GetByID(r.Context(), code)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if list == nil {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List does not exist.")
+		return
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	prod, err := prodRepo.GetByID(r.Context(), prodID)
+	if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	if prod == nil {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	priceSvc := priceservice.NewPriceService()
+	violations := priceSvc.ValidatePrices(prod, prices)
+	if !violations.IsValid() {
+		listCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	result, err := priceSvc.SetPrices(r.Context(), list, prod, prices)
+	if err == priceservice.ErrPriceNotCreated {
+		listCtl.WriteResponse(w, http.StatusNotFound, false, nil, err.Error())
+		return
+	} else if err != nil {
+		listCtl.WriteError(w, err)
+		return
+	}
+
+	listCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product Prices have been set.")
+}
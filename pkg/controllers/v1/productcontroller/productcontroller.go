@@ -1,21 +1,42 @@
 package productcontroller
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
 	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
-	"github.com/bungysheep/catalogue-api/pkg/commons/status"
+	"github.com/bungysheep/catalogue-api/pkg/commons/decimal"
+	"github.com/bungysheep/catalogue-api/pkg/commons/jsonpatch"
+	"github.com/bungysheep/catalogue-api/pkg/commons/lifecycle"
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
+	"github.com/bungysheep/catalogue-api/pkg/commons/reviewstatus"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
 	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
+	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	changerequestmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/changerequest"
 	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	productrelationmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/productrelation"
+	revisionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/revision"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
 	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
-	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcustomfieldrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/changerequestrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/pricelistrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrelationrepository"
 	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
-	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/unitofmeasurerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/revisionproductrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/priceservice"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/productservice"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/relationservice"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/revisionservice"
 	"github.com/gorilla/mux"
 )
 
@@ -29,319 +50,1553 @@ func NewProductController() *ProductController {
 	return &ProductController{}
 }
 
-// GetByCatalogue - Return produts by catalogue
+// GetByCatalogue - Return produts of catalogue as published in revision, or as drafted, variants following their parent
 func (prodCtl *ProductController) GetByCatalogue(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	clgCode := params["clg_code"]
+	grouped := r.URL.Query().Get("group") == "parent"
+	listed := prodCtl.getStatusFilter(r)
 
 	log.Printf("Retrieving Products by Catalogue '%v'.\n", clgCode)
 
-	prodRepo := productrepository.NewProductRepository()
-	result, err := prodRepo.GetByCatalogue(r.Context(), clgCode)
-	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+	forEachFamily, ok := prodCtl.getFamilySource(w, r, clgCode)
+	if !ok {
 		return
 	}
 
-	prodCtl.WriteResponse(w, http.StatusOK, true, result, "")
+	prodCtl.WriteStream(w, r, func(write func(interface{}) error) error {
+		var parent *productmodel.Product
+		err := forEachFamily(func(product *productmodel.Product) error {
+			if parent != nil && product.GetParentID() == parent.GetID() {
+				product.Inherit(parent)
+				if !listed(product) {
+					return nil
+				}
+				if grouped {
+					parent.Variants = append(parent.Variants, product)
+					return nil
+				}
+				return write(product)
+			}
+
+			// Grouped parent is held until all its variants have been read
+			if grouped && parent != nil && (listed(parent) || len(parent.Variants) > 0) {
+				if err := write(parent); err != nil {
+					return err
+				}
+			}
+
+			parent = nil
+			if !product.IsVariant() {
+				parent = product
+				if grouped {
+					return nil
+				}
+			}
+
+			if !listed(product) {
+				return nil
+			}
+
+			return write(product)
+		})
+
+		if err == nil && grouped && parent != nil && (listed(parent) || len(parent.Variants) > 0) {
+			err = write(parent)
+		}
+
+		return err
+	})
 }
 
-// GetByID - Return a product
+// GetByID - Return a product as read from revision of its catalogue
 func (prodCtl *ProductController) GetByID(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id, err := strconv.ParseInt(params["id"], 10, 64)
 
 	log.Printf("Retrieving Product '%v'.\n", id)
 
-	prodRepo := productrepository.NewProductRepository()
-	result, err := prodRepo.GetByID(r.Context(), id)
+	prodSvc := productservice.NewProductService()
+	result, err := prodSvc.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil {
+		prodCtl.WriteResponse(w, http.StatusOK, true, result, "")
+		return
+	}
+
+	rev, ok := prodCtl.getReadRevision(w, r, result.GetCatalogueCode())
+	if !ok {
+		return
+	}
+
+	if rev == nil {
+		prodCtl.WriteETag(w, result.GetVers())
+		prodCtl.WriteResponse(w, http.StatusOK, true, result, "")
+		return
+	}
+
+	revSvc := revisionservice.NewRevisionService()
+	result, err = revSvc.GetProduct(r.Context(), rev, id)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product is not published.")
 		return
 	}
 
 	prodCtl.WriteResponse(w, http.StatusOK, true, result, "")
 }
 
-// Create - Create new product
-func (prodCtl *ProductController) Create(w http.ResponseWriter, r *http.Request) {
-	log.Printf("Creating Product.\n")
+// GetByCode - Return product of code in catalogue as read from its revision, version is only sent for the draft
+func (prodCtl *ProductController) GetByCode(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["clg_code"]
+	code := params["code"]
 
-	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+	log.Printf("Retrieving Product '%v' of Catalogue '%v'.\n", code, clgCode)
 
-	newProd := productmodel.NewProduct()
-	err := json.NewDecoder(r.Body).Decode(newProd)
-	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid create product request.")
+	rev, ok := prodCtl.getReadRevision(w, r, clgCode)
+	if !ok {
 		return
 	}
 
-	clgRepo := cataloguerepository.NewCatalogueRepository()
-	clg, err := clgRepo.GetByID(r.Context(), newProd.GetCatalogueCode())
+	var result *productmodel.Product
+	var err error
+	if rev == nil {
+		prodSvc := productservice.NewProductService()
+		result, err = prodSvc.GetByCode(r.Context(), clgCode, code)
+	} else {
+		revSvc := revisionservice.NewRevisionService()
+		result, err = revSvc.GetProductByCode(r.Context(), rev, code)
+	}
+
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid catalogue code.")
+		prodCtl.WriteError(w, err)
 		return
 	}
 
-	valid, message := newProd.DoValidate(nil, clg)
-	if !valid {
-		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, message)
+	if result == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
 		return
 	}
 
-	newProd.Status = status.Active.String()
-	newProd.CreatedBy = authClaims.GetUsername()
-	newProd.ModifiedBy = authClaims.GetUsername()
-	newProd.Vers = 1
+	if rev == nil {
+		prodCtl.WriteETag(w, result.GetVers())
+	}
+
+	prodCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// Convert - Return quantity of product converted between units of measure
+func (prodCtl *ProductController) Convert(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	query := r.URL.Query()
+	from, to := query.Get("from"), query.Get("to")
+
+	log.Printf("Converting Product '%v' from '%v' to '%v'.\n", id, from, to)
+
+	qty, err := strconv.ParseFloat(query.Get("qty"), 64)
+	if err != nil || math.IsNaN(qty) || math.IsInf(qty, 0) {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid quantity.")
+		return
+	}
+
+	if from == "" || to == "" {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Units of Measure to convert from and to must be specified.")
+		return
+	}
 
 	prodRepo := productrepository.NewProductRepository()
-	lastID, err := prodRepo.Create(r.Context(), newProd)
+	prod, err := prodRepo.GetByID(r.Context(), id)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteError(w, err)
 		return
 	}
 
-	if lastID == 0 {
-		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product was not created.")
+	if prod == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
 		return
 	}
 
-	result, err := prodRepo.GetByID(r.Context(), lastID)
+	prodSvc := productservice.NewProductService()
+	result, message, err := prodSvc.Convert(r.Context(), prod, qty, from, to)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteError(w, err)
 		return
 	}
 
-	if result != nil {
-		uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
-		for _, newUom := range newProd.GetAllUoms() {
-			if newUom.GetChangeMode() == changemode.Add {
-				newUom.ProdID = lastID
-				newUom.Vers = 1
-
-				lastUomID, err := uomRepo.Create(r.Context(), newUom)
-				if err != nil {
-					prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-					return
-				}
+	if result == nil {
+		prodCtl.WriteProblem(w, http.StatusUnprocessableEntity, problem.UomNotConvertible, nil, message)
+		return
+	}
 
-				if lastUomID == 0 {
-					prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure was not created.")
-					return
-				}
-			}
-		}
+	prodCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
 
-		fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
-		for _, newfield := range newProd.GetAllCustomFields() {
-			newfield.ProdID = lastID
+// GetPrice - Return effective price of product in price list for quantity and date
+func (prodCtl *ProductController) GetPrice(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
 
-			lastFieldID, err := fieldRepo.Create(r.Context(), newfield)
-			if err != nil {
-				prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-				return
-			}
+	query := r.URL.Query()
+	listCode := query.Get("list")
 
-			if lastFieldID == 0 {
-				prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Custom Field was not created.")
-				return
-			}
-		}
+	log.Printf("Resolving Price of Product '%v' in Price List '%v'.\n", id, listCode)
 
-		uoms, err := uomRepo.GetByProduct(r.Context(), lastID)
-		if err != nil {
-			prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+	if listCode == "" {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Price list must be specified.")
+		return
+	}
+
+	qty := decimal.New(1, 0)
+	if text := query.Get("qty"); text != "" {
+		qty, err = decimal.Parse(text)
+		if err != nil || qty.Sign() <= 0 {
+			prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid quantity.")
 			return
 		}
-		result.UnitOfMeasures = uoms
+	}
 
-		fields, err := fieldRepo.GetByProduct(r.Context(), lastID)
+	date := time.Now()
+	if text := query.Get("date"); text != "" {
+		date, err = time.Parse(configs.SHORTDATEFORMAT, text)
 		if err != nil {
-			prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+			prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid date.")
 			return
 		}
-		result.CustomFields = fields
 	}
 
-	prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product has been created.")
+	listRepo := pricelistrepository.NewPriceListRepository()
+	list, err := listRepo.GetByID(r.Context(), listCode)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if list == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Price List does not exist.")
+		return
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	prod, err := prodRepo.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if prod == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	priceSvc := priceservice.NewPriceService()
+	result, message, err := priceSvc.Resolve(r.Context(), list, prod, query.Get("uom"), qty, date)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil {
+		prodCtl.WriteProblem(w, http.StatusNotFound, problem.PriceNotFound, nil, message)
+		return
+	}
+
+	prodCtl.WriteResponse(w, http.StatusOK, true, result, "")
 }
 
-// Update - Update product
-func (prodCtl *ProductController) Update(w http.ResponseWriter, r *http.Request) {
+// GetRelations - Return relations from product, or to product with direction=inbound, optionally of a type
+func (prodCtl *ProductController) GetRelations(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id, err := strconv.ParseInt(params["id"], 10, 64)
 
-	log.Printf("Updating Product '%v'.\n", id)
+	query := r.URL.Query()
+	relType := strings.ToUpper(query.Get("type"))
+
+	log.Printf("Retrieving Relations of Product '%v'.\n", id)
+
+	relRepo := productrelationrepository.NewProductRelationRepository()
+
+	var relations []*productrelationmodel.ProductRelation
+	if query.Get("direction") == "inbound" {
+		relations, err = relRepo.GetByRelated(r.Context(), id)
+	} else {
+		relations, err = relRepo.GetByProduct(r.Context(), id)
+	}
+
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	result := make([]*productrelationmodel.ProductRelation, 0)
+	for _, rel := range relations {
+		if relType == "" || rel.GetType() == relType {
+			result = append(result, rel)
+		}
+	}
+
+	prodCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// SetRelations - Replace all relations from product
+func (prodCtl *ProductController) SetRelations(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Setting Relations of Product '%v'.\n", id)
 
 	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
 
+	relations := make([]*productrelationmodel.ProductRelation, 0)
+	err = json.NewDecoder(r.Body).Decode(&relations)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid set relations request.")
+		return
+	}
+
 	prodRepo := productrepository.NewProductRepository()
-	oldProd, err := prodRepo.GetByID(r.Context(), id)
+	prod, err := prodRepo.GetByID(r.Context(), id)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteError(w, err)
 		return
 	}
 
-	updProd := productmodel.NewProduct()
-	err = json.NewDecoder(r.Body).Decode(updProd)
+	if prod == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	relSvc := relationservice.NewRelationService()
+	violations, err := relSvc.ValidateRelations(r.Context(), prod, relations)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid update product request.")
+		prodCtl.WriteError(w, err)
 		return
 	}
 
-	if oldProd.GetVers() != updProd.GetVers() {
-		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid product version.")
+	if !violations.IsValid() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
 		return
 	}
 
-	clgRepo := cataloguerepository.NewCatalogueRepository()
-	clg, err := clgRepo.GetByID(r.Context(), oldProd.GetCatalogueCode())
+	for _, rel := range relations {
+		rel.CreatedBy = authClaims.GetUsername()
+	}
+
+	result, err := relSvc.SetRelations(r.Context(), prod, relations)
+	if err == relationservice.ErrRelationNotCreated {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, err.Error())
+		return
+	} else if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product Relations have been set.")
+}
+
+// Create - Create new product
+func (prodCtl *ProductController) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Creating Product.\n")
+
+	newProd := productmodel.NewProduct()
+	err := json.NewDecoder(r.Body).Decode(newProd)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid catalogue code.")
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid create product request.")
 		return
 	}
 
-	valid, message := updProd.DoValidate(oldProd, clg)
-	if !valid {
-		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, message)
+	prodCtl.doCreate(w, r, newProd)
+}
+
+// Upsert - Create product of code in catalogue or update it, uoms and custom fields left out are deleted
+func (prodCtl *ProductController) Upsert(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["clg_code"]
+	code := params["code"]
+
+	log.Printf("Upserting Product '%v' of Catalogue '%v'.\n", code, clgCode)
+
+	prod := productmodel.NewProduct()
+	err := json.NewDecoder(r.Body).Decode(prod)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid upsert product request.")
 		return
 	}
 
-	oldProd.Description = updProd.GetDescription()
-	oldProd.Details = updProd.GetDetails()
-	oldProd.Status = updProd.GetStatus()
-	oldProd.ModifiedBy = authClaims.GetUsername()
+	prod.CatalogueCode = clgCode
+	prod.Code = strings.ToUpper(code)
 
-	nbrRows, err := prodRepo.Update(r.Context(), oldProd)
+	prodSvc := productservice.NewProductService()
+	oldProd, err := prodSvc.GetByCode(r.Context(), clgCode, code)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if oldProd == nil {
+		if prodCtl.HasIfMatch(r) {
+			prodCtl.WriteResponse(w, http.StatusPreconditionFailed, false, nil, "Product version does not match.")
+			return
+		}
+
+		prodCtl.doCreate(w, r, prod)
 		return
 	}
 
-	if nbrRows == 0 {
-		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product was not updated.")
+	if !prodCtl.IsIfMatchMet(r, oldProd.GetVers()) {
+		prodCtl.WriteETag(w, oldProd.GetVers())
+		prodCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldProd, "Product version does not match.")
 		return
 	}
 
-	result, err := prodRepo.GetByID(r.Context(), oldProd.GetID())
+	prod.Vers = oldProd.GetVers()
+	prod.MatchKeys(oldProd)
+	prod.MarkChanges(oldProd)
+
+	prodCtl.doUpdate(w, r, oldProd, prod, nil)
+}
+
+// doCreate - Creates product, variant when it has a parent
+func (prodCtl *ProductController) doCreate(w http.ResponseWriter, r *http.Request, newProd *productmodel.Product) {
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), newProd.GetCatalogueCode())
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid catalogue code.")
 		return
 	}
 
-	if result != nil {
-		uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
-		for _, updUom := range updProd.GetAllUoms() {
-			oldUom := oldProd.GetUom(updUom.GetID())
-
-			if updUom.GetChangeMode() == changemode.Add {
-				if oldUom == nil {
-					updUom.ProdID = oldProd.GetID()
-					updUom.Vers = 1
-
-					lastUomID, err := uomRepo.Create(r.Context(), updUom)
-					if err != nil {
-						prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-						return
-					}
-
-					if lastUomID == 0 {
-						prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure was not created.")
-						return
-					}
-				}
+	if clg == nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid catalogue code.")
+		return
+	}
 
-			} else if updUom.GetChangeMode() == changemode.Update {
-				if !updUom.IsEqual(oldUom) {
-					oldUom.Code = updUom.GetCode()
-					oldUom.Description = updUom.GetDescription()
-					oldUom.Ratio = updUom.GetRatio()
-
-					_, err := uomRepo.Update(r.Context(), oldUom)
-					if err != nil {
-						prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-						return
-					}
-				}
+	// Product is active unless created as draft
+	if newProd.GetStatus() == "" {
+		newProd.Status = lifecycle.Active.String()
+	}
 
-			} else if updUom.GetChangeMode() == changemode.Delete {
-				nbrRow, err := uomRepo.Delete(r.Context(), oldUom.GetID())
-				if err != nil {
-					prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-					return
-				}
+	parent, ok := prodCtl.getParent(w, r, newProd)
+	if !ok {
+		return
+	}
 
-				if nbrRow == 0 {
-					prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure was not deleted.")
-					return
-				}
+	if parent != nil {
+		newProd.Inherit(parent)
+	}
 
-			}
-		}
+	err = newProd.ApplyDefaults(clg)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, err.Error())
+		return
+	}
 
-		fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
-		for _, updfield := range updProd.GetAllCustomFields() {
-			updfield.ProdID = oldProd.GetID()
+	violations := newProd.DoValidate(nil, clg)
+	if parent != nil {
+		violations.Append("", newProd.DoValidateVariant(parent, clg))
+	}
 
-			_, err := fieldRepo.Update(r.Context(), updfield)
-			if err != nil {
-				prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-				return
-			}
-		}
+	if !violations.IsValid() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
 
-		uoms, err := uomRepo.GetByProduct(r.Context(), oldProd.GetID())
-		if err != nil {
-			prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-			return
-		}
-		result.UnitOfMeasures = uoms
+	if parent != nil {
+		newProd.DropInherited(parent)
+	}
 
-		fields, err := fieldRepo.GetByProduct(r.Context(), oldProd.GetID())
-		if err != nil {
-			prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
-			return
-		}
-		result.CustomFields = fields
+	prodSvc := productservice.NewProductService()
+	unique, message, err := prodSvc.ValidateUnique(r.Context(), nil, newProd, clg)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
 	}
 
-	prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product has been updated.")
+	if !unique {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, message)
+		return
+	}
+
+	if !prodCtl.validateVariant(w, r, parent, newProd, clg) {
+		return
+	}
+
+	valid, message, err := prodSvc.ValidateUoms(r.Context(), newProd)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if !valid {
+		prodCtl.WriteProblem(w, http.StatusUnprocessableEntity, problem.ReferenceViolation, nil, message)
+		return
+	}
+
+	newProd.CreatedBy = authClaims.GetUsername()
+	newProd.ModifiedBy = authClaims.GetUsername()
+	newProd.Vers = 1
+
+	result, err := prodSvc.Create(r.Context(), newProd)
+	if err != nil {
+		prodCtl.writeServiceError(w, err)
+		return
+	}
+
+	prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product has been created.")
 }
 
-// Delete - Delete product
-func (prodCtl *ProductController) Delete(w http.ResponseWriter, r *http.Request) {
+// Copy - Copy product to catalogue of to, listing custom fields and categories that could not be mapped
+func (prodCtl *ProductController) Copy(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id, err := strconv.ParseInt(params["id"], 10, 64)
+	toClgCode := r.URL.Query().Get("to")
 
-	log.Printf("Deleting Product '%v'.\n", id)
+	log.Printf("Copying Product '%v' to Catalogue '%v'.\n", id, toClgCode)
 
-	prodRepo := productrepository.NewProductRepository()
-	nbrRows, err := prodRepo.Delete(r.Context(), id)
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	if toClgCode == "" {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Catalogue to copy product to must be specified.")
+		return
+	}
+
+	prodSvc := productservice.NewProductService()
+	prod, err := prodSvc.GetByID(r.Context(), id)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteError(w, err)
 		return
 	}
 
-	if nbrRows == 0 {
+	if prod == nil {
 		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
 		return
 	}
 
-	// Also delete all related unit of measures
-	uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
-	err = uomRepo.DeleteByProduct(r.Context(), id)
+	if prod.IsVariant() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Variant can not be copied to another catalogue.")
+		return
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), prod.GetCatalogueCode())
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteError(w, err)
 		return
 	}
 
-	// Also delete all related custom fields
-	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
-	err = fieldRepo.DeleteByProduct(r.Context(), id)
+	toClg, err := clgRepo.GetByID(r.Context(), toClgCode)
 	if err != nil {
-		prodCtl.WriteResponse(w, http.StatusInternalServerError, false, nil, err.Error())
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil || toClg == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	result := prod.NewCopy(clg, toClg, clg.MapFieldDefinitions(toClg), clg.MapCategories(toClg))
+	newProd := result.Product
+
+	// Copy starts a new lifecycle
+	if !lifecycle.IsInitial(newProd.GetStatus()) {
+		newProd.Status = lifecycle.Draft.String()
+	}
+
+	err = newProd.ApplyDefaults(toClg)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, err.Error())
+		return
+	}
+
+	violations := newProd.DoValidate(nil, toClg)
+	if !violations.IsValid() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	unique, message, err := prodSvc.ValidateUnique(r.Context(), nil, newProd, toClg)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if !unique {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, message)
+		return
+	}
+
+	valid, message, err := prodSvc.ValidateUoms(r.Context(), newProd)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if !valid {
+		prodCtl.WriteProblem(w, http.StatusUnprocessableEntity, problem.ReferenceViolation, nil, message)
+		return
+	}
+
+	newProd.CreatedBy = authClaims.GetUsername()
+	newProd.ModifiedBy = authClaims.GetUsername()
+	newProd.Vers = 1
+
+	result.Product, err = prodSvc.Create(r.Context(), newProd)
+	if err != nil {
+		prodCtl.writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/v1/products/%d", result.Product.GetID()))
+	prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product has been copied.")
+}
+
+// Move - Move product along with its variants to catalogue of to, unmapped values are dropped when drop_unmapped=true
+func (prodCtl *ProductController) Move(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	query := r.URL.Query()
+	toClgCode := query.Get("to")
+	dropUnmapped := query.Get("drop_unmapped") == "true"
+
+	log.Printf("Moving Product '%v' to Catalogue '%v'.\n", id, toClgCode)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	if toClgCode == "" {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Catalogue to move product to must be specified.")
+		return
+	}
+
+	// Product and variants are read as stored, without the values variants inherit
+	prodRepo := productrepository.NewProductRepository()
+	oldProd, err := prodRepo.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if oldProd == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	if oldProd.IsVariant() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Variant can not be moved to another catalogue, move its parent instead.")
+		return
+	}
+
+	if !prodCtl.IsIfMatchMet(r, oldProd.GetVers()) {
+		prodCtl.WriteETag(w, oldProd.GetVers())
+		prodCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldProd, "Product version does not match.")
+		return
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), oldProd.GetCatalogueCode())
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	toClg, err := clgRepo.GetByID(r.Context(), toClgCode)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil || toClg == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	if clg.GetCode() == toClg.GetCode() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, fmt.Sprintf("Product is already in catalogue '%s'.", toClg.GetCode()))
+		return
+	}
+
+	changeRepo := changerequestrepository.NewChangeRequestRepository()
+	pending, err := changeRepo.GetPendingByProduct(r.Context(), oldProd.GetID())
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if pending != nil {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Conflict, pending, "Product has a pending change request.")
+		return
+	}
+
+	variants, err := prodRepo.GetByParent(r.Context(), oldProd.GetID())
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	fieldIDs := clg.MapFieldDefinitions(toClg)
+	categoryIDs := clg.MapCategories(toClg)
+
+	movedProd := *oldProd
+	result := movedProd.MoveTo(clg, toClg, fieldIDs, categoryIDs)
+
+	movedVariants := make([]*productmodel.Product, 0, len(variants))
+	for _, variant := range variants {
+		movedVariant := *variant
+		transfer := movedVariant.MoveTo(clg, toClg, fieldIDs, categoryIDs)
+		result.UnmappedFields = appendMissing(result.UnmappedFields, transfer.UnmappedFields...)
+		result.UnmappedCategories = appendMissing(result.UnmappedCategories, transfer.UnmappedCategories...)
+		movedVariants = append(movedVariants, &movedVariant)
+	}
+
+	if !result.IsTransferred() && !dropUnmapped {
+		result.Product = nil
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Conflict, result, fmt.Sprintf("Product has values that can not be mapped to catalogue '%s'.", toClg.GetCode()))
+		return
+	}
+
+	err = movedProd.ApplyDefaults(toClg)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, err.Error())
+		return
+	}
+
+	violations := movedProd.DoValidate(oldProd, toClg)
+
+	// Variants are validated along with the values they inherit from the moved parent
+	for i, movedVariant := range movedVariants {
+		movedVariant.Inherit(&movedProd)
+
+		variantViolations := movedVariant.DoValidate(variants[i], toClg)
+		variantViolations.Append("", movedVariant.DoValidateVariant(&movedProd, toClg))
+		violations.Append(validation.Pointer("variants", i), variantViolations)
+
+		movedVariant.DropInherited(&movedProd)
+	}
+
+	if !violations.IsValid() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	prodSvc := productservice.NewProductService()
+	for i, moved := range append([]*productmodel.Product{&movedProd}, movedVariants...) {
+		otherProd := oldProd
+		if i > 0 {
+			otherProd = variants[i-1]
+		}
+
+		unique, message, err := prodSvc.ValidateUnique(r.Context(), otherProd, moved, toClg)
+		if err != nil {
+			prodCtl.WriteError(w, err)
+			return
+		}
+
+		if !unique {
+			prodCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, message)
+			return
+		}
+	}
+
+	now := time.Now()
+	for _, moved := range append([]*productmodel.Product{&movedProd}, movedVariants...) {
+		moved.ModifiedBy = authClaims.GetUsername()
+		moved.ModifiedAt = now
+	}
+
+	result.Product, err = prodSvc.Move(r.Context(), &movedProd, movedVariants)
+	if err == productservice.ErrProductNotUpdated {
+		prodCtl.writeUpdateConflict(w, r, oldProd.GetID())
+		return
+	} else if err != nil {
+		prodCtl.writeServiceError(w, err)
+		return
+	}
+
+	if result.Product != nil {
+		prodCtl.WriteETag(w, result.Product.GetVers())
+	}
+
+	prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product has been moved.")
+}
+
+// Update - Update product
+func (prodCtl *ProductController) Update(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Updating Product '%v'.\n", id)
+
+	prodSvc := productservice.NewProductService()
+	oldProd, err := prodSvc.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if oldProd == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	if !prodCtl.IsIfMatchMet(r, oldProd.GetVers()) {
+		prodCtl.WriteETag(w, oldProd.GetVers())
+		prodCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldProd, "Product version does not match.")
+		return
+	}
+
+	updProd := productmodel.NewProduct()
+	err = json.NewDecoder(r.Body).Decode(updProd)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid update product request.")
+		return
+	}
+
+	prodCtl.doUpdate(w, r, oldProd, updProd, nil)
+}
+
+// Patch - Patch product using merge patch or json patch
+func (prodCtl *ProductController) Patch(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Patching Product '%v'.\n", id)
+
+	prodSvc := productservice.NewProductService()
+	oldProd, err := prodSvc.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if oldProd == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	if !prodCtl.IsIfMatchMet(r, oldProd.GetVers()) {
+		prodCtl.WriteETag(w, oldProd.GetVers())
+		prodCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldProd, "Product version does not match.")
+		return
+	}
+
+	patch, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid patch product request.")
+		return
+	}
+
+	doc, err := json.Marshal(oldProd)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	patchedDoc, err := jsonpatch.Apply(r.Header.Get("Content-Type"), doc, patch)
+	if err == jsonpatch.ErrUnsupportedContentType {
+		prodCtl.WriteResponse(w, http.StatusUnsupportedMediaType, false, nil, err.Error())
+		return
+	} else if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, err.Error())
+		return
+	}
+
+	updProd := productmodel.NewProduct()
+	err = json.Unmarshal(patchedDoc, updProd)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid patch product request.")
+		return
+	}
+
+	updProd.MarkChanges(oldProd)
+
+	prodCtl.doUpdate(w, r, oldProd, updProd, nil)
+}
+
+// doUpdate - Updates product or submits it for approval, change is the approved change request being applied
+func (prodCtl *ProductController) doUpdate(w http.ResponseWriter, r *http.Request, oldProd *productmodel.Product, updProd *productmodel.Product, change *changerequestmodel.ChangeRequest) {
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	// If-Match takes precedence over version in request body
+	if !prodCtl.HasIfMatch(r) && oldProd.GetVers() != updProd.GetVers() {
+		prodCtl.WriteETag(w, oldProd.GetVers())
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, oldProd, "Product has been modified by another user.")
+		return
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), oldProd.GetCatalogueCode())
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid catalogue code.")
+		return
+	}
+
+	// Change is submitted as requested, it is validated again once approved
+	var submitted []byte
+	if change == nil && clg != nil && clg.GetApprovalRequired() {
+		updProd.Vers = oldProd.GetVers()
+		submitted, err = json.Marshal(updProd)
+		if err != nil {
+			prodCtl.WriteError(w, err)
+			return
+		}
+	}
+
+	// Catalogue, code and parent of product can not be changed
+	updProd.CatalogueCode = oldProd.GetCatalogueCode()
+	updProd.Code = oldProd.GetCode()
+	updProd.ParentID = oldProd.GetParentID()
+
+	// Categories left out of request are kept as assigned
+	if updProd.CategoryIDs == nil {
+		updProd.CategoryIDs = oldProd.GetCategoryIDs()
+	}
+
+	parent, ok := prodCtl.getParent(w, r, updProd)
+	if !ok {
+		return
+	}
+
+	if parent != nil {
+		updProd.Inherit(parent)
+	}
+
+	violations := updProd.DoValidate(oldProd, clg)
+	if parent != nil {
+		violations.Append("", updProd.DoValidateVariant(parent, clg))
+	}
+
+	if !violations.IsValid() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	if parent != nil {
+		updProd.DropInherited(parent)
+	}
+
+	prodSvc := productservice.NewProductService()
+	unique, message, err := prodSvc.ValidateUnique(r.Context(), oldProd, updProd, clg)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if !unique {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, message)
+		return
+	}
+
+	updProd.ID = oldProd.GetID()
+	if !prodCtl.validateVariant(w, r, parent, updProd, clg) {
+		return
+	}
+
+	valid, message, err := prodSvc.ValidateUoms(r.Context(), updProd)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if !valid {
+		prodCtl.WriteProblem(w, http.StatusUnprocessableEntity, problem.ReferenceViolation, nil, message)
+		return
+	}
+
+	if submitted != nil {
+		prodCtl.submitChange(w, r, oldProd, submitted)
+		return
+	}
+
+	updProd.ModifiedBy = authClaims.GetUsername()
+
+	var result *productmodel.Product
+	if change != nil {
+		updProd.ModifiedBy = change.GetSubmittedBy()
+
+		now := time.Now()
+		change.Status = reviewstatus.Approved.String()
+		change.ReviewedBy = authClaims.GetUsername()
+		change.ReviewedAt = &now
+
+		result, err = prodSvc.ApplyChange(r.Context(), oldProd, updProd, change)
+	} else {
+		result, err = prodSvc.Update(r.Context(), oldProd, updProd)
+	}
+
+	if err == productservice.ErrProductNotUpdated {
+		prodCtl.writeUpdateConflict(w, r, oldProd.GetID())
+		return
+	} else if err == productservice.ErrChangeAlreadyReviewed {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Conflict, nil, err.Error())
+		return
+	} else if err != nil {
+		prodCtl.writeServiceError(w, err)
+		return
+	}
+
+	if result != nil {
+		prodCtl.WriteETag(w, result.GetVers())
+	}
+
+	if change != nil {
+		prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product change has been approved.")
+		return
+	}
+
+	prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Product has been updated.")
+}
+
+// submitChange - Records update of product as a change request pending approval, product keeps only one pending change
+func (prodCtl *ProductController) submitChange(w http.ResponseWriter, r *http.Request, oldProd *productmodel.Product, submitted []byte) {
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	submittedProd := productmodel.NewProduct()
+	if err := json.Unmarshal(submitted, submittedProd); err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	prodSvc := productservice.NewProductService()
+	newChange, err := prodSvc.SubmitChange(r.Context(), oldProd, submittedProd, authClaims.GetUsername())
+	if err == productservice.ErrChangePending {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Conflict, newChange, err.Error())
+		return
+	} else if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/v1/products/%d/changes", oldProd.GetID()))
+	prodCtl.WriteResponse(w, http.StatusAccepted, true, newChange, "Product change has been submitted for approval.")
+}
+
+// GetChanges - Return change requests of product from the latest one, which is its review history
+func (prodCtl *ProductController) GetChanges(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, _ := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Retrieving Change Requests of Product '%v'.\n", id)
+
+	changeRepo := changerequestrepository.NewChangeRequestRepository()
+	prodCtl.WriteStream(w, r, func(write func(interface{}) error) error {
+		return changeRepo.ForEachByProduct(r.Context(), id, func(change *changerequestmodel.ChangeRequest) error {
+			return write(change)
+		})
+	})
+}
+
+// GetChangesByCatalogue - Return change requests of catalogue of review status, pending by default
+func (prodCtl *ProductController) GetChangesByCatalogue(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	log.Printf("Retrieving Change Requests of Catalogue '%v'.\n", clgCode)
+
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+	switch status {
+	case "":
+		status = reviewstatus.Pending.String()
+	case "ALL":
+		status = ""
+	case reviewstatus.Pending.String(), reviewstatus.Approved.String(), reviewstatus.Rejected.String():
+	default:
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid review status.")
+		return
+	}
+
+	changeRepo := changerequestrepository.NewChangeRequestRepository()
+	prodCtl.WriteStream(w, r, func(write func(interface{}) error) error {
+		return changeRepo.ForEachByCatalogue(r.Context(), strings.ToUpper(clgCode), status, func(change *changerequestmodel.ChangeRequest) error {
+			return write(change)
+		})
+	})
+}
+
+// ApproveChange - Approve pending change request of product and apply it, request body optionally holds a comment
+func (prodCtl *ProductController) ApproveChange(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	log.Printf("Approving Change Request '%v' of Product '%v'.\n", params["change_id"], params["id"])
+
+	change, review, oldProd, ok := prodCtl.getChangeForReview(w, r, true)
+	if !ok {
+		return
+	}
+
+	// Change was validated against the version it was submitted on
+	if oldProd.GetVers() != change.GetBaseVers() {
+		prodCtl.WriteETag(w, oldProd.GetVers())
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, oldProd, "Product has been modified since change was submitted.")
+		return
+	}
+
+	change.Comment = review.GetComment()
+
+	prodCtl.doUpdate(w, r, oldProd, change.GetProduct(), change)
+}
+
+// RejectChange - Reject pending change request of product, request body holds the reason as comment
+func (prodCtl *ProductController) RejectChange(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	log.Printf("Rejecting Change Request '%v' of Product '%v'.\n", params["change_id"], params["id"])
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	change, review, _, ok := prodCtl.getChangeForReview(w, r, false)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	change.Status = reviewstatus.Rejected.String()
+	change.ReviewedBy = authClaims.GetUsername()
+	change.ReviewedAt = &now
+	change.Comment = review.GetComment()
+
+	changeRepo := changerequestrepository.NewChangeRequestRepository()
+	nbrReviewed, err := changeRepo.Review(r.Context(), change)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrReviewed == 0 {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Conflict, nil, "Product Change Request has already been reviewed.")
+		return
+	}
+
+	prodCtl.WriteResponse(w, http.StatusAccepted, true, change, "Product change has been rejected.")
+}
+
+// getChangeForReview - Returns pending change request, its review and product, when user may review it
+func (prodCtl *ProductController) getChangeForReview(w http.ResponseWriter, r *http.Request, approve bool) (*changerequestmodel.ChangeRequest, *changerequestmodel.Review, *productmodel.Product, bool) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid product id.")
+		return nil, nil, nil, false
+	}
+
+	changeID, err := strconv.ParseInt(params["change_id"], 10, 64)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid change request id.")
+		return nil, nil, nil, false
+	}
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid review request.")
+		return nil, nil, nil, false
+	}
+
+	review := changerequestmodel.NewReview()
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, review); err != nil {
+			prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid review request.")
+			return nil, nil, nil, false
+		}
+	}
+
+	review.Comment = strings.TrimSpace(review.Comment)
+	violations := review.DoValidate(approve)
+	if !violations.IsValid() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return nil, nil, nil, false
+	}
+
+	changeRepo := changerequestrepository.NewChangeRequestRepository()
+	change, err := changeRepo.GetByID(r.Context(), changeID)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return nil, nil, nil, false
+	}
+
+	if change == nil || change.GetProdID() != id {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product Change Request does not exist.")
+		return nil, nil, nil, false
+	}
+
+	if !change.IsPending() {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Conflict, change, "Product Change Request has already been reviewed.")
+		return nil, nil, nil, false
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), change.GetCatalogueCode())
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return nil, nil, nil, false
+	}
+
+	if clg == nil || !clg.IsReviewer(authClaims.GetUsername()) {
+		prodCtl.WriteProblem(w, http.StatusForbidden, problem.Forbidden, nil, "Product Change Request can only be reviewed by a reviewer of catalogue.")
+		return nil, nil, nil, false
+	}
+
+	if strings.EqualFold(change.GetSubmittedBy(), authClaims.GetUsername()) {
+		prodCtl.WriteProblem(w, http.StatusForbidden, problem.Forbidden, nil, "Product Change Request can not be reviewed by its submitter.")
+		return nil, nil, nil, false
+	}
+
+	prodSvc := productservice.NewProductService()
+	oldProd, err := prodSvc.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return nil, nil, nil, false
+	}
+
+	if oldProd == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return nil, nil, nil, false
+	}
+
+	return change, review, oldProd, true
+}
+
+// GenerateVariants - Generate missing variants of product for the combinations of variant axis values
+func (prodCtl *ProductController) GenerateVariants(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Generating Variants of Product '%v'.\n", id)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid generate variants request.")
+		return
+	}
+
+	axisValues := make([]*productmodel.VariantAxisValues, 0)
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &axisValues); err != nil {
+			prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid generate variants request.")
+			return
+		}
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	parent, err := prodRepo.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if parent == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), parent.GetCatalogueCode())
+	if err != nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid catalogue code.")
+		return
+	}
+
+	variants, violations := productmodel.NewVariants(parent, clg, axisValues)
+	if !violations.IsValid() {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	existingVariants, err := prodRepo.GetByParent(r.Context(), parent.GetID())
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	axes := clg.GetVariantAxes()
+	keys := make(map[string]bool)
+	for _, variant := range existingVariants {
+		keys[variant.GetVariantKey(axes)] = true
+	}
+
+	// All missing variants are validated before any of them is created
+	prodSvc := productservice.NewProductService()
+	newVariants := make([]*productmodel.Product, 0)
+	for _, variant := range variants {
+		if keys[variant.GetVariantKey(axes)] {
+			continue
+		}
+
+		variant.Inherit(parent)
+
+		violations := variant.DoValidate(nil, clg)
+		violations.Append("", variant.DoValidateVariant(parent, clg))
+		if !violations.IsValid() {
+			prodCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+			return
+		}
+
+		variant.DropInherited(parent)
+
+		unique, message, err := prodSvc.ValidateUnique(r.Context(), nil, variant, clg)
+		if err != nil {
+			prodCtl.WriteError(w, err)
+			return
+		}
+
+		if !unique {
+			prodCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, message)
+			return
+		}
+
+		newVariants = append(newVariants, variant)
+	}
+
+	result := make([]*productmodel.Product, 0)
+	for _, variant := range newVariants {
+		variant.CreatedBy = authClaims.GetUsername()
+		variant.ModifiedBy = authClaims.GetUsername()
+		variant.Vers = 1
+
+		newVariant, err := prodSvc.Create(r.Context(), variant)
+		if err != nil {
+			prodCtl.writeServiceError(w, err)
+			return
+		}
+
+		result = append(result, newVariant)
+	}
+
+	prodCtl.WriteResponse(w, http.StatusAccepted, true, result, "Variants have been generated.")
+}
+
+// Delete - Delete product
+func (prodCtl *ProductController) Delete(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.ParseInt(params["id"], 10, 64)
+
+	log.Printf("Deleting Product '%v'.\n", id)
+
+	// Product is deleted only while it still has the version matched by If-Match
+	var vers int64
+	prodRepo := productrepository.NewProductRepository()
+	if prodCtl.HasIfMatch(r) {
+		oldProd, err := prodRepo.GetByID(r.Context(), id)
+		if err != nil {
+			prodCtl.WriteError(w, err)
+			return
+		}
+
+		if oldProd == nil {
+			prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+			return
+		}
+
+		if !prodCtl.IsIfMatchMet(r, oldProd.GetVers()) {
+			prodCtl.WriteETag(w, oldProd.GetVers())
+			prodCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldProd, "Product version does not match.")
+			return
+		}
+
+		vers = oldProd.GetVers()
+	}
+
+	prodSvc := productservice.NewProductService()
+	err = prodSvc.Delete(r.Context(), id, vers)
+	if err == productservice.ErrProductHasVariants {
+		prodCtl.WriteResponse(w, http.StatusConflict, false, nil, err.Error())
+		return
+	}
+
+	if err == productservice.ErrProductNotDeleted && vers > 0 {
+		prodCtl.writeDeleteConflict(w, r, id)
+		return
+	}
+
+	if err == productservice.ErrProductNotDeleted {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	if err != nil {
+		prodCtl.WriteError(w, err)
 		return
 	}
 
 	prodCtl.WriteResponse(w, http.StatusOK, true, nil, "Product has been deleted.")
 }
+
+// appendMissing - Appends values to list which it does not hold yet
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, item := range list {
+			if item == value {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			list = append(list, value)
+		}
+	}
+
+	return list
+}
+
+// getParent - Returns parent of variant, nil when product is not a variant, false once failure response is written
+func (prodCtl *ProductController) getParent(w http.ResponseWriter, r *http.Request, prod *productmodel.Product) (*productmodel.Product, bool) {
+	if !prod.IsVariant() {
+		return nil, true
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	parent, err := prodRepo.GetByID(r.Context(), prod.GetParentID())
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return nil, false
+	}
+
+	if parent == nil {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Parent product does not exist.")
+		return nil, false
+	}
+
+	return parent, true
+}
+
+// validateVariant - Validate variant axis values are not used by other variants of parent, writing the failure response
+func (prodCtl *ProductController) validateVariant(w http.ResponseWriter, r *http.Request, parent *productmodel.Product, prod *productmodel.Product, clg *cataloguemodel.Catalogue) bool {
+	if parent == nil {
+		return true
+	}
+
+	prodSvc := productservice.NewProductService()
+	valid, message, err := prodSvc.ValidateVariant(r.Context(), parent, prod, clg)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return false
+	}
+
+	if !valid {
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, message)
+		return false
+	}
+
+	return true
+}
+
+func (prodCtl *ProductController) writeUpdateConflict(w http.ResponseWriter, r *http.Request, id int64) {
+	prodSvc := productservice.NewProductService()
+	current, err := prodSvc.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if current == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	prodCtl.WriteETag(w, current.GetVers())
+	prodCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, current, "Product has been modified by another user.")
+}
+
+func (prodCtl *ProductController) writeDeleteConflict(w http.ResponseWriter, r *http.Request, id int64) {
+	prodRepo := productrepository.NewProductRepository()
+	current, err := prodRepo.GetByID(r.Context(), id)
+	if err != nil {
+		prodCtl.WriteError(w, err)
+		return
+	}
+
+	if current == nil {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Product does not exist.")
+		return
+	}
+
+	prodCtl.WriteETag(w, current.GetVers())
+	prodCtl.WriteResponse(w, http.StatusPreconditionFailed, false, current, "Product version does not match.")
+}
+
+func (prodCtl *ProductController) writeServiceError(w http.ResponseWriter, err error) {
+	switch err {
+	case productservice.ErrProductNotCreated,
+		productservice.ErrUomNotCreated,
+		productservice.ErrUomNotDeleted,
+		productservice.ErrCustomFieldNotCreated,
+		productservice.ErrCustomFieldNotDeleted,
+		productservice.ErrCategoryNotAssigned:
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, err.Error())
+
+	case productservice.ErrProductCodeExists:
+		prodCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, err.Error())
+
+	default:
+		prodCtl.WriteError(w, err)
+
+	}
+}
+
+// getFamilySource - Returns iteration of products of catalogue as read from requested revision
+func (prodCtl *ProductController) getFamilySource(w http.ResponseWriter, r *http.Request, clgCode string) (func(func(*productmodel.Product) error) error, bool) {
+	rev, ok := prodCtl.getReadRevision(w, r, clgCode)
+	if !ok {
+		return nil, false
+	}
+
+	if rev == nil {
+		return func(fn func(*productmodel.Product) error) error {
+			prodRepo := productrepository.NewProductRepository()
+			return prodRepo.ForEachFamilyByCatalogue(r.Context(), clgCode, fn)
+		}, true
+	}
+
+	return func(fn func(*productmodel.Product) error) error {
+		revProdRepo := revisionproductrepository.NewRevisionProductRepository()
+		return revProdRepo.ForEachByRevision(r.Context(), rev.GetID(), fn)
+	}, true
+}
+
+// getReadRevision - Returns requested revision of catalogue, nil to read the draft
+func (prodCtl *ProductController) getReadRevision(w http.ResponseWriter, r *http.Request, clgCode string) (*revisionmodel.Revision, bool) {
+	revSvc := revisionservice.NewRevisionService()
+	rev, err := revSvc.GetForReading(r.Context(), clgCode, r.URL.Query().Get("revision"))
+	if err == revisionservice.ErrInvalidRevision {
+		prodCtl.WriteResponse(w, http.StatusBadRequest, false, nil, err.Error())
+		return nil, false
+	} else if err == revisionservice.ErrRevisionNotFound {
+		prodCtl.WriteResponse(w, http.StatusNotFound, false, nil, err.Error())
+		return nil, false
+	} else if err != nil {
+		prodCtl.WriteError(w, err)
+		return nil, false
+	}
+
+	return rev, true
+}
+
+// getStatusFilter - Returns whether product is listed by lifecycle statuses of status query parameter
+func (prodCtl *ProductController) getStatusFilter(r *http.Request) func(*productmodel.Product) bool {
+	statuses := make(map[string]bool)
+	for _, value := range strings.Split(r.URL.Query().Get("status"), ",") {
+		if value = strings.ToUpper(strings.TrimSpace(value)); value != "" {
+			statuses[value] = true
+		}
+	}
+
+	return func(product *productmodel.Product) bool {
+		return len(statuses) == 0 || statuses[product.GetStatus()]
+	}
+}
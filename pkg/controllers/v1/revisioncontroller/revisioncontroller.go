@@ -0,0 +1,208 @@
+package revisioncontroller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
+	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
+	revisionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/revision"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/revisionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/revisionservice"
+	"github.com/gorilla/mux"
+)
+
+// RevisionController type
+type RevisionController struct {
+	basecontroller.BaseResource
+}
+
+// NewRevisionController - Creates revision controller
+func NewRevisionController() *RevisionController {
+	return &RevisionController{}
+}
+
+// Publish - Freeze catalogue and its products as a new published revision, request body optionally holds its label
+func (revCtl *RevisionController) Publish(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	log.Printf("Publishing Catalogue '%v'.\n", clgCode)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		revCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid publish catalogue request.")
+		return
+	}
+
+	publish := &revisionmodel.Publish{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, publish); err != nil {
+			revCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid publish catalogue request.")
+			return
+		}
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(r.Context(), clgCode)
+	if err != nil {
+		revCtl.WriteError(w, err)
+		return
+	}
+
+	if clg == nil {
+		revCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue does not exist.")
+		return
+	}
+
+	newRev := revisionmodel.NewRevision()
+	newRev.Label = strings.TrimSpace(publish.Label)
+	violations := newRev.DoValidate()
+	if !violations.IsValid() {
+		revCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	revSvc := revisionservice.NewRevisionService()
+	result, err := revSvc.Publish(r.Context(), clg, newRev.GetLabel(), authClaims.GetUsername())
+	if err == revisionservice.ErrRevisionLabelExists {
+		message := fmt.Sprintf("Catalogue Revision '%s' already exists.", newRev.GetLabel())
+		revCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, message)
+		return
+	} else if err == revisionservice.ErrRevisionNotCreated {
+		revCtl.WriteResponse(w, http.StatusNotFound, false, nil, err.Error())
+		return
+	} else if err != nil {
+		revCtl.WriteError(w, err)
+		return
+	}
+
+	// Snapshot is retrieved from the revision itself
+	result.Snapshot = nil
+
+	w.Header().Set("Location", fmt.Sprintf("/v1/catalogues/%s/revisions/%d", result.GetCatalogueCode(), result.GetRevision()))
+	revCtl.WriteResponse(w, http.StatusAccepted, true, result, "Catalogue has been published.")
+}
+
+// GetByCatalogue - Return all published revisions of catalogue from the latest one, without their snapshots
+func (revCtl *RevisionController) GetByCatalogue(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	log.Printf("Retrieving Revisions of Catalogue '%v'.\n", clgCode)
+
+	revRepo := revisionrepository.NewRevisionRepository()
+	revCtl.WriteStream(w, r, func(write func(interface{}) error) error {
+		return revRepo.ForEachByCatalogue(r.Context(), strings.ToUpper(clgCode), func(rev *revisionmodel.Revision) error {
+			return write(rev)
+		})
+	})
+}
+
+// GetByRevision - Return published revision of catalogue with its snapshot, revision is either a number or latest
+func (revCtl *RevisionController) GetByRevision(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	log.Printf("Retrieving Revision '%v' of Catalogue '%v'.\n", params["revision"], clgCode)
+
+	result, ok := revCtl.getRevision(w, r, clgCode, params["revision"])
+	if !ok {
+		return
+	}
+
+	revSvc := revisionservice.NewRevisionService()
+	if err := revSvc.LoadProducts(r.Context(), result); err != nil {
+		revCtl.WriteError(w, err)
+		return
+	}
+
+	revCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// GetDiff - Return changes of published revision since revision of from, from defaults to the previous revision
+func (revCtl *RevisionController) GetDiff(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	clgCode := params["id"]
+
+	log.Printf("Comparing Revision '%v' of Catalogue '%v'.\n", params["revision"], clgCode)
+
+	toRev, ok := revCtl.getRevision(w, r, clgCode, params["revision"])
+	if !ok {
+		return
+	}
+
+	fromRevision := toRev.GetRevision() - 1
+	if value := r.URL.Query().Get("from"); value != "" {
+		var err error
+		fromRevision, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || fromRevision < 0 {
+			revCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid revision to compare from.")
+			return
+		}
+	}
+
+	// Revision zero is the catalogue before it was first published
+	var fromRev *revisionmodel.Revision
+	if fromRevision > 0 {
+		fromRev, ok = revCtl.getRevision(w, r, clgCode, strconv.FormatInt(fromRevision, 10))
+		if !ok {
+			return
+		}
+	}
+
+	revSvc := revisionservice.NewRevisionService()
+	for _, rev := range []*revisionmodel.Revision{fromRev, toRev} {
+		if rev == nil {
+			continue
+		}
+
+		if err := revSvc.LoadProducts(r.Context(), rev); err != nil {
+			revCtl.WriteError(w, err)
+			return
+		}
+	}
+
+	revCtl.WriteResponse(w, http.StatusOK, true, revisionmodel.NewDiff(fromRev, toRev), "")
+}
+
+// getRevision - Returns revision of catalogue, writes not found when it does not exist
+func (revCtl *RevisionController) getRevision(w http.ResponseWriter, r *http.Request, clgCode string, revision string) (*revisionmodel.Revision, bool) {
+	revRepo := revisionrepository.NewRevisionRepository()
+
+	var result *revisionmodel.Revision
+	var err error
+	if revision == "latest" {
+		result, err = revRepo.GetLatest(r.Context(), strings.ToUpper(clgCode))
+	} else {
+		number, parseErr := strconv.ParseInt(revision, 10, 64)
+		if parseErr != nil {
+			revCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid revision.")
+			return nil, false
+		}
+		result, err = revRepo.GetByRevision(r.Context(), strings.ToUpper(clgCode), number)
+	}
+
+	if err != nil {
+		revCtl.WriteError(w, err)
+		return nil, false
+	}
+
+	if result == nil {
+		revCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Catalogue Revision does not exist.")
+		return nil, false
+	}
+
+	return result, true
+}
@@ -0,0 +1,273 @@
+package uomcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
+	"github.com/bungysheep/catalogue-api/pkg/controllers/v1/basecontroller"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
+	uommastermodel "github.com/bungysheep/catalogue-api/pkg/models/v1/uommaster"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/unitofmeasurerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/uommasterrepository"
+	"github.com/gorilla/mux"
+)
+
+// UomController type
+type UomController struct {
+	basecontroller.BaseResource
+}
+
+// NewUomController - Creates unit of measure master controller
+func NewUomController() *UomController {
+	return &UomController{}
+}
+
+// GetAll - Return all units of measure master
+func (uomCtl *UomController) GetAll(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Retrieving all Units of Measure.\n")
+
+	masterRepo := uommasterrepository.NewUomMasterRepository()
+	result, err := masterRepo.GetAll(r.Context())
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	uomCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// GetByID - Return a unit of measure master
+func (uomCtl *UomController) GetByID(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["code"]
+
+	log.Printf("Retrieving Unit of Measure '%v'.\n", code)
+
+	masterRepo := uommasterrepository.NewUomMasterRepository()
+	result, err := masterRepo.GetByID(r.Context(), code)
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	if result == nil {
+		uomCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure does not exist.")
+		return
+	}
+
+	uomCtl.WriteETag(w, result.GetVers())
+	uomCtl.WriteResponse(w, http.StatusOK, true, result, "")
+}
+
+// Create - Create new unit of measure master
+func (uomCtl *UomController) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Creating Unit of Measure.\n")
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	newMaster := uommastermodel.NewUomMaster()
+	err := json.NewDecoder(r.Body).Decode(newMaster)
+	if err != nil {
+		uomCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid create unit of measure request.")
+		return
+	}
+
+	violations := newMaster.DoValidate(nil)
+	if !violations.IsValid() {
+		uomCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	masterRepo := uommasterrepository.NewUomMasterRepository()
+	oldMaster, err := masterRepo.GetByID(r.Context(), newMaster.GetCode())
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	if oldMaster != nil {
+		uomCtl.WriteProblem(w, http.StatusConflict, problem.Duplicate, nil, fmt.Sprintf("Unit of Measure '%s' already exists.", newMaster.GetCode()))
+		return
+	}
+
+	newMaster.CreatedBy = authClaims.GetUsername()
+	newMaster.CreatedAt = time.Now()
+	newMaster.ModifiedBy = authClaims.GetUsername()
+	newMaster.ModifiedAt = time.Now()
+
+	nbrRows, err := masterRepo.Create(r.Context(), newMaster)
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 {
+		uomCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure was not created.")
+		return
+	}
+
+	result, err := masterRepo.GetByID(r.Context(), newMaster.GetCode())
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	if result != nil {
+		uomCtl.WriteETag(w, result.GetVers())
+	}
+
+	uomCtl.WriteResponse(w, http.StatusAccepted, true, result, "Unit of Measure has been created.")
+}
+
+// Update - Update unit of measure master, factor of unit used by products can not be changed
+func (uomCtl *UomController) Update(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["code"]
+
+	log.Printf("Updating Unit of Measure '%v'.\n", code)
+
+	authClaims := r.Context().Value(contextkey.ClaimToken).(signinclaimresource.SignInClaimResource)
+
+	updMaster := uommastermodel.NewUomMaster()
+	err := json.NewDecoder(r.Body).Decode(updMaster)
+	if err != nil {
+		uomCtl.WriteResponse(w, http.StatusBadRequest, false, nil, "Invalid update unit of measure request.")
+		return
+	}
+	updMaster.Code = code
+
+	masterRepo := uommasterrepository.NewUomMasterRepository()
+	oldMaster, err := masterRepo.GetByID(r.Context(), code)
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	if oldMaster == nil {
+		uomCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure does not exist.")
+		return
+	}
+
+	if !uomCtl.IsIfMatchMet(r, oldMaster.GetVers()) {
+		uomCtl.WriteETag(w, oldMaster.GetVers())
+		uomCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldMaster, "Unit of Measure version does not match.")
+		return
+	}
+
+	// If-Match takes precedence over version in request body
+	if !uomCtl.HasIfMatch(r) && oldMaster.GetVers() != updMaster.GetVers() {
+		uomCtl.WriteETag(w, oldMaster.GetVers())
+		uomCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, oldMaster, "Unit of Measure has been modified by another user.")
+		return
+	}
+
+	violations := updMaster.DoValidate(oldMaster)
+	if !violations.IsValid() {
+		uomCtl.WriteResponse(w, http.StatusBadRequest, false, violations, violations.Error())
+		return
+	}
+
+	if updMaster.GetFactor() != oldMaster.GetFactor() {
+		nbrUoms, err := unitofmeasurerepository.NewUnitOfMeasureRepository().CountByCode(r.Context(), oldMaster.GetCode())
+		if err != nil {
+			uomCtl.WriteError(w, err)
+			return
+		}
+
+		if nbrUoms > 0 {
+			uomCtl.WriteResponse(w, http.StatusConflict, false, nil, fmt.Sprintf("Factor of Unit of Measure '%s' can not be changed, it is used by products.", oldMaster.GetCode()))
+			return
+		}
+	}
+
+	oldMaster.Description = updMaster.GetDescription()
+	oldMaster.Factor = updMaster.GetFactor()
+	oldMaster.ModifiedBy = authClaims.GetUsername()
+	oldMaster.ModifiedAt = time.Now()
+
+	nbrRows, err := masterRepo.Update(r.Context(), oldMaster)
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	result, err := masterRepo.GetByID(r.Context(), code)
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 {
+		if result == nil {
+			uomCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure does not exist.")
+			return
+		}
+
+		uomCtl.WriteETag(w, result.GetVers())
+		uomCtl.WriteProblem(w, http.StatusConflict, problem.ConcurrentModification, result, "Unit of Measure has been modified by another user.")
+		return
+	}
+
+	if result != nil {
+		uomCtl.WriteETag(w, result.GetVers())
+	}
+
+	uomCtl.WriteResponse(w, http.StatusAccepted, true, result, "Unit of Measure has been updated.")
+}
+
+// Delete - Delete unit of measure master which is not used by any product
+func (uomCtl *UomController) Delete(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	code := params["code"]
+
+	log.Printf("Deleting Unit of Measure '%v'.\n", code)
+
+	masterRepo := uommasterrepository.NewUomMasterRepository()
+	if uomCtl.HasIfMatch(r) {
+		oldMaster, err := masterRepo.GetByID(r.Context(), code)
+		if err != nil {
+			uomCtl.WriteError(w, err)
+			return
+		}
+
+		if oldMaster == nil {
+			uomCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure does not exist.")
+			return
+		}
+
+		if !uomCtl.IsIfMatchMet(r, oldMaster.GetVers()) {
+			uomCtl.WriteETag(w, oldMaster.GetVers())
+			uomCtl.WriteResponse(w, http.StatusPreconditionFailed, false, oldMaster, "Unit of Measure version does not match.")
+			return
+		}
+	}
+
+	nbrUoms, err := unitofmeasurerepository.NewUnitOfMeasureRepository().CountByCode(r.Context(), code)
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrUoms > 0 {
+		uomCtl.WriteResponse(w, http.StatusConflict, false, nil, fmt.Sprintf("Unit of Measure '%s' is used by products.", code))
+		return
+	}
+
+	nbrRows, err := masterRepo.Delete(r.Context(), code)
+	if err != nil {
+		uomCtl.WriteError(w, err)
+		return
+	}
+
+	if nbrRows == 0 {
+		uomCtl.WriteResponse(w, http.StatusNotFound, false, nil, "Unit of Measure does not exist.")
+		return
+	}
+
+	uomCtl.WriteResponse(w, http.StatusOK, true, nil, "Unit of Measure has been deleted.")
+}
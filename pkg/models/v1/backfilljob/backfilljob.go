@@ -0,0 +1,83 @@
+package backfilljob
+
+import (
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+)
+
+// BackfillJob type
+type BackfillJob struct {
+	basemodel.BaseModel
+	ID            int64     `json:"id"`
+	CatalogueCode string    `json:"clg_code"`
+	FieldID       int64     `json:"field_id"`
+	Status        string    `json:"status"`
+	TotalRows     int64     `json:"total_rows"`
+	ProcessedRows int64     `json:"processed_rows"`
+	UpdatedRows   int64     `json:"updated_rows"`
+	Message       string    `json:"message"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	ModifiedAt    time.Time `json:"modified_at"`
+}
+
+// NewBackfillJob - Creates backfill job
+func NewBackfillJob() *BackfillJob {
+	return &BackfillJob{}
+}
+
+// GetID - Returns backfill job id
+func (job *BackfillJob) GetID() int64 {
+	return job.ID
+}
+
+// GetCatalogueCode - Returns catalogue code
+func (job *BackfillJob) GetCatalogueCode() string {
+	return job.CatalogueCode
+}
+
+// GetFieldID - Returns custom field definition id
+func (job *BackfillJob) GetFieldID() int64 {
+	return job.FieldID
+}
+
+// GetStatus - Returns job status
+func (job *BackfillJob) GetStatus() string {
+	return job.Status
+}
+
+// GetTotalRows - Returns total products of catalogue
+func (job *BackfillJob) GetTotalRows() int64 {
+	return job.TotalRows
+}
+
+// GetProcessedRows - Returns processed products
+func (job *BackfillJob) GetProcessedRows() int64 {
+	return job.ProcessedRows
+}
+
+// GetUpdatedRows - Returns products given the default value
+func (job *BackfillJob) GetUpdatedRows() int64 {
+	return job.UpdatedRows
+}
+
+// GetMessage - Returns job message
+func (job *BackfillJob) GetMessage() string {
+	return job.Message
+}
+
+// GetCreatedBy - Returns created by
+func (job *BackfillJob) GetCreatedBy() string {
+	return job.CreatedBy
+}
+
+// GetCreatedAt - Returns created at
+func (job *BackfillJob) GetCreatedAt() time.Time {
+	return job.CreatedAt
+}
+
+// GetModifiedAt - Returns modified at
+func (job *BackfillJob) GetModifiedAt() time.Time {
+	return job.ModifiedAt
+}
@@ -1,27 +1,45 @@
 package catalogue
 
 import (
-	"time"
-
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/category"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/customfielddefinition"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/customfieldgroup"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/productcustomfield"
 )
 
-// Catalogue type
+// Catalogue type, product changes requiring approval are applied once approved by a reviewer
 type Catalogue struct {
 	basemodel.BaseModel
 	Code                   string                                         `json:"code" mandatory:"true" max_length:"16"`
 	Description            string                                         `json:"description" mandatory:"true" max_length:"32"`
 	Details                string                                         `json:"details" max_length:"64"`
 	Status                 string                                         `json:"status" mandatory:"true" max_length:"1"`
+	ApprovalRequired       bool                                           `json:"approval_required"`
+	Reviewers              []string                                       `json:"reviewers"`
 	CreatedBy              string                                         `json:"created_by"`
 	CreatedAt              time.Time                                      `json:"created_at"`
 	ModifiedBy             string                                         `json:"modified_by"`
 	ModifiedAt             time.Time                                      `json:"modified_at"`
 	Vers                   int64                                          `json:"vers"`
 	CustomFieldDefinitions []*customfielddefinition.CustomFieldDefinition `json:"field_definitions"`
+	FieldGroups            []*customfieldgroup.CustomFieldGroup           `json:"field_groups"`
+	Categories             []*category.Category                           `json:"categories"`
+}
+
+// Clone type, catalogue to create as a copy of an existing one, optionally along with its products
+type Clone struct {
+	basemodel.BaseModel
+	Code            string `json:"code"`
+	Description     string `json:"description"`
+	Details         string `json:"details"`
+	IncludeProducts bool   `json:"include_products"`
 }
 
 // NewCatalogue - Creates catalogue
@@ -49,6 +67,32 @@ func (clg *Catalogue) GetStatus() string {
 	return strings.ToUpper(clg.Status)
 }
 
+// GetApprovalRequired - Returns whether product changes require approval
+func (clg *Catalogue) GetApprovalRequired() bool {
+	return clg.ApprovalRequired
+}
+
+// GetAllReviewers - Returns usernames of users allowed to review product changes
+func (clg *Catalogue) GetAllReviewers() []string {
+	reviewers := make([]string, 0, len(clg.Reviewers))
+	for _, reviewer := range clg.Reviewers {
+		reviewers = append(reviewers, strings.ToUpper(reviewer))
+	}
+
+	return reviewers
+}
+
+// IsReviewer - Whether user of username may review product changes
+func (clg *Catalogue) IsReviewer(username string) bool {
+	for _, reviewer := range clg.GetAllReviewers() {
+		if reviewer == strings.ToUpper(username) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetCreatedBy - Returns created by
 func (clg *Catalogue) GetCreatedBy() string {
 	return clg.CreatedBy
@@ -90,7 +134,300 @@ func (clg *Catalogue) GetCustomFieldDefinition(fieldDefID int64) *customfielddef
 	return nil
 }
 
-// DoValidate - Validate catalogue
-func (clg *Catalogue) DoValidate() (bool, string) {
-	return clg.DoValidateBase(*clg)
+// GetCustomFieldDefinitionByCaption - Returns custom field definition of caption, regardless of case
+func (clg *Catalogue) GetCustomFieldDefinitionByCaption(caption string) *customfielddefinition.CustomFieldDefinition {
+	for _, fieldDef := range clg.CustomFieldDefinitions {
+		if strings.EqualFold(fieldDef.GetCaption(), caption) {
+			return fieldDef
+		}
+	}
+
+	return nil
+}
+
+// GetVariantAxes - Returns custom field definitions variants of a product differ by
+func (clg *Catalogue) GetVariantAxes() []*customfielddefinition.CustomFieldDefinition {
+	axes := make([]*customfielddefinition.CustomFieldDefinition, 0)
+	for _, fieldDef := range clg.CustomFieldDefinitions {
+		if fieldDef.GetVariantAxis() {
+			axes = append(axes, fieldDef)
+		}
+	}
+
+	return axes
+}
+
+// GetAllFieldGroups - Returns all custom field groups
+func (clg *Catalogue) GetAllFieldGroups() []*customfieldgroup.CustomFieldGroup {
+	return clg.FieldGroups
+}
+
+// GetFieldGroup - Returns custom field group
+func (clg *Catalogue) GetFieldGroup(groupID int64) *customfieldgroup.CustomFieldGroup {
+	for _, group := range clg.FieldGroups {
+		if group.GetID() == groupID {
+			return group
+		}
+	}
+
+	return nil
+}
+
+// GetAllCategories - Returns all categories, ordered by path
+func (clg *Catalogue) GetAllCategories() []*category.Category {
+	return clg.Categories
+}
+
+// GetCategory - Returns category
+func (clg *Catalogue) GetCategory(categoryID int64) *category.Category {
+	for _, cat := range clg.Categories {
+		if cat.GetID() == categoryID {
+			return cat
+		}
+	}
+
+	return nil
+}
+
+// GetCategoryByCode - Returns category of code
+func (clg *Catalogue) GetCategoryByCode(code string) *category.Category {
+	for _, cat := range clg.Categories {
+		if cat.GetCode() == strings.ToUpper(code) {
+			return cat
+		}
+	}
+
+	return nil
+}
+
+// IsFieldDefinitionApplicable - Whether definition applies to a product of categories, one without category applies to all
+func (clg *Catalogue) IsFieldDefinitionApplicable(fieldDef *customfielddefinition.CustomFieldDefinition, categoryIDs []int64) bool {
+	if fieldDef.GetCategoryID() == 0 {
+		return true
+	}
+
+	fieldDefCat := clg.GetCategory(fieldDef.GetCategoryID())
+	if fieldDefCat == nil {
+		return false
+	}
+
+	for _, categoryID := range categoryIDs {
+		if cat := clg.GetCategory(categoryID); cat != nil && cat.IsDescendantOf(fieldDefCat) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetApplicableFieldDefinitions - Returns custom field definitions applying to a product of categories
+func (clg *Catalogue) GetApplicableFieldDefinitions(categoryIDs []int64) []*customfielddefinition.CustomFieldDefinition {
+	fieldDefs := make([]*customfielddefinition.CustomFieldDefinition, 0)
+	for _, fieldDef := range clg.CustomFieldDefinitions {
+		if clg.IsFieldDefinitionApplicable(fieldDef, categoryIDs) {
+			fieldDefs = append(fieldDefs, fieldDef)
+		}
+	}
+
+	return fieldDefs
+}
+
+// MapFieldDefinitions - Returns ids of definitions of other catalogue of the same caption and type by id
+func (clg *Catalogue) MapFieldDefinitions(otherClg *Catalogue) map[int64]int64 {
+	fieldIDs := make(map[int64]int64)
+	for _, fieldDef := range clg.CustomFieldDefinitions {
+		otherFieldDef := otherClg.GetCustomFieldDefinitionByCaption(fieldDef.GetCaption())
+		if otherFieldDef != nil && otherFieldDef.GetType() == fieldDef.GetType() {
+			fieldIDs[fieldDef.GetID()] = otherFieldDef.GetID()
+		}
+	}
+
+	return fieldIDs
+}
+
+// MapCategories - Returns ids of categories of other catalogue of the same code by id
+func (clg *Catalogue) MapCategories(otherClg *Catalogue) map[int64]int64 {
+	categoryIDs := make(map[int64]int64)
+	for _, cat := range clg.Categories {
+		if otherCat := otherClg.GetCategoryByCode(cat.GetCode()); otherCat != nil {
+			categoryIDs[cat.GetID()] = otherCat.GetID()
+		}
+	}
+
+	return categoryIDs
+}
+
+// NewClone - Creates catalogue of clone holding copies of definitions, groups and categories keeping their ids
+func (clg *Catalogue) NewClone(clone *Clone) *Catalogue {
+	newClg := NewCatalogue()
+	newClg.Code = clone.Code
+	newClg.Description = clone.Description
+	if newClg.Description == "" {
+		newClg.Description = clg.GetDescription()
+	}
+	newClg.Details = clone.Details
+	if newClg.Details == "" {
+		newClg.Details = clg.GetDetails()
+	}
+	newClg.Status = clg.GetStatus()
+	newClg.ApprovalRequired = clg.GetApprovalRequired()
+	newClg.Reviewers = clg.GetAllReviewers()
+
+	for _, group := range clg.FieldGroups {
+		newGroup := *group
+		newGroup.CatalogueCode = newClg.GetCode()
+		newGroup.ChangeMode = changemode.Add
+		newClg.FieldGroups = append(newClg.FieldGroups, &newGroup)
+	}
+
+	for _, fieldDef := range clg.CustomFieldDefinitions {
+		newFieldDef := *fieldDef
+		newFieldDef.CatalogueCode = newClg.GetCode()
+		newFieldDef.ChangeMode = changemode.Add
+		newFieldDef.Options = nil
+		for _, option := range fieldDef.GetAllOptions() {
+			newOption := *option
+			newOption.ChangeMode = changemode.Add
+			newFieldDef.Options = append(newFieldDef.Options, &newOption)
+		}
+		newClg.CustomFieldDefinitions = append(newClg.CustomFieldDefinitions, &newFieldDef)
+	}
+
+	for _, cat := range clg.Categories {
+		newCat := *cat
+		newCat.CatalogueCode = newClg.GetCode()
+		newCat.Children = nil
+		newClg.Categories = append(newClg.Categories, &newCat)
+	}
+
+	return newClg
+}
+
+// MarkChanges - Marks change mode of custom field definitions and groups against other catalogue
+func (clg *Catalogue) MarkChanges(otherClg *Catalogue) {
+	for _, fieldDef := range clg.CustomFieldDefinitions {
+		otherFieldDef := otherClg.GetCustomFieldDefinition(fieldDef.GetID())
+
+		if fieldDef.GetID() == 0 || otherFieldDef == nil {
+			fieldDef.ChangeMode = changemode.Add
+		} else if !fieldDef.IsEqual(otherFieldDef) {
+			fieldDef.ChangeMode = changemode.Update
+		} else {
+			fieldDef.ChangeMode = changemode.Unchange
+		}
+
+		if otherFieldDef != nil {
+			fieldDef.MarkChanges(otherFieldDef)
+		}
+	}
+
+	for _, otherFieldDef := range otherClg.CustomFieldDefinitions {
+		if clg.GetCustomFieldDefinition(otherFieldDef.GetID()) == nil {
+			deletedFieldDef := *otherFieldDef
+			deletedFieldDef.ChangeMode = changemode.Delete
+			clg.CustomFieldDefinitions = append(clg.CustomFieldDefinitions, &deletedFieldDef)
+		}
+	}
+
+	for _, group := range clg.FieldGroups {
+		otherGroup := otherClg.GetFieldGroup(group.GetID())
+
+		if group.GetID() == 0 || otherGroup == nil {
+			group.ChangeMode = changemode.Add
+		} else if !group.IsEqual(otherGroup) {
+			group.ChangeMode = changemode.Update
+		} else {
+			group.ChangeMode = changemode.Unchange
+		}
+	}
+
+	for _, otherGroup := range otherClg.FieldGroups {
+		if clg.GetFieldGroup(otherGroup.GetID()) == nil {
+			deletedGroup := *otherGroup
+			deletedGroup.ChangeMode = changemode.Delete
+			clg.FieldGroups = append(clg.FieldGroups, &deletedGroup)
+		}
+	}
+}
+
+// DoValidate - Validate catalogue, otherClg is the stored catalogue if any
+func (clg *Catalogue) DoValidate(otherClg *Catalogue) validation.Violations {
+	violations := validation.Validate(clg)
+
+	if clg.GetApprovalRequired() && len(clg.Reviewers) == 0 {
+		violations.Add(validation.Pointer("reviewers"), "Catalogue requiring approval must have reviewers.")
+	}
+
+	reviewers := make(map[string]bool)
+	for i, reviewer := range clg.GetAllReviewers() {
+		if reviewers[reviewer] {
+			violations.Add(validation.Pointer("reviewers", i), fmt.Sprintf("Reviewer '%s' is duplicated.", reviewer))
+		}
+		reviewers[reviewer] = true
+	}
+
+	groupCodes := make(map[string]bool)
+	for i, group := range clg.FieldGroups {
+		if group.GetChangeMode() == changemode.Delete {
+			continue
+		}
+
+		path := validation.Pointer("field_groups", i)
+		violations.Append(path, group.DoValidate())
+
+		if groupCodes[group.GetCode()] {
+			violations.Add(path+validation.Pointer("code"), fmt.Sprintf("Custom Field Group '%s' is duplicated.", group.GetCode()))
+		}
+		groupCodes[group.GetCode()] = true
+	}
+
+	// Groups and definitions left out of request are kept as stored
+	if otherClg != nil {
+		for _, otherGroup := range otherClg.FieldGroups {
+			if clg.GetFieldGroup(otherGroup.GetID()) == nil {
+				groupCodes[otherGroup.GetCode()] = true
+			}
+		}
+
+		for _, otherFieldDef := range otherClg.CustomFieldDefinitions {
+			if clg.GetCustomFieldDefinition(otherFieldDef.GetID()) == nil && otherFieldDef.GetGroupCode() != "" && !groupCodes[otherFieldDef.GetGroupCode()] {
+				violations.Add(validation.Pointer("field_groups"), fmt.Sprintf("Custom Field Group '%s' is still used by Custom Field '%s'.", otherFieldDef.GetGroupCode(), otherFieldDef.GetCaption()))
+			}
+		}
+	}
+
+	for i, fieldDef := range clg.CustomFieldDefinitions {
+		if fieldDef.GetChangeMode() == changemode.Delete {
+			continue
+		}
+
+		path := validation.Pointer("field_definitions", i)
+		fieldDefViolations := fieldDef.DoValidate()
+		violations.Append(path, fieldDefViolations)
+
+		if fieldDef.GetGroupCode() != "" && !groupCodes[fieldDef.GetGroupCode()] {
+			violations.Add(path+validation.Pointer("group_code"), fmt.Sprintf("Custom Field '%s' refers to unknown group '%s'.", fieldDef.GetCaption(), fieldDef.GetGroupCode()))
+		}
+
+		if fieldDef.GetCategoryID() != 0 && clg.GetCategory(fieldDef.GetCategoryID()) == nil {
+			violations.Add(path+validation.Pointer("category_id"), fmt.Sprintf("Custom Field '%s' refers to unknown category '%d'.", fieldDef.GetCaption(), fieldDef.GetCategoryID()))
+		}
+
+		// Default value can only be checked against a valid definition
+		if fieldDef.HasDefaultValue() && fieldDefViolations.IsValid() {
+			defaultPath := path + validation.Pointer("default_value")
+
+			defaultField, err := productcustomfield.NewDefaultProductCustomField(fieldDef)
+			if err != nil {
+				violations.Add(defaultPath, err.Error())
+				continue
+			}
+
+			for _, violation := range defaultField.DoValidate(fieldDef, nil) {
+				violations.Add(defaultPath, violation.Message)
+			}
+		}
+	}
+
+	return violations
 }
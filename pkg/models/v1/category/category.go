@@ -0,0 +1,157 @@
+package category
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+)
+
+// Category type, node of category tree of a catalogue, path holds ids from the root down e.g. '/1/4/'
+type Category struct {
+	basemodel.BaseModel
+	ID            int64       `json:"id"`
+	CatalogueCode string      `json:"clg_code"`
+	ParentID      int64       `json:"parent_id"`
+	Code          string      `json:"code" mandatory:"true" max_length:"16"`
+	Description   string      `json:"description" mandatory:"true" max_length:"32"`
+	Path          string      `json:"path"`
+	CreatedBy     string      `json:"created_by"`
+	CreatedAt     time.Time   `json:"created_at"`
+	ModifiedBy    string      `json:"modified_by"`
+	ModifiedAt    time.Time   `json:"modified_at"`
+	Vers          int64       `json:"vers"`
+	Children      []*Category `json:"children,omitempty"`
+}
+
+// Move type, new parent of a category, zero moves category to the root
+type Move struct {
+	ParentID int64 `json:"parent_id"`
+}
+
+// NewCategory - Creates category
+func NewCategory() *Category {
+	return &Category{}
+}
+
+// GetID - Returns category id
+func (cat *Category) GetID() int64 {
+	return cat.ID
+}
+
+// GetCatalogueCode - Returns catalogue code
+func (cat *Category) GetCatalogueCode() string {
+	return strings.ToUpper(cat.CatalogueCode)
+}
+
+// GetParentID - Returns id of parent category, zero for a root category
+func (cat *Category) GetParentID() int64 {
+	return cat.ParentID
+}
+
+// IsRoot - Whether category has no parent
+func (cat *Category) IsRoot() bool {
+	return cat.ParentID == 0
+}
+
+// GetCode - Returns category code
+func (cat *Category) GetCode() string {
+	return strings.ToUpper(cat.Code)
+}
+
+// GetDescription - Returns category description
+func (cat *Category) GetDescription() string {
+	return cat.Description
+}
+
+// GetPath - Returns materialized path of category
+func (cat *Category) GetPath() string {
+	return cat.Path
+}
+
+// GetCreatedBy - Returns created by
+func (cat *Category) GetCreatedBy() string {
+	return cat.CreatedBy
+}
+
+// GetCreatedAt - Returns created at
+func (cat *Category) GetCreatedAt() time.Time {
+	return cat.CreatedAt
+}
+
+// GetModifiedBy - Returns modified by
+func (cat *Category) GetModifiedBy() string {
+	return cat.ModifiedBy
+}
+
+// GetModifiedAt - Returns modified at
+func (cat *Category) GetModifiedAt() time.Time {
+	return cat.ModifiedAt
+}
+
+// GetVers - Returns vers
+func (cat *Category) GetVers() int64 {
+	return cat.Vers
+}
+
+// GetAllChildren - Returns all child categories
+func (cat *Category) GetAllChildren() []*Category {
+	return cat.Children
+}
+
+// IsDescendantOf - Whether category is other category or is beneath it
+func (cat *Category) IsDescendantOf(other *Category) bool {
+	return strings.HasPrefix(cat.GetPath(), other.GetPath())
+}
+
+// DoValidate - Validate category
+func (cat *Category) DoValidate() validation.Violations {
+	return validation.Validate(cat)
+}
+
+// DoValidateParent - Validate category can be placed beneath parent, parent is nil for a root category
+func (cat *Category) DoValidateParent(parent *Category) validation.Violations {
+	violations := make(validation.Violations, 0)
+	if parent == nil {
+		return violations
+	}
+
+	if parent.GetCatalogueCode() != cat.GetCatalogueCode() {
+		violations.Add(validation.Pointer("parent_id"), fmt.Sprintf("Category '%s' is not in catalogue '%s'.", parent.GetCode(), cat.GetCatalogueCode()))
+	} else if cat.GetID() != 0 && parent.IsDescendantOf(cat) {
+		violations.Add(validation.Pointer("parent_id"), fmt.Sprintf("Category '%s' can not be moved beneath itself or its descendant '%s'.", cat.GetCode(), parent.GetCode()))
+	}
+
+	return violations
+}
+
+// NewPath - Returns path of category of id beneath parent, parent is nil for a root category
+func NewPath(parent *Category, id int64) string {
+	path := "/"
+	if parent != nil {
+		path = parent.GetPath()
+	}
+
+	return path + strconv.FormatInt(id, 10) + "/"
+}
+
+// NewTree - Nests categories ordered by path beneath their parents, returns the root categories
+func NewTree(categories []*Category) []*Category {
+	roots := make([]*Category, 0)
+	byID := make(map[int64]*Category)
+	for _, cat := range categories {
+		cat.Children = nil
+		byID[cat.GetID()] = cat
+
+		if parent := byID[cat.GetParentID()]; parent != nil {
+			parent.Children = append(parent.Children, cat)
+		} else {
+			roots = append(roots, cat)
+		}
+	}
+
+	return roots
+}
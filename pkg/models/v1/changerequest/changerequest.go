@@ -0,0 +1,118 @@
+package changerequest
+
+import (
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/reviewstatus"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+)
+
+// ChangeRequest type, product change submitted against base vers of product pending approval
+type ChangeRequest struct {
+	basemodel.BaseModel
+	ID            int64            `json:"id"`
+	ProdID        int64            `json:"prod_id"`
+	CatalogueCode string           `json:"clg_code"`
+	BaseVers      int64            `json:"base_vers"`
+	Status        string           `json:"status"`
+	Product       *product.Product `json:"product"`
+	SubmittedBy   string           `json:"submitted_by"`
+	SubmittedAt   time.Time        `json:"submitted_at"`
+	ReviewedBy    string           `json:"reviewed_by"`
+	ReviewedAt    *time.Time       `json:"reviewed_at"`
+	Comment       string           `json:"comment"`
+}
+
+// Review type, approval or rejection of a change request
+type Review struct {
+	basemodel.BaseModel
+	Comment string `json:"comment" max_length:"256"`
+}
+
+// NewChangeRequest - Creates change request
+func NewChangeRequest() *ChangeRequest {
+	return &ChangeRequest{}
+}
+
+// GetID - Returns change request id
+func (change *ChangeRequest) GetID() int64 {
+	return change.ID
+}
+
+// GetProdID - Returns product id
+func (change *ChangeRequest) GetProdID() int64 {
+	return change.ProdID
+}
+
+// GetCatalogueCode - Returns catalogue code
+func (change *ChangeRequest) GetCatalogueCode() string {
+	return change.CatalogueCode
+}
+
+// GetBaseVers - Returns version of product change was submitted against
+func (change *ChangeRequest) GetBaseVers() int64 {
+	return change.BaseVers
+}
+
+// GetStatus - Returns review status
+func (change *ChangeRequest) GetStatus() string {
+	return change.Status
+}
+
+// IsPending - Whether change awaits a reviewer
+func (change *ChangeRequest) IsPending() bool {
+	return change.Status == reviewstatus.Pending.String()
+}
+
+// GetProduct - Returns product as submitted
+func (change *ChangeRequest) GetProduct() *product.Product {
+	return change.Product
+}
+
+// GetSubmittedBy - Returns submitted by
+func (change *ChangeRequest) GetSubmittedBy() string {
+	return change.SubmittedBy
+}
+
+// GetSubmittedAt - Returns submitted at
+func (change *ChangeRequest) GetSubmittedAt() time.Time {
+	return change.SubmittedAt
+}
+
+// GetReviewedBy - Returns reviewed by, empty while pending
+func (change *ChangeRequest) GetReviewedBy() string {
+	return change.ReviewedBy
+}
+
+// GetReviewedAt - Returns reviewed at, nil while pending
+func (change *ChangeRequest) GetReviewedAt() *time.Time {
+	return change.ReviewedAt
+}
+
+// GetComment - Returns comment of reviewer
+func (change *ChangeRequest) GetComment() string {
+	return change.Comment
+}
+
+// NewReview - Creates review
+func NewReview() *Review {
+	return &Review{}
+}
+
+// GetComment - Returns comment of reviewer
+func (review *Review) GetComment() string {
+	return review.Comment
+}
+
+// DoValidate - Validate review, rejection must give a comment
+func (review *Review) DoValidate(approve bool) validation.Violations {
+	violations := validation.Validate(review)
+
+	if !approve && review.GetComment() == "" {
+		violations.Add(validation.Pointer("comment"), "Comment must be specified when rejecting a change.")
+	}
+
+	return violations
+}
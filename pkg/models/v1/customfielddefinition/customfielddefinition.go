@@ -1,26 +1,58 @@
 package customfielddefinition
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/decimal"
+	"github.com/bungysheep/catalogue-api/pkg/commons/definitiontype"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/customfieldoption"
 )
 
+// alphaValueMaxLength - Storage length of alpha values
+const alphaValueMaxLength = 64
+
 // CustomFieldDefinition type
 type CustomFieldDefinition struct {
 	basemodel.BaseModel
-	ID            int64                 `json:"id"`
-	CatalogueCode string                `json:"clg_code"`
-	Caption       string                `json:"caption" mandatory:"true" max_length:"32"`
-	Type          string                `json:"type" mandatory:"true" max_length:"1" valid_value:"A,N,D"`
-	Mandatory     bool                  `json:"mandatory"`
-	CreatedBy     string                `json:"created_by"`
-	CreatedAt     time.Time             `json:"created_at"`
-	ModifiedBy    string                `json:"modified_by"`
-	ModifiedAt    time.Time             `json:"modified_at"`
-	Vers          int64                 `json:"vers"`
-	ChangeMode    changemode.ChangeMode `json:"change_mode"`
+	ID            int64                                  `json:"id"`
+	CatalogueCode string                                 `json:"clg_code"`
+	Caption       string                                 `json:"caption" mandatory:"true" max_length:"32"`
+	Type          string                                 `json:"type" mandatory:"true" max_length:"1" valid_value:"A,N,D,B,I,F,U,E,P,M"`
+	Mandatory     bool                                   `json:"mandatory"`
+	Precision     int                                    `json:"precision"`
+	MinLength     int                                    `json:"min_length"`
+	MaxLength     int                                    `json:"max_length"`
+	Pattern       string                                 `json:"pattern" max_length:"256"`
+	MinValue      *decimal.Decimal                       `json:"min_value"`
+	MaxValue      *decimal.Decimal                       `json:"max_value"`
+	Step          *decimal.Decimal                       `json:"step"`
+	MinDate       *time.Time                             `json:"min_date"`
+	MaxDate       *time.Time                             `json:"max_date"`
+	Unique        bool                                   `json:"unique"`
+	DefaultValue  string                                 `json:"default_value" max_length:"64"`
+	GroupCode     string                                 `json:"group_code" max_length:"16"`
+	SortOrder     int                                    `json:"sort_order"`
+	HelpText      string                                 `json:"help_text" max_length:"256"`
+	Placeholder   string                                 `json:"placeholder" max_length:"64"`
+	UnitLabel     string                                 `json:"unit_label" max_length:"16"`
+	Hidden        bool                                   `json:"hidden"`
+	ReadOnly      bool                                   `json:"read_only"`
+	VariantAxis   bool                                   `json:"variant_axis"`
+	CategoryID    int64                                  `json:"category_id"`
+	CreatedBy     string                                 `json:"created_by"`
+	CreatedAt     time.Time                              `json:"created_at"`
+	ModifiedBy    string                                 `json:"modified_by"`
+	ModifiedAt    time.Time                              `json:"modified_at"`
+	Vers          int64                                  `json:"vers"`
+	ChangeMode    changemode.ChangeMode                  `json:"change_mode"`
+	Options       []*customfieldoption.CustomFieldOption `json:"options"`
 }
 
 // NewCustomFieldDefinition - Creates custom field definition
@@ -53,6 +85,125 @@ func (cfd *CustomFieldDefinition) GetMandatory() bool {
 	return cfd.Mandatory
 }
 
+// GetPrecision - Returns number of decimal places of decimal type
+func (cfd *CustomFieldDefinition) GetPrecision() int {
+	return cfd.Precision
+}
+
+// GetMinLength - Returns minimum length of text value, zero when not constrained
+func (cfd *CustomFieldDefinition) GetMinLength() int {
+	return cfd.MinLength
+}
+
+// GetMaxLength - Returns maximum length of text value, zero when not constrained
+func (cfd *CustomFieldDefinition) GetMaxLength() int {
+	return cfd.MaxLength
+}
+
+// GetPattern - Returns regular expression text value must match
+func (cfd *CustomFieldDefinition) GetPattern() string {
+	return cfd.Pattern
+}
+
+// GetMinValue - Returns minimum numeric value
+func (cfd *CustomFieldDefinition) GetMinValue() *decimal.Decimal {
+	return cfd.MinValue
+}
+
+// GetMaxValue - Returns maximum numeric value
+func (cfd *CustomFieldDefinition) GetMaxValue() *decimal.Decimal {
+	return cfd.MaxValue
+}
+
+// GetStep - Returns step numeric value must be a multiple of, counted from minimum value
+func (cfd *CustomFieldDefinition) GetStep() *decimal.Decimal {
+	return cfd.Step
+}
+
+// GetMinDate - Returns minimum date value
+func (cfd *CustomFieldDefinition) GetMinDate() *time.Time {
+	return cfd.MinDate
+}
+
+// GetMaxDate - Returns maximum date value
+func (cfd *CustomFieldDefinition) GetMaxDate() *time.Time {
+	return cfd.MaxDate
+}
+
+// GetUnique - Returns whether value must be unique within catalogue
+func (cfd *CustomFieldDefinition) GetUnique() bool {
+	return cfd.Unique
+}
+
+// GetDefaultValue - Returns text representation of default value
+func (cfd *CustomFieldDefinition) GetDefaultValue() string {
+	return cfd.DefaultValue
+}
+
+// HasDefaultValue - Whether default value has been specified
+func (cfd *CustomFieldDefinition) HasDefaultValue() bool {
+	return cfd.DefaultValue != ""
+}
+
+// GetGroupCode - Returns code of the group definition belongs to
+func (cfd *CustomFieldDefinition) GetGroupCode() string {
+	return strings.ToUpper(cfd.GroupCode)
+}
+
+// GetSortOrder - Returns sort order within catalogue
+func (cfd *CustomFieldDefinition) GetSortOrder() int {
+	return cfd.SortOrder
+}
+
+// GetHelpText - Returns help text shown by product editor
+func (cfd *CustomFieldDefinition) GetHelpText() string {
+	return cfd.HelpText
+}
+
+// GetPlaceholder - Returns placeholder shown by product editor
+func (cfd *CustomFieldDefinition) GetPlaceholder() string {
+	return cfd.Placeholder
+}
+
+// GetUnitLabel - Returns unit label shown next to value
+func (cfd *CustomFieldDefinition) GetUnitLabel() string {
+	return cfd.UnitLabel
+}
+
+// GetHidden - Returns whether product editor hides the field
+func (cfd *CustomFieldDefinition) GetHidden() bool {
+	return cfd.Hidden
+}
+
+// GetReadOnly - Returns whether product editor shows the field as read-only
+func (cfd *CustomFieldDefinition) GetReadOnly() bool {
+	return cfd.ReadOnly
+}
+
+// GetVariantAxis - Returns whether variants of a product differ by the field
+func (cfd *CustomFieldDefinition) GetVariantAxis() bool {
+	return cfd.VariantAxis
+}
+
+// GetCategoryID - Returns id of category definition is attached to, zero when it applies to the whole catalogue
+func (cfd *CustomFieldDefinition) GetCategoryID() int64 {
+	return cfd.CategoryID
+}
+
+// IsText - Whether definition type holds free text
+func (cfd *CustomFieldDefinition) IsText() bool {
+	return cfd.GetType() == definitiontype.Alphanumeric.String() ||
+		cfd.GetType() == definitiontype.URL.String() ||
+		cfd.GetType() == definitiontype.Email.String()
+}
+
+// IsNumeric - Whether definition type holds number
+func (cfd *CustomFieldDefinition) IsNumeric() bool {
+	return cfd.GetType() == definitiontype.Numeric.String() ||
+		cfd.GetType() == definitiontype.Integer.String() ||
+		cfd.GetType() == definitiontype.Decimal.String()
+}
+
 // GetCreatedBy - Returns created by
 func (cfd *CustomFieldDefinition) GetCreatedBy() string {
 	return cfd.CreatedBy
@@ -83,15 +234,204 @@ func (cfd *CustomFieldDefinition) GetChangeMode() changemode.ChangeMode {
 	return cfd.ChangeMode
 }
 
+// GetAllOptions - Returns all options
+func (cfd *CustomFieldDefinition) GetAllOptions() []*customfieldoption.CustomFieldOption {
+	return cfd.Options
+}
+
+// GetOption - Returns option
+func (cfd *CustomFieldDefinition) GetOption(optionID int64) *customfieldoption.CustomFieldOption {
+	for _, option := range cfd.Options {
+		if option.GetID() == optionID {
+			return option
+		}
+	}
+
+	return nil
+}
+
+// GetOptionByCode - Returns option by code
+func (cfd *CustomFieldDefinition) GetOptionByCode(code string) *customfieldoption.CustomFieldOption {
+	for _, option := range cfd.Options {
+		if option.GetCode() == code && option.GetChangeMode() != changemode.Delete {
+			return option
+		}
+	}
+
+	return nil
+}
+
+// HasOptions - Whether definition type takes its values from options
+func (cfd *CustomFieldDefinition) HasOptions() bool {
+	return cfd.GetType() == definitiontype.Picklist.String() || cfd.GetType() == definitiontype.MultiSelect.String()
+}
+
+// MarkChanges - Marks change mode of options against other custom field definition
+func (cfd *CustomFieldDefinition) MarkChanges(otherFieldDef *CustomFieldDefinition) {
+	for _, option := range cfd.Options {
+		otherOption := otherFieldDef.GetOption(option.GetID())
+
+		if option.GetID() == 0 || otherOption == nil {
+			option.ChangeMode = changemode.Add
+		} else if !option.IsEqual(otherOption) {
+			option.ChangeMode = changemode.Update
+		} else {
+			option.ChangeMode = changemode.Unchange
+		}
+	}
+
+	for _, otherOption := range otherFieldDef.Options {
+		if cfd.GetOption(otherOption.GetID()) == nil {
+			deletedOption := *otherOption
+			deletedOption.ChangeMode = changemode.Delete
+			cfd.Options = append(cfd.Options, &deletedOption)
+		}
+	}
+}
+
 // IsEqual - Whether equal
 func (cfd *CustomFieldDefinition) IsEqual(otherFieldDef *CustomFieldDefinition) bool {
 	return cfd.ID == otherFieldDef.GetID() &&
 		cfd.Caption == otherFieldDef.GetCaption() &&
 		cfd.Type == otherFieldDef.GetType() &&
-		cfd.Mandatory == otherFieldDef.GetMandatory()
+		cfd.Mandatory == otherFieldDef.GetMandatory() &&
+		cfd.Precision == otherFieldDef.GetPrecision() &&
+		cfd.MinLength == otherFieldDef.GetMinLength() &&
+		cfd.MaxLength == otherFieldDef.GetMaxLength() &&
+		cfd.Pattern == otherFieldDef.GetPattern() &&
+		isEqualDecimal(cfd.MinValue, otherFieldDef.GetMinValue()) &&
+		isEqualDecimal(cfd.MaxValue, otherFieldDef.GetMaxValue()) &&
+		isEqualDecimal(cfd.Step, otherFieldDef.GetStep()) &&
+		isEqualDate(cfd.MinDate, otherFieldDef.GetMinDate()) &&
+		isEqualDate(cfd.MaxDate, otherFieldDef.GetMaxDate()) &&
+		cfd.Unique == otherFieldDef.GetUnique() &&
+		cfd.DefaultValue == otherFieldDef.GetDefaultValue() &&
+		cfd.GetGroupCode() == otherFieldDef.GetGroupCode() &&
+		cfd.SortOrder == otherFieldDef.GetSortOrder() &&
+		cfd.HelpText == otherFieldDef.GetHelpText() &&
+		cfd.Placeholder == otherFieldDef.GetPlaceholder() &&
+		cfd.UnitLabel == otherFieldDef.GetUnitLabel() &&
+		cfd.Hidden == otherFieldDef.GetHidden() &&
+		cfd.ReadOnly == otherFieldDef.GetReadOnly() &&
+		cfd.VariantAxis == otherFieldDef.GetVariantAxis() &&
+		cfd.CategoryID == otherFieldDef.GetCategoryID()
+}
+
+func isEqualDecimal(value *decimal.Decimal, otherValue *decimal.Decimal) bool {
+	if value == nil || otherValue == nil {
+		return value == nil && otherValue == nil
+	}
+	return value.Equal(*otherValue)
+}
+
+func isEqualDate(value *time.Time, otherValue *time.Time) bool {
+	if value == nil || otherValue == nil {
+		return value == nil && otherValue == nil
+	}
+	return value.Equal(*otherValue)
 }
 
 // DoValidate - Validate custom field definition
-func (cfd *CustomFieldDefinition) DoValidate() (bool, string) {
-	return cfd.DoValidateBase(*cfd)
+func (cfd *CustomFieldDefinition) DoValidate() validation.Violations {
+	violations := make(validation.Violations, 0)
+
+	cfd.GroupCode = cfd.GetGroupCode()
+
+	if cfd.GetType() == definitiontype.Decimal.String() {
+		if cfd.GetPrecision() < 0 || cfd.GetPrecision() > configs.MAXDECIMALPRECISION {
+			violations.Add(validation.Pointer("precision"), fmt.Sprintf("Custom Field '%s' precision must be between 0 and %d.", cfd.GetCaption(), configs.MAXDECIMALPRECISION))
+		}
+	} else {
+		cfd.Precision = 0
+	}
+
+	cfd.validateRules(&violations)
+
+	// Each variant holds a single value of every axis
+	if cfd.GetVariantAxis() && cfd.GetType() == definitiontype.MultiSelect.String() {
+		violations.Add(validation.Pointer("variant_axis"), fmt.Sprintf("Custom Field '%s' holding multiple options can not be a variant axis.", cfd.GetCaption()))
+	}
+
+	// Every variant of a catalogue specifies every axis, whatever its categories
+	if cfd.GetVariantAxis() && cfd.GetCategoryID() != 0 {
+		violations.Add(validation.Pointer("variant_axis"), fmt.Sprintf("Custom Field '%s' attached to a category can not be a variant axis.", cfd.GetCaption()))
+	}
+
+	nbrOptions := 0
+	codes := make(map[string]bool)
+	for i, option := range cfd.Options {
+		if option.GetChangeMode() == changemode.Delete {
+			continue
+		}
+
+		path := validation.Pointer("options", i)
+		if !cfd.HasOptions() {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' does not support options.", cfd.GetCaption()))
+			break
+		}
+
+		violations.Append(path, option.DoValidate())
+
+		if codes[option.GetCode()] {
+			violations.Add(path+validation.Pointer("code"), fmt.Sprintf("Custom Field '%s' has duplicate option '%s'.", cfd.GetCaption(), option.GetCode()))
+		}
+		codes[option.GetCode()] = true
+		nbrOptions++
+	}
+
+	if cfd.HasOptions() && nbrOptions == 0 {
+		violations.Add(validation.Pointer("options"), fmt.Sprintf("Custom Field '%s' must have at least one option.", cfd.GetCaption()))
+	}
+
+	violations.Append("", validation.Validate(cfd))
+
+	return violations
+}
+
+// validateRules - Validate value constraints, clearing the ones not applicable to definition type
+func (cfd *CustomFieldDefinition) validateRules(violations *validation.Violations) {
+	if cfd.IsText() {
+		if cfd.GetMinLength() < 0 || cfd.GetMaxLength() < 0 || cfd.GetMaxLength() > alphaValueMaxLength {
+			violations.Add(validation.Pointer("max_length"), fmt.Sprintf("Custom Field '%s' length must be between 0 and %d.", cfd.GetCaption(), alphaValueMaxLength))
+		} else if cfd.GetMaxLength() > 0 && cfd.GetMinLength() > cfd.GetMaxLength() {
+			violations.Add(validation.Pointer("min_length"), fmt.Sprintf("Custom Field '%s' minimum length can not be more than maximum length.", cfd.GetCaption()))
+		}
+
+		if _, err := regexp.Compile(cfd.GetPattern()); err != nil {
+			violations.Add(validation.Pointer("pattern"), fmt.Sprintf("Custom Field '%s' pattern is not a valid regular expression.", cfd.GetCaption()))
+		}
+	} else {
+		cfd.MinLength = 0
+		cfd.MaxLength = 0
+		cfd.Pattern = ""
+	}
+
+	if cfd.IsNumeric() {
+		if cfd.GetMinValue() != nil && cfd.GetMaxValue() != nil && cfd.GetMinValue().Cmp(*cfd.GetMaxValue()) > 0 {
+			violations.Add(validation.Pointer("min_value"), fmt.Sprintf("Custom Field '%s' minimum value can not be more than maximum value.", cfd.GetCaption()))
+		}
+
+		if cfd.GetStep() != nil && cfd.GetStep().Sign() <= 0 {
+			violations.Add(validation.Pointer("step"), fmt.Sprintf("Custom Field '%s' step must be more than zero.", cfd.GetCaption()))
+		}
+	} else {
+		cfd.MinValue = nil
+		cfd.MaxValue = nil
+		cfd.Step = nil
+	}
+
+	if cfd.GetType() == definitiontype.Date.String() {
+		if cfd.GetMinDate() != nil && cfd.GetMaxDate() != nil && cfd.GetMinDate().After(*cfd.GetMaxDate()) {
+			violations.Add(validation.Pointer("min_date"), fmt.Sprintf("Custom Field '%s' minimum date can not be after maximum date.", cfd.GetCaption()))
+		}
+	} else {
+		cfd.MinDate = nil
+		cfd.MaxDate = nil
+	}
+
+	if cfd.GetUnique() && (cfd.GetType() == definitiontype.Boolean.String() || cfd.GetType() == definitiontype.MultiSelect.String()) {
+		violations.Add(validation.Pointer("unique"), fmt.Sprintf("Custom Field '%s' can not be unique.", cfd.GetCaption()))
+	} else if cfd.GetUnique() && cfd.HasDefaultValue() {
+		violations.Add(validation.Pointer("default_value"), fmt.Sprintf("Custom Field '%s' can not have default value as it is unique.", cfd.GetCaption()))
+	}
 }
@@ -0,0 +1,70 @@
+package customfieldgroup
+
+import (
+	"strings"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+)
+
+// CustomFieldGroup type
+type CustomFieldGroup struct {
+	basemodel.BaseModel
+	ID            int64                 `json:"id"`
+	CatalogueCode string                `json:"clg_code"`
+	Code          string                `json:"code" mandatory:"true" max_length:"16"`
+	Name          string                `json:"name" mandatory:"true" max_length:"32"`
+	SortOrder     int                   `json:"sort_order"`
+	ChangeMode    changemode.ChangeMode `json:"change_mode"`
+}
+
+// NewCustomFieldGroup - Creates custom field group
+func NewCustomFieldGroup() *CustomFieldGroup {
+	return &CustomFieldGroup{}
+}
+
+// GetID - Returns custom field group id
+func (grp *CustomFieldGroup) GetID() int64 {
+	return grp.ID
+}
+
+// GetCatalogueCode - Returns catalogue code
+func (grp *CustomFieldGroup) GetCatalogueCode() string {
+	return grp.CatalogueCode
+}
+
+// GetCode - Returns group code
+func (grp *CustomFieldGroup) GetCode() string {
+	return strings.ToUpper(grp.Code)
+}
+
+// GetName - Returns group name
+func (grp *CustomFieldGroup) GetName() string {
+	return grp.Name
+}
+
+// GetSortOrder - Returns sort order
+func (grp *CustomFieldGroup) GetSortOrder() int {
+	return grp.SortOrder
+}
+
+// GetChangeMode - Returns change mode
+func (grp *CustomFieldGroup) GetChangeMode() changemode.ChangeMode {
+	return grp.ChangeMode
+}
+
+// IsEqual - Whether equal
+func (grp *CustomFieldGroup) IsEqual(otherGrp *CustomFieldGroup) bool {
+	return grp.ID == otherGrp.GetID() &&
+		grp.GetCode() == otherGrp.GetCode() &&
+		grp.Name == otherGrp.GetName() &&
+		grp.SortOrder == otherGrp.GetSortOrder()
+}
+
+// DoValidate - Validate custom field group
+func (grp *CustomFieldGroup) DoValidate() validation.Violations {
+	grp.Code = grp.GetCode()
+
+	return validation.Validate(grp)
+}
@@ -0,0 +1,99 @@
+package customfieldoption
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+)
+
+// CustomFieldOption type
+type CustomFieldOption struct {
+	basemodel.BaseModel
+	ID         int64                 `json:"id"`
+	FieldID    int64                 `json:"field_id"`
+	Code       string                `json:"code" mandatory:"true" max_length:"32"`
+	Label      string                `json:"label" mandatory:"true" max_length:"64"`
+	SortOrder  int                   `json:"sort_order"`
+	Active     bool                  `json:"active"`
+	ChangeMode changemode.ChangeMode `json:"change_mode"`
+}
+
+// NewCustomFieldOption - Creates custom field option, active by default
+func NewCustomFieldOption() *CustomFieldOption {
+	return &CustomFieldOption{Active: true}
+}
+
+// UnmarshalJSON - Decodes custom field option, keeping it active when not specified
+func (opt *CustomFieldOption) UnmarshalJSON(data []byte) error {
+	type customFieldOption CustomFieldOption
+
+	decoded := customFieldOption(*NewCustomFieldOption())
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*opt = CustomFieldOption(decoded)
+	return nil
+}
+
+// GetID - Returns custom field option id
+func (opt *CustomFieldOption) GetID() int64 {
+	return opt.ID
+}
+
+// GetFieldID - Returns field id
+func (opt *CustomFieldOption) GetFieldID() int64 {
+	return opt.FieldID
+}
+
+// GetCode - Returns code
+func (opt *CustomFieldOption) GetCode() string {
+	return opt.Code
+}
+
+// GetLabel - Returns label
+func (opt *CustomFieldOption) GetLabel() string {
+	return opt.Label
+}
+
+// GetSortOrder - Returns sort order
+func (opt *CustomFieldOption) GetSortOrder() int {
+	return opt.SortOrder
+}
+
+// IsActive - Whether option can still be selected
+func (opt *CustomFieldOption) IsActive() bool {
+	return opt.Active
+}
+
+// GetChangeMode - Returns change mode
+func (opt *CustomFieldOption) GetChangeMode() changemode.ChangeMode {
+	return opt.ChangeMode
+}
+
+// IsEqual - Whether equal
+func (opt *CustomFieldOption) IsEqual(otherOpt *CustomFieldOption) bool {
+	return opt.ID == otherOpt.GetID() &&
+		opt.Code == otherOpt.GetCode() &&
+		opt.Label == otherOpt.GetLabel() &&
+		opt.SortOrder == otherOpt.GetSortOrder() &&
+		opt.Active == otherOpt.IsActive()
+}
+
+// DoValidate - Validate custom field option
+func (opt *CustomFieldOption) DoValidate() validation.Violations {
+	violations := make(validation.Violations, 0)
+
+	if strings.Contains(opt.GetCode(), configs.MULTIVALUESEPARATOR) {
+		violations.Add(validation.Pointer("code"), fmt.Sprintf("Option '%s' can not contain '%s'.", opt.GetCode(), configs.MULTIVALUESEPARATOR))
+	}
+
+	violations.Append("", validation.Validate(opt))
+
+	return violations
+}
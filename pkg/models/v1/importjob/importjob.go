@@ -0,0 +1,192 @@
+package importjob
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/unitofmeasure"
+)
+
+// ImportJob type
+type ImportJob struct {
+	basemodel.BaseModel
+	ID            int64     `json:"id"`
+	CatalogueCode string    `json:"clg_code"`
+	Format        string    `json:"format" mandatory:"true" max_length:"8" valid_value:"CSV,NDJSON"`
+	DryRun        bool      `json:"dry_run"`
+	Status        string    `json:"status"`
+	TotalRows     int64     `json:"total_rows"`
+	ProcessedRows int64     `json:"processed_rows"`
+	CreatedRows   int64     `json:"created_rows"`
+	UpdatedRows   int64     `json:"updated_rows"`
+	FailedRows    int64     `json:"failed_rows"`
+	Message       string    `json:"message"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	ModifiedAt    time.Time `json:"modified_at"`
+}
+
+// ImportOptions type
+type ImportOptions struct {
+	Format       string                       `json:"format"`
+	DryRun       bool                         `json:"dry_run"`
+	Mapping      map[string]string            `json:"mapping"`
+	CustomFields map[string]string            `json:"custom_fields"`
+	DefaultUom   *unitofmeasure.UnitOfMeasure `json:"default_uom"`
+}
+
+// ImportError type
+type ImportError struct {
+	ID      int64  `json:"id"`
+	JobID   int64  `json:"job_id"`
+	RowNo   int64  `json:"row_no"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewImportJob - Creates import job
+func NewImportJob() *ImportJob {
+	return &ImportJob{}
+}
+
+// NewImportOptions - Creates import options
+func NewImportOptions() *ImportOptions {
+	return &ImportOptions{}
+}
+
+// NewImportError - Creates import error
+func NewImportError() *ImportError {
+	return &ImportError{}
+}
+
+// GetID - Returns import job id
+func (job *ImportJob) GetID() int64 {
+	return job.ID
+}
+
+// GetCatalogueCode - Returns catalogue code
+func (job *ImportJob) GetCatalogueCode() string {
+	return job.CatalogueCode
+}
+
+// GetFormat - Returns import format
+func (job *ImportJob) GetFormat() string {
+	return strings.ToUpper(job.Format)
+}
+
+// GetDryRun - Returns whether dry run
+func (job *ImportJob) GetDryRun() bool {
+	return job.DryRun
+}
+
+// GetStatus - Returns job status
+func (job *ImportJob) GetStatus() string {
+	return job.Status
+}
+
+// GetTotalRows - Returns total rows
+func (job *ImportJob) GetTotalRows() int64 {
+	return job.TotalRows
+}
+
+// GetProcessedRows - Returns processed rows
+func (job *ImportJob) GetProcessedRows() int64 {
+	return job.ProcessedRows
+}
+
+// GetCreatedRows - Returns created rows
+func (job *ImportJob) GetCreatedRows() int64 {
+	return job.CreatedRows
+}
+
+// GetUpdatedRows - Returns updated rows
+func (job *ImportJob) GetUpdatedRows() int64 {
+	return job.UpdatedRows
+}
+
+// GetFailedRows - Returns failed rows
+func (job *ImportJob) GetFailedRows() int64 {
+	return job.FailedRows
+}
+
+// GetMessage - Returns job message
+func (job *ImportJob) GetMessage() string {
+	return job.Message
+}
+
+// GetCreatedBy - Returns created by
+func (job *ImportJob) GetCreatedBy() string {
+	return job.CreatedBy
+}
+
+// GetCreatedAt - Returns created at
+func (job *ImportJob) GetCreatedAt() time.Time {
+	return job.CreatedAt
+}
+
+// GetModifiedAt - Returns modified at
+func (job *ImportJob) GetModifiedAt() time.Time {
+	return job.ModifiedAt
+}
+
+// DoValidate - Validate import job
+func (job *ImportJob) DoValidate() validation.Violations {
+	job.Format = job.GetFormat()
+
+	return validation.Validate(job)
+}
+
+// GetFormat - Returns import format
+func (opt *ImportOptions) GetFormat() string {
+	return strings.ToUpper(opt.Format)
+}
+
+// GetColumn - Returns column name mapped to product field
+func (opt *ImportOptions) GetColumn(field string) string {
+	if column, ok := opt.Mapping[field]; ok {
+		return column
+	}
+
+	return field
+}
+
+// GetCustomFieldColumn - Returns column name mapped to custom field caption
+func (opt *ImportOptions) GetCustomFieldColumn(caption string) string {
+	if column, ok := opt.CustomFields[caption]; ok {
+		return column
+	}
+
+	return caption
+}
+
+// GetDefaultUom - Returns default uom for new products
+func (opt *ImportOptions) GetDefaultUom() *unitofmeasure.UnitOfMeasure {
+	return opt.DefaultUom
+}
+
+// GetID - Returns import error id
+func (ie *ImportError) GetID() int64 {
+	return ie.ID
+}
+
+// GetJobID - Returns job id
+func (ie *ImportError) GetJobID() int64 {
+	return ie.JobID
+}
+
+// GetRowNo - Returns row number
+func (ie *ImportError) GetRowNo() int64 {
+	return ie.RowNo
+}
+
+// GetCode - Returns product code
+func (ie *ImportError) GetCode() string {
+	return ie.Code
+}
+
+// GetMessage - Returns error message
+func (ie *ImportError) GetMessage() string {
+	return ie.Message
+}
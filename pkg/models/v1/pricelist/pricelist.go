@@ -0,0 +1,121 @@
+package pricelist
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/status"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+)
+
+// PriceList type, prices in one currency for a customer segment during a validity window
+type PriceList struct {
+	basemodel.BaseModel
+	Code        string     `json:"code" mandatory:"true" max_length:"16"`
+	Description string     `json:"description" mandatory:"true" max_length:"32"`
+	Currency    string     `json:"currency" mandatory:"true" pattern:"^[A-Z]{3}$"`
+	Segment     string     `json:"segment" max_length:"16"`
+	ValidFrom   time.Time  `json:"valid_from" mandatory:"true"`
+	ValidTo     *time.Time `json:"valid_to"`
+	Status      string     `json:"status" mandatory:"true" valid_value:"A,I"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ModifiedBy  string     `json:"modified_by"`
+	ModifiedAt  time.Time  `json:"modified_at"`
+	Vers        int64      `json:"vers"`
+}
+
+// NewPriceList - Creates price list
+func NewPriceList() *PriceList {
+	return &PriceList{}
+}
+
+// GetCode - Returns price list code
+func (list *PriceList) GetCode() string {
+	return strings.ToUpper(list.Code)
+}
+
+// GetDescription - Returns price list description
+func (list *PriceList) GetDescription() string {
+	return list.Description
+}
+
+// GetCurrency - Returns currency, ISO 4217 code
+func (list *PriceList) GetCurrency() string {
+	return list.Currency
+}
+
+// GetSegment - Returns customer segment
+func (list *PriceList) GetSegment() string {
+	return strings.ToUpper(list.Segment)
+}
+
+// GetValidFrom - Returns first date of validity
+func (list *PriceList) GetValidFrom() time.Time {
+	return list.ValidFrom
+}
+
+// GetValidTo - Returns last date of validity, nil when list never expires
+func (list *PriceList) GetValidTo() *time.Time {
+	return list.ValidTo
+}
+
+// GetStatus - Returns status
+func (list *PriceList) GetStatus() string {
+	return list.Status
+}
+
+// GetCreatedBy - Returns created by
+func (list *PriceList) GetCreatedBy() string {
+	return list.CreatedBy
+}
+
+// GetCreatedAt - Returns created at
+func (list *PriceList) GetCreatedAt() time.Time {
+	return list.CreatedAt
+}
+
+// GetModifiedBy - Returns modified by
+func (list *PriceList) GetModifiedBy() string {
+	return list.ModifiedBy
+}
+
+// GetModifiedAt - Returns modified at
+func (list *PriceList) GetModifiedAt() time.Time {
+	return list.ModifiedAt
+}
+
+// GetVers - Returns vers
+func (list *PriceList) GetVers() int64 {
+	return list.Vers
+}
+
+// IsValidAt - Whether price list is active and valid at date, only the date part is compared
+func (list *PriceList) IsValidAt(date time.Time) bool {
+	if list.GetStatus() != status.Active.String() {
+		return false
+	}
+
+	day := truncateDate(date)
+	if day.Before(truncateDate(list.GetValidFrom())) {
+		return false
+	}
+
+	return list.GetValidTo() == nil || !day.After(truncateDate(*list.GetValidTo()))
+}
+
+// DoValidate - Validate price list
+func (list *PriceList) DoValidate() validation.Violations {
+	violations := validation.Validate(list)
+
+	if list.GetValidTo() != nil && truncateDate(*list.GetValidTo()).Before(truncateDate(list.GetValidFrom())) {
+		violations.Add(validation.Pointer("valid_to"), "Valid to can not be before valid from")
+	}
+
+	return violations
+}
+
+func truncateDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -1,31 +1,56 @@
 package product
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/definitiontype"
+	"github.com/bungysheep/catalogue-api/pkg/commons/jsonschema"
+	"github.com/bungysheep/catalogue-api/pkg/commons/lifecycle"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/customfielddefinition"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/productcustomfield"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/unitofmeasure"
 )
 
-// Product type
+// Product type, status follows the product lifecycle
 type Product struct {
 	basemodel.BaseModel
 	ID             int64                                    `json:"id"`
 	CatalogueCode  string                                   `json:"clg_code"`
+	ParentID       int64                                    `json:"parent_id"`
 	Code           string                                   `json:"code"`
 	Description    string                                   `json:"description" mandatory:"true" max_length:"32"`
 	Details        string                                   `json:"details" max_length:"64"`
-	Status         string                                   `json:"status" mandatory:"true" max_length:"1"`
+	Status         string                                   `json:"status" mandatory:"true" valid_value:"D,A,X,E"`
+	EffectiveFrom  *time.Time                               `json:"effective_from"`
+	EffectiveTo    *time.Time                               `json:"effective_to"`
 	CreatedBy      string                                   `json:"created_by"`
 	CreatedAt      time.Time                                `json:"created_at"`
 	ModifiedBy     string                                   `json:"modified_by"`
 	ModifiedAt     time.Time                                `json:"modified_at"`
 	Vers           int64                                    `json:"vers"`
-	UnitOfMeasures []*unitofmeasure.UnitOfMeasure           `json:"uoms"`
+	UnitOfMeasures []*unitofmeasure.UnitOfMeasure           `json:"uoms" dive:"true"`
 	CustomFields   []*productcustomfield.ProductCustomField `json:"custom_fields"`
+	CategoryIDs    []int64                                  `json:"category_ids"`
+	Variants       []*Product                               `json:"variants,omitempty"`
+}
+
+// VariantAxisValues type, values of a variant axis variants are generated of
+type VariantAxisValues struct {
+	FieldID int64    `json:"field_id"`
+	Values  []string `json:"values"`
+}
+
+// Transfer type, product copied or moved to another catalogue along with the fields and categories not transferred
+type Transfer struct {
+	Product            *Product `json:"product"`
+	UnmappedFields     []string `json:"unmapped_fields"`
+	UnmappedCategories []string `json:"unmapped_categories"`
 }
 
 // NewProduct - Creates product
@@ -43,6 +68,16 @@ func (prod *Product) GetCatalogueCode() string {
 	return prod.CatalogueCode
 }
 
+// GetParentID - Returns id of parent product, zero when product is not a variant
+func (prod *Product) GetParentID() int64 {
+	return prod.ParentID
+}
+
+// IsVariant - Whether product is a variant of another product
+func (prod *Product) IsVariant() bool {
+	return prod.ParentID != 0
+}
+
 // GetCode - Returns product code
 func (prod *Product) GetCode() string {
 	return strings.ToUpper(prod.Code)
@@ -63,6 +98,16 @@ func (prod *Product) GetStatus() string {
 	return strings.ToUpper(prod.Status)
 }
 
+// GetEffectiveFrom - Returns date draft product becomes active, nil when activated by hand
+func (prod *Product) GetEffectiveFrom() *time.Time {
+	return prod.EffectiveFrom
+}
+
+// GetEffectiveTo - Returns date product reaches end of life, nil when product does not expire
+func (prod *Product) GetEffectiveTo() *time.Time {
+	return prod.EffectiveTo
+}
+
 // GetCreatedBy - Returns created by
 func (prod *Product) GetCreatedBy() string {
 	return prod.CreatedBy
@@ -88,10 +133,10 @@ func (prod *Product) GetVers() int64 {
 	return prod.Vers
 }
 
-// GetDefaultUom - Returns default uom
+// GetDefaultUom - Returns default uom, uoms being deleted are left out
 func (prod *Product) GetDefaultUom() *unitofmeasure.UnitOfMeasure {
 	for _, uom := range prod.UnitOfMeasures {
-		if uom.IsDefault() {
+		if uom.IsDefault() && uom.GetChangeMode() != changemode.Delete {
 			return uom
 		}
 	}
@@ -109,6 +154,16 @@ func (prod *Product) GetAllCustomFields() []*productcustomfield.ProductCustomFie
 	return prod.CustomFields
 }
 
+// GetCategoryIDs - Returns ids of categories product is assigned to, nil when not specified
+func (prod *Product) GetCategoryIDs() []int64 {
+	return prod.CategoryIDs
+}
+
+// GetAllVariants - Returns variants grouped under product
+func (prod *Product) GetAllVariants() []*Product {
+	return prod.Variants
+}
+
 // GetUom - Returns uom
 func (prod *Product) GetUom(uomID int64) *unitofmeasure.UnitOfMeasure {
 	for _, uom := range prod.UnitOfMeasures {
@@ -120,6 +175,17 @@ func (prod *Product) GetUom(uomID int64) *unitofmeasure.UnitOfMeasure {
 	return nil
 }
 
+// GetUomByCode - Returns uom of code
+func (prod *Product) GetUomByCode(code string) *unitofmeasure.UnitOfMeasure {
+	for _, uom := range prod.UnitOfMeasures {
+		if uom.GetCode() == strings.ToUpper(code) && uom.GetChangeMode() != changemode.Delete {
+			return uom
+		}
+	}
+
+	return nil
+}
+
 // GetCustomField - Returns custom field
 func (prod *Product) GetCustomField(fieldID int64) *productcustomfield.ProductCustomField {
 	for _, field := range prod.CustomFields {
@@ -131,12 +197,23 @@ func (prod *Product) GetCustomField(fieldID int64) *productcustomfield.ProductCu
 	return nil
 }
 
-// GetNumberOfDefaultUom - Returns number of default uom
+// GetCustomFieldByFieldID - Returns custom field of the definition
+func (prod *Product) GetCustomFieldByFieldID(fieldID int64) *productcustomfield.ProductCustomField {
+	for _, field := range prod.CustomFields {
+		if field.GetFieldID() == fieldID {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// GetNumberOfDefaultUom - Returns number of default uom, uoms being deleted are left out
 func (prod *Product) GetNumberOfDefaultUom() int {
 	count := 0
 
 	for _, uom := range prod.UnitOfMeasures {
-		if uom.IsDefault() {
+		if uom.IsDefault() && uom.GetChangeMode() != changemode.Delete {
 			count++
 		}
 
@@ -148,46 +225,477 @@ func (prod *Product) GetNumberOfDefaultUom() int {
 	return count
 }
 
-// DoValidate - Validate product
-func (prod *Product) DoValidate(otherProd *Product, clg *catalogue.Catalogue) (bool, string) {
-	var ok bool
-	var message string
+// MarkChanges - Marks change mode of uoms and custom fields against other product
+func (prod *Product) MarkChanges(otherProd *Product) {
+	for _, uom := range prod.UnitOfMeasures {
+		otherUom := otherProd.GetUom(uom.GetID())
+
+		if uom.GetID() == 0 || otherUom == nil {
+			uom.ChangeMode = changemode.Add
+		} else if !uom.IsEqual(otherUom) {
+			uom.ChangeMode = changemode.Update
+		} else {
+			uom.ChangeMode = changemode.Unchange
+		}
+	}
+
+	for _, otherUom := range otherProd.UnitOfMeasures {
+		if prod.GetUom(otherUom.GetID()) == nil {
+			deletedUom := *otherUom
+			deletedUom.ChangeMode = changemode.Delete
+			prod.UnitOfMeasures = append(prod.UnitOfMeasures, &deletedUom)
+		}
+	}
+
+	for _, field := range prod.CustomFields {
+		if field.GetID() == 0 || otherProd.GetCustomField(field.GetID()) == nil {
+			field.ChangeMode = changemode.Add
+		} else {
+			field.ChangeMode = changemode.Update
+		}
+	}
+
+	for _, otherField := range otherProd.CustomFields {
+		if prod.GetCustomField(otherField.GetID()) == nil {
+			deletedField := *otherField
+			deletedField.ChangeMode = changemode.Delete
+			prod.CustomFields = append(prod.CustomFields, &deletedField)
+		}
+	}
+}
+
+// MatchKeys - Takes ids of uoms and custom fields from the ones of other product of the same code and definition
+func (prod *Product) MatchKeys(otherProd *Product) {
+	for _, uom := range prod.UnitOfMeasures {
+		if otherUom := otherProd.GetUomByCode(uom.GetCode()); otherUom != nil {
+			uom.ID = otherUom.GetID()
+		}
+	}
+
+	for _, field := range prod.CustomFields {
+		if otherField := otherProd.GetCustomFieldByFieldID(field.GetFieldID()); otherField != nil {
+			field.ID = otherField.GetID()
+		}
+	}
+}
+
+// ApplyDefaults - Sets default value of custom fields applying to categories of new product, when missing or not specified
+func (prod *Product) ApplyDefaults(clg *catalogue.Catalogue) error {
+	for _, fieldDef := range clg.GetApplicableFieldDefinitions(prod.GetCategoryIDs()) {
+		if !fieldDef.HasDefaultValue() {
+			continue
+		}
+
+		field := prod.GetCustomFieldByFieldID(fieldDef.GetID())
+		if field == nil {
+			defaultField, err := productcustomfield.NewDefaultProductCustomField(fieldDef)
+			if err != nil {
+				return err
+			}
+
+			prod.CustomFields = append(prod.CustomFields, defaultField)
+		} else if !field.IsSpecified(fieldDef) {
+			if err := field.SetValueString(fieldDef, fieldDef.GetDefaultValue()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Inherit - Resolves description, categories and custom fields variant does not override from its parent
+func (prod *Product) Inherit(parent *Product) {
+	if prod.Description == "" {
+		prod.Description = parent.GetDescription()
+	}
+
+	if len(prod.CategoryIDs) == 0 {
+		prod.CategoryIDs = parent.GetCategoryIDs()
+	}
+
+	for _, field := range prod.CustomFields {
+		parentField := parent.GetCustomFieldByFieldID(field.GetFieldID())
+		field.Inherited = parentField != nil && field.IsEqualValue(parentField)
+	}
+
+	for _, parentField := range parent.CustomFields {
+		if prod.GetCustomFieldByFieldID(parentField.GetFieldID()) == nil {
+			inheritedField := *parentField
+			inheritedField.ID = 0
+			inheritedField.ProdID = prod.GetID()
+			inheritedField.Inherited = true
+			inheritedField.ChangeMode = changemode.Unchange
+			prod.CustomFields = append(prod.CustomFields, &inheritedField)
+		}
+	}
+}
+
+// DropInherited - Removes values of variant equal to its parent, so they keep following the parent
+func (prod *Product) DropInherited(parent *Product) {
+	if prod.Description == parent.GetDescription() {
+		prod.Description = ""
+	}
+
+	if prod.CategoryIDs != nil && isEqualIDs(prod.CategoryIDs, parent.GetCategoryIDs()) {
+		prod.CategoryIDs = make([]int64, 0)
+	}
+
+	fields := make([]*productcustomfield.ProductCustomField, 0)
+	for _, field := range prod.CustomFields {
+		parentField := parent.GetCustomFieldByFieldID(field.GetFieldID())
+		inherited := parentField != nil && field.IsEqualValue(parentField)
+
+		if field.GetID() == 0 {
+			if inherited || field.GetChangeMode() == changemode.Delete {
+				continue
+			}
+			field.ChangeMode = changemode.Add
+		} else if inherited && field.GetChangeMode() != changemode.Add {
+			field.ChangeMode = changemode.Delete
+		}
+
+		field.Inherited = false
+		fields = append(fields, field)
+	}
+
+	prod.CustomFields = fields
+}
+
+// GetVariantKey - Returns values of variant axes, identifying variant among variants of its parent
+func (prod *Product) GetVariantKey(axes []*customfielddefinition.CustomFieldDefinition) string {
+	values := make([]string, 0)
+	for _, axis := range axes {
+		value := ""
+		if field := prod.GetCustomFieldByFieldID(axis.GetID()); field != nil && field.GetChangeMode() != changemode.Delete {
+			value = field.GetValueString(axis)
+		}
+		values = append(values, value)
+	}
+
+	return strings.Join(values, "/")
+}
+
+// NewVariants - Creates variants of parent for the cartesian product of variant axis values
+func NewVariants(parent *Product, clg *catalogue.Catalogue, axisValues []*VariantAxisValues) ([]*Product, validation.Violations) {
+	violations := make(validation.Violations, 0)
+
+	values := make(map[int64][]string)
+	for i, item := range axisValues {
+		fieldDef := clg.GetCustomFieldDefinition(item.FieldID)
+		if fieldDef == nil || !fieldDef.GetVariantAxis() {
+			violations.Add(validation.Pointer(i, "field_id"), fmt.Sprintf("Custom Field '%d' is not a variant axis.", item.FieldID))
+			continue
+		}
+		values[item.FieldID] = item.Values
+	}
+
+	axes := clg.GetVariantAxes()
+	if len(axes) == 0 {
+		violations.Add("", fmt.Sprintf("Catalogue '%s' has no variant axes.", clg.GetCode()))
+	}
+
+	for _, axis := range axes {
+		if _, ok := values[axis.GetID()]; !ok {
+			for _, option := range axis.GetAllOptions() {
+				if option.IsActive() {
+					values[axis.GetID()] = append(values[axis.GetID()], option.GetCode())
+				}
+			}
+		}
+
+		if len(values[axis.GetID()]) == 0 {
+			violations.Add("", fmt.Sprintf("Variant axis '%s' has no values.", axis.GetCaption()))
+		}
+
+		for _, value := range values[axis.GetID()] {
+			if err := productcustomfield.NewProductCustomField().SetValueString(axis, value); err != nil {
+				violations.Add("", err.Error())
+			}
+		}
+	}
+
+	if !violations.IsValid() {
+		return nil, violations
+	}
+
+	// Each combination extends the combinations of the previous axes by every value of the axis
+	combinations := [][]string{{}}
+	for _, axis := range axes {
+		next := make([][]string, 0)
+		for _, combination := range combinations {
+			for _, value := range values[axis.GetID()] {
+				next = append(next, append(append([]string{}, combination...), value))
+			}
+		}
+		combinations = next
+	}
+
+	variants := make([]*Product, 0)
+	for _, combination := range combinations {
+		variant := NewProduct()
+		variant.CatalogueCode = parent.GetCatalogueCode()
+		variant.ParentID = parent.GetID()
+		variant.Code = parent.GetCode() + "-" + strings.Join(combination, "-")
+		variant.Status = parent.GetStatus()
+		if !lifecycle.IsInitial(variant.Status) {
+			variant.Status = lifecycle.Draft.String()
+		}
+
+		for _, uom := range parent.UnitOfMeasures {
+			variantUom := *uom
+			variantUom.ID = 0
+			variantUom.ProdID = 0
+			variantUom.ChangeMode = changemode.Add
+			variant.UnitOfMeasures = append(variant.UnitOfMeasures, &variantUom)
+		}
+
+		// Values have been checked to parse above
+		for i, axis := range axes {
+			field := productcustomfield.NewProductCustomField()
+			field.FieldID = axis.GetID()
+			field.ChangeMode = changemode.Add
+			field.SetValueString(axis, combination[i])
+			variant.CustomFields = append(variant.CustomFields, field)
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return variants, violations
+}
+
+// NewCopy - Creates copy of product in other catalogue, remapping fields and categories by fieldIDs and categoryIDs
+func (prod *Product) NewCopy(clg *catalogue.Catalogue, otherClg *catalogue.Catalogue, fieldIDs map[int64]int64, categoryIDs map[int64]int64) *Transfer {
+	newProd := NewProduct()
+	newProd.ParentID = prod.GetParentID()
+	newProd.Code = prod.GetCode()
+	newProd.Description = prod.GetDescription()
+	newProd.Details = prod.GetDetails()
+	newProd.Status = prod.GetStatus()
+	newProd.EffectiveFrom = prod.GetEffectiveFrom()
+	newProd.EffectiveTo = prod.GetEffectiveTo()
+	newProd.UnitOfMeasures = make([]*unitofmeasure.UnitOfMeasure, 0)
+	newProd.CategoryIDs = prod.GetCategoryIDs()
+
+	for _, uom := range prod.UnitOfMeasures {
+		newUom := *uom
+		newUom.ID = 0
+		newUom.ProdID = 0
+		newUom.ChangeMode = changemode.Add
+		newProd.UnitOfMeasures = append(newProd.UnitOfMeasures, &newUom)
+	}
+
+	for _, field := range prod.CustomFields {
+		newField := *field
+		newField.ID = 0
+		newField.ProdID = 0
+		newField.ChangeMode = changemode.Add
+		newProd.CustomFields = append(newProd.CustomFields, &newField)
+	}
+
+	return newProd.MoveTo(clg, otherClg, fieldIDs, categoryIDs)
+}
+
+// MoveTo - Moves product to other catalogue, custom fields and categories remapped by fieldIDs and categoryIDs
+func (prod *Product) MoveTo(clg *catalogue.Catalogue, otherClg *catalogue.Catalogue, fieldIDs map[int64]int64, categoryIDs map[int64]int64) *Transfer {
+	result := &Transfer{Product: prod, UnmappedFields: make([]string, 0), UnmappedCategories: make([]string, 0)}
+
+	prod.CatalogueCode = otherClg.GetCode()
+
+	fields := make([]*productcustomfield.ProductCustomField, 0)
+	for _, field := range prod.CustomFields {
+		// Inherited values belong to the parent
+		if field.IsInherited() {
+			continue
+		}
+
+		fieldID, ok := fieldIDs[field.GetFieldID()]
+		if !ok {
+			if fieldDef := clg.GetCustomFieldDefinition(field.GetFieldID()); fieldDef != nil {
+				result.UnmappedFields = append(result.UnmappedFields, fieldDef.GetCaption())
+			}
+			continue
+		}
+
+		newField := *field
+		newField.FieldID = fieldID
+		fields = append(fields, &newField)
+	}
+	prod.CustomFields = fields
+
+	newCategoryIDs := make([]int64, 0)
+	for _, categoryID := range prod.CategoryIDs {
+		newCategoryID, ok := categoryIDs[categoryID]
+		if !ok {
+			if cat := clg.GetCategory(categoryID); cat != nil {
+				result.UnmappedCategories = append(result.UnmappedCategories, cat.GetCode())
+			}
+			continue
+		}
+
+		newCategoryIDs = append(newCategoryIDs, newCategoryID)
+	}
+	prod.CategoryIDs = newCategoryIDs
+
+	return result
+}
+
+// IsTransferred - Whether every custom field and category of product was mapped to the other catalogue
+func (transfer *Transfer) IsTransferred() bool {
+	return len(transfer.UnmappedFields) == 0 && len(transfer.UnmappedCategories) == 0
+}
+
+// NewSchema - Creates json schema (draft 2020-12) of new product in catalogue
+func NewSchema(clg *catalogue.Catalogue) *jsonschema.Schema {
+	schema := jsonschema.FromModel(Product{})
+	schema.Schema = jsonschema.Draft
+	schema.ID = fmt.Sprintf("/v1/catalogues/%s/schema", clg.GetCode())
+	schema.Title = clg.GetDescription()
+
+	schema.Properties["clg_code"].Const = clg.GetCode()
+	schema.Required = append([]string{"clg_code"}, schema.Required...)
+
+	// Exactly one unit of measure with ratio 1 is the default one
+	uoms := schema.Properties["uoms"]
+	uoms.Contains = &jsonschema.Schema{
+		Properties: map[string]*jsonschema.Schema{"ratio": {Const: 1}},
+		Required:   []string{"ratio"},
+	}
+	uoms.MinContains = jsonschema.Int(1)
+	uoms.MaxContains = jsonschema.Int(1)
+	schema.Required = append(schema.Required, "uoms")
+
+	schema.Properties["custom_fields"].Items = productcustomfield.NewSchema(clg.GetAllCustomFieldDefinitions())
+
+	return schema
+}
+
+// DoValidateVariant - Validate variant against its parent, variant must specify every variant axis itself
+func (prod *Product) DoValidateVariant(parent *Product, clg *catalogue.Catalogue) validation.Violations {
+	violations := make(validation.Violations, 0)
+
+	if parent.IsVariant() {
+		violations.Add(validation.Pointer("parent_id"), fmt.Sprintf("Product '%s' is a variant and can not have variants.", parent.GetCode()))
+		return violations
+	}
+
+	if parent.GetCatalogueCode() != prod.GetCatalogueCode() {
+		violations.Add(validation.Pointer("parent_id"), fmt.Sprintf("Product '%s' is not in catalogue '%s'.", parent.GetCode(), prod.GetCatalogueCode()))
+		return violations
+	}
+
+	axes := clg.GetVariantAxes()
+	if len(axes) == 0 {
+		violations.Add(validation.Pointer("parent_id"), fmt.Sprintf("Catalogue '%s' has no variant axes.", clg.GetCode()))
+	}
+
+	for _, axis := range axes {
+		field := prod.GetCustomFieldByFieldID(axis.GetID())
+		if field == nil || field.GetChangeMode() == changemode.Delete || field.IsInherited() || !field.IsSpecified(axis) {
+			violations.Add(validation.Pointer("custom_fields"), fmt.Sprintf("Variant must specify Custom Field '%s'.", axis.GetCaption()))
+		}
+	}
+
+	return violations
+}
+
+// DoValidate - Validate product, units of measure are validated by their tags
+func (prod *Product) DoValidate(otherProd *Product, clg *catalogue.Catalogue) validation.Violations {
+	violations := validation.Validate(prod)
+
+	if otherProd == nil && !lifecycle.IsInitial(prod.GetStatus()) {
+		violations.Add(validation.Pointer("status"), "Product can only be created as draft or active.")
+	} else if otherProd != nil && !lifecycle.IsTransitionAllowed(otherProd.GetStatus(), prod.GetStatus()) {
+		violations.Add(validation.Pointer("status"), fmt.Sprintf("Product status can not change from '%s' to '%s'.", otherProd.GetStatus(), prod.GetStatus()))
+	}
+
+	if prod.GetEffectiveFrom() != nil && prod.GetEffectiveTo() != nil && prod.GetEffectiveTo().Before(*prod.GetEffectiveFrom()) {
+		violations.Add(validation.Pointer("effective_to"), "Effective to can not be before effective from.")
+	}
 
-	ok, message = prod.DoValidateBase(*prod)
-	if !ok {
-		return false, message
+	var otherDefaultUom *unitofmeasure.UnitOfMeasure
+	if otherProd != nil {
+		otherDefaultUom = otherProd.GetDefaultUom()
 	}
 
 	nbrDefaultUom := prod.GetNumberOfDefaultUom()
 	if nbrDefaultUom == 0 {
 		// If existing default uom has been changed to be non-default uom
-		if otherProd == nil || prod.GetUom(otherProd.GetDefaultUom().GetID()) != nil {
-			return false, "No default unit of measure."
+		if otherDefaultUom == nil || prod.GetUom(otherDefaultUom.GetID()) != nil {
+			violations.Add(validation.Pointer("uoms"), "No default unit of measure.")
 		}
 	} else if nbrDefaultUom == 1 {
-		// If existing default uom is different with updated default uom
-		if otherProd != nil && prod.GetDefaultUom().GetID() != otherProd.GetDefaultUom().GetID() {
-			return false, "Found multiple default unit of measure."
+		// If existing default uom is different with updated default uom, and is kept as it is
+		if otherDefaultUom != nil && prod.GetDefaultUom().GetID() != otherDefaultUom.GetID() && prod.GetUom(otherDefaultUom.GetID()) == nil {
+			violations.Add(validation.Pointer("uoms"), "Found multiple default unit of measure.")
 		}
 	} else if nbrDefaultUom > 1 {
-		return false, "Found multiple default unit of measure."
+		violations.Add(validation.Pointer("uoms"), "Found multiple default unit of measure.")
 	}
 
-	for _, uom := range prod.UnitOfMeasures {
-		ok, message = uom.DoValidate()
-		if !ok {
-			return false, message
-		}
+	applicable := make(map[int64]bool)
+	for _, fieldDef := range clg.GetApplicableFieldDefinitions(prod.GetCategoryIDs()) {
+		applicable[fieldDef.GetID()] = true
 	}
 
-	for _, field := range prod.CustomFields {
+	for i, field := range prod.CustomFields {
 		fieldDef := clg.GetCustomFieldDefinition(field.GetFieldID())
+		if fieldDef != nil && !applicable[fieldDef.GetID()] && field.GetChangeMode() != changemode.Delete {
+			violations.Add(validation.Pointer("custom_fields", i, "field_id"), fmt.Sprintf("Custom Field '%s' does not apply to categories of product.", fieldDef.GetCaption()))
+			continue
+		}
 
-		ok, message = field.DoValidate(fieldDef)
-		if !ok {
-			return false, message
+		var oldField *productcustomfield.ProductCustomField
+		if otherProd != nil {
+			oldField = otherProd.GetCustomFieldByFieldID(field.GetFieldID())
+		}
+
+		violations.Append(validation.Pointer("custom_fields", i), field.DoValidate(fieldDef, oldField))
+	}
+
+	for _, fieldDef := range clg.GetApplicableFieldDefinitions(prod.GetCategoryIDs()) {
+		if !fieldDef.GetMandatory() || fieldDef.HasDefaultValue() || fieldDef.GetType() == definitiontype.Boolean.String() {
+			continue
+		}
+
+		field := prod.GetCustomFieldByFieldID(fieldDef.GetID())
+		if field == nil || field.GetChangeMode() == changemode.Delete {
+			violations.Add(validation.Pointer("custom_fields"), fmt.Sprintf("Custom Field '%s' must be specified.", fieldDef.GetCaption()))
+		}
+	}
+
+	categoryIDs := make(map[int64]bool)
+	for i, categoryID := range prod.CategoryIDs {
+		path := validation.Pointer("category_ids", i)
+		if clg.GetCategory(categoryID) == nil {
+			violations.Add(path, fmt.Sprintf("Category '%d' is not in catalogue '%s'.", categoryID, clg.GetCode()))
+		} else if categoryIDs[categoryID] {
+			violations.Add(path, fmt.Sprintf("Category '%d' is duplicated.", categoryID))
+		}
+		categoryIDs[categoryID] = true
+	}
+
+	return violations
+}
+
+// isEqualIDs - Whether both lists hold the same ids, regardless of their order
+func isEqualIDs(ids []int64, otherIDs []int64) bool {
+	if len(ids) != len(otherIDs) {
+		return false
+	}
+
+	counts := make(map[int64]int)
+	for _, id := range ids {
+		counts[id]++
+	}
+
+	for _, id := range otherIDs {
+		if counts[id] == 0 {
+			return false
 		}
+		counts[id]--
 	}
 
-	return true, ""
+	return true
 }
@@ -1,10 +1,21 @@
 package productcustomfield
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/decimal"
 	"github.com/bungysheep/catalogue-api/pkg/commons/definitiontype"
+	"github.com/bungysheep/catalogue-api/pkg/commons/jsonschema"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
 	"github.com/bungysheep/catalogue-api/pkg/configs"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/customfielddefinition"
@@ -13,12 +24,18 @@ import (
 // ProductCustomField type
 type ProductCustomField struct {
 	basemodel.BaseModel
-	ID           int64     `json:"id"`
-	ProdID       int64     `json:"prod_id"`
-	FieldID      int64     `json:"field_id"`
-	AlphaValue   string    `json:"alpha_value" max_length:"64"`
-	NumericValue float64   `json:"numeric_value"`
-	DateValue    time.Time `json:"date_value"`
+	ID           int64                 `json:"id"`
+	ProdID       int64                 `json:"prod_id"`
+	FieldID      int64                 `json:"field_id"`
+	AlphaValue   string                `json:"alpha_value" max_length:"64"`
+	NumericValue float64               `json:"numeric_value"`
+	DateValue    time.Time             `json:"date_value"`
+	BooleanValue bool                  `json:"boolean_value"`
+	IntegerValue int64                 `json:"integer_value"`
+	DecimalValue decimal.Decimal       `json:"decimal_value"`
+	MultiValue   []string              `json:"multi_value"`
+	Inherited    bool                  `json:"inherited"`
+	ChangeMode   changemode.ChangeMode `json:"change_mode"`
 }
 
 // NewProductCustomField - Creates product custom field
@@ -26,6 +43,21 @@ func NewProductCustomField() *ProductCustomField {
 	return &ProductCustomField{}
 }
 
+// NewDefaultProductCustomField - Creates product custom field holding default value of definition
+func NewDefaultProductCustomField(fieldDef *customfielddefinition.CustomFieldDefinition) (*ProductCustomField, error) {
+	pcf := NewProductCustomField()
+	pcf.FieldID = fieldDef.GetID()
+	pcf.ChangeMode = changemode.Add
+
+	if err := pcf.SetValueString(fieldDef, fieldDef.GetDefaultValue()); err != nil {
+		return nil, err
+	}
+
+	pcf.clearValuesExcept(fieldDef.GetType())
+
+	return pcf, nil
+}
+
 // GetID - Returns product custom field id
 func (pcf *ProductCustomField) GetID() int64 {
 	return pcf.ID
@@ -56,40 +88,622 @@ func (pcf *ProductCustomField) GetDateValue() time.Time {
 	return pcf.DateValue
 }
 
-// DoValidate - Validate product custom field
-func (pcf *ProductCustomField) DoValidate(fieldDef *customfielddefinition.CustomFieldDefinition) (bool, string) {
+// GetBooleanValue - Returns boolean value
+func (pcf *ProductCustomField) GetBooleanValue() bool {
+	return pcf.BooleanValue
+}
+
+// GetIntegerValue - Returns integer value
+func (pcf *ProductCustomField) GetIntegerValue() int64 {
+	return pcf.IntegerValue
+}
+
+// GetDecimalValue - Returns decimal value
+func (pcf *ProductCustomField) GetDecimalValue() decimal.Decimal {
+	return pcf.DecimalValue
+}
+
+// GetMultiValue - Returns multi-select value
+func (pcf *ProductCustomField) GetMultiValue() []string {
+	return pcf.MultiValue
+}
+
+// HasMultiValue - Whether multi-select value contains the code
+func (pcf *ProductCustomField) HasMultiValue(code string) bool {
+	for _, value := range pcf.MultiValue {
+		if value == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsInherited - Whether value of variant is inherited from its parent
+func (pcf *ProductCustomField) IsInherited() bool {
+	return pcf.Inherited
+}
+
+// IsEqualValue - Whether value equals value of other product custom field
+func (pcf *ProductCustomField) IsEqualValue(otherField *ProductCustomField) bool {
+	if len(pcf.MultiValue) != len(otherField.GetMultiValue()) {
+		return false
+	}
+
+	for i, value := range pcf.MultiValue {
+		if value != otherField.GetMultiValue()[i] {
+			return false
+		}
+	}
+
+	return pcf.AlphaValue == otherField.GetAlphaValue() &&
+		pcf.NumericValue == otherField.GetNumericValue() &&
+		pcf.DateValue.Equal(otherField.GetDateValue()) &&
+		pcf.BooleanValue == otherField.GetBooleanValue() &&
+		pcf.IntegerValue == otherField.GetIntegerValue() &&
+		pcf.DecimalValue.Equal(otherField.GetDecimalValue())
+}
+
+// GetChangeMode - Returns change mode
+func (pcf *ProductCustomField) GetChangeMode() changemode.ChangeMode {
+	return pcf.ChangeMode
+}
+
+// SetValueString - Sets value from its text representation based on definition type
+func (pcf *ProductCustomField) SetValueString(fieldDef *customfielddefinition.CustomFieldDefinition, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch fieldDef.GetType() {
+	case definitiontype.Alphanumeric.String(), definitiontype.URL.String(), definitiontype.Email.String(), definitiontype.Picklist.String():
+		pcf.AlphaValue = value
+
+	case definitiontype.MultiSelect.String():
+		pcf.MultiValue = make([]string, 0)
+		for _, item := range strings.Split(value, configs.MULTIVALUESEPARATOR) {
+			if item = strings.TrimSpace(item); item != "" {
+				pcf.MultiValue = append(pcf.MultiValue, item)
+			}
+		}
+
+	case definitiontype.Numeric.String():
+		if value == "" {
+			pcf.NumericValue = 0
+			return nil
+		}
+
+		numericValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("Custom Field '%s' value '%s' is not numeric.", fieldDef.GetCaption(), value)
+		}
+		pcf.NumericValue = numericValue
+
+	case definitiontype.Date.String():
+		if value == "" {
+			pcf.DateValue, _ = time.Parse(configs.DATEFORMAT, configs.DEFAULTDATE)
+			return nil
+		}
+
+		dateValue, err := time.Parse(configs.SHORTDATEFORMAT, value)
+		if err != nil {
+			dateValue, err = time.Parse(time.RFC3339, value)
+			if err != nil {
+				return fmt.Errorf("Custom Field '%s' value '%s' is not a date.", fieldDef.GetCaption(), value)
+			}
+		}
+		pcf.DateValue = dateValue
+
+	case definitiontype.Boolean.String():
+		if value == "" {
+			pcf.BooleanValue = false
+			return nil
+		}
+
+		booleanValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("Custom Field '%s' value '%s' is not a boolean.", fieldDef.GetCaption(), value)
+		}
+		pcf.BooleanValue = booleanValue
+
+	case definitiontype.Integer.String():
+		if value == "" {
+			pcf.IntegerValue = 0
+			return nil
+		}
+
+		integerValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Custom Field '%s' value '%s' is not an integer.", fieldDef.GetCaption(), value)
+		}
+		pcf.IntegerValue = integerValue
+
+	case definitiontype.Decimal.String():
+		if value == "" {
+			pcf.DecimalValue = decimal.Decimal{}
+			return nil
+		}
+
+		decimalValue, err := decimal.Parse(value)
+		if err != nil {
+			return fmt.Errorf("Custom Field '%s' value '%s' is not a decimal.", fieldDef.GetCaption(), value)
+		}
+		pcf.DecimalValue = decimalValue
+
+	}
+
+	return nil
+}
+
+// GetValueString - Returns text representation of value based on definition type
+func (pcf *ProductCustomField) GetValueString(fieldDef *customfielddefinition.CustomFieldDefinition) string {
 	defaultDate, _ := time.Parse(configs.DATEFORMAT, configs.DEFAULTDATE)
 
+	switch fieldDef.GetType() {
+	case definitiontype.Numeric.String():
+		return strconv.FormatFloat(pcf.GetNumericValue(), 'f', -1, 64)
+
+	case definitiontype.Date.String():
+		if pcf.GetDateValue().Equal(defaultDate) {
+			return ""
+		}
+		return pcf.GetDateValue().Format(configs.SHORTDATEFORMAT)
+
+	case definitiontype.Boolean.String():
+		return strconv.FormatBool(pcf.GetBooleanValue())
+
+	case definitiontype.Integer.String():
+		return strconv.FormatInt(pcf.GetIntegerValue(), 10)
+
+	case definitiontype.Decimal.String():
+		return pcf.GetDecimalValue().String()
+
+	case definitiontype.MultiSelect.String():
+		return strings.Join(pcf.GetMultiValue(), configs.MULTIVALUESEPARATOR)
+
+	}
+
+	return pcf.GetAlphaValue()
+}
+
+// DoValidate - Validate product custom field, oldField is the stored value if any
+func (pcf *ProductCustomField) DoValidate(fieldDef *customfielddefinition.CustomFieldDefinition, oldField *ProductCustomField) validation.Violations {
+	violations := make(validation.Violations, 0)
+
 	if fieldDef == nil {
-		return false, fmt.Sprintf("Custom Field '%d' has invalid definition.", pcf.GetFieldID())
+		violations.Add(validation.Pointer("field_id"), fmt.Sprintf("Custom Field '%d' has invalid definition.", pcf.GetFieldID()))
+		return violations
+	}
+
+	pcf.clearValuesExcept(fieldDef.GetType())
+	path := validation.Pointer(valueProperty(fieldDef.GetType()))
+
+	if !pcf.IsSpecified(fieldDef) {
+		if fieldDef.GetMandatory() {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' must be specified.", fieldDef.GetCaption()))
+			return violations
+		}
+
+		violations.Append("", validation.Validate(pcf))
+		return violations
 	}
 
 	switch fieldDef.GetType() {
-	case definitiontype.Alphanumeric.String():
-		pcf.NumericValue = 0
-		pcf.DateValue = defaultDate
+	case definitiontype.Decimal.String():
+		// Values are stored with exactly the definition precision, never rounded
+		if pcf.GetDecimalValue().Normalize().Scale() > fieldDef.GetPrecision() {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' can not have more than %d decimal places.", fieldDef.GetCaption(), fieldDef.GetPrecision()))
+		} else {
+			pcf.DecimalValue = pcf.GetDecimalValue().Rescale(fieldDef.GetPrecision())
+		}
+
+	case definitiontype.URL.String():
+		if !isValidURL(pcf.GetAlphaValue()) {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' is not a valid url.", fieldDef.GetCaption()))
+		}
 
-		if fieldDef.GetMandatory() && pcf.GetAlphaValue() == "" {
-			return false, fmt.Sprintf("Custom Field '%d' must be specified.", pcf.GetFieldID())
+	case definitiontype.Email.String():
+		if !isValidEmail(pcf.GetAlphaValue()) {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' is not a valid email.", fieldDef.GetCaption()))
 		}
 
+	case definitiontype.Picklist.String():
+		keepRetired := oldField != nil && oldField.GetAlphaValue() == pcf.GetAlphaValue()
+		if ok, message := validateOption(fieldDef, pcf.GetAlphaValue(), keepRetired); !ok {
+			violations.Add(path, message)
+		}
+
+	case definitiontype.MultiSelect.String():
+		codes := make(map[string]bool)
+		for i, code := range pcf.GetMultiValue() {
+			if codes[code] {
+				violations.Add(path+validation.Pointer(i), fmt.Sprintf("Custom Field '%s' has duplicate option '%s'.", fieldDef.GetCaption(), code))
+				continue
+			}
+			codes[code] = true
+
+			keepRetired := oldField != nil && oldField.HasMultiValue(code)
+			if ok, message := validateOption(fieldDef, code, keepRetired); !ok {
+				violations.Add(path+validation.Pointer(i), message)
+			}
+		}
+
+	}
+
+	pcf.validateRules(fieldDef, path, &violations)
+
+	violations.Append("", validation.Validate(pcf))
+
+	return violations
+}
+
+// IsSpecified - Whether value has been specified, zero numbers, default date and empty text are not
+func (pcf *ProductCustomField) IsSpecified(fieldDef *customfielddefinition.CustomFieldDefinition) bool {
+	defaultDate, _ := time.Parse(configs.DATEFORMAT, configs.DEFAULTDATE)
+
+	switch fieldDef.GetType() {
 	case definitiontype.Numeric.String():
-		pcf.AlphaValue = ""
-		pcf.DateValue = defaultDate
+		return pcf.GetNumericValue() != 0
+
+	case definitiontype.Date.String():
+		return !pcf.GetDateValue().Equal(defaultDate)
+
+	case definitiontype.Boolean.String():
+		return true
 
-		if fieldDef.GetMandatory() && pcf.GetNumericValue() == 0 {
-			return false, fmt.Sprintf("Custom Field '%d' must be specified.", pcf.GetFieldID())
+	case definitiontype.Integer.String():
+		return pcf.GetIntegerValue() != 0
+
+	case definitiontype.Decimal.String():
+		return !pcf.GetDecimalValue().IsZero()
+
+	case definitiontype.MultiSelect.String():
+		return len(pcf.GetMultiValue()) > 0
+
+	}
+
+	return pcf.GetAlphaValue() != ""
+}
+
+// GetNumberValue - Returns numeric, integer or decimal value as exact decimal
+func (pcf *ProductCustomField) GetNumberValue(fieldDef *customfielddefinition.CustomFieldDefinition) decimal.Decimal {
+	switch fieldDef.GetType() {
+	case definitiontype.Numeric.String():
+		return decimal.FromFloat(pcf.GetNumericValue())
+
+	case definitiontype.Integer.String():
+		return decimal.New(pcf.GetIntegerValue(), 0)
+
+	}
+
+	return pcf.GetDecimalValue()
+}
+
+// validateRules - Validate specified value against definition constraints
+func (pcf *ProductCustomField) validateRules(fieldDef *customfielddefinition.CustomFieldDefinition, path string, violations *validation.Violations) {
+	if fieldDef.IsText() {
+		length := utf8.RuneCountInString(pcf.GetAlphaValue())
+		if fieldDef.GetMinLength() > 0 && length < fieldDef.GetMinLength() {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' can not be less than %d chars.", fieldDef.GetCaption(), fieldDef.GetMinLength()))
 		}
 
+		if fieldDef.GetMaxLength() > 0 && length > fieldDef.GetMaxLength() {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' can not be more than %d chars.", fieldDef.GetCaption(), fieldDef.GetMaxLength()))
+		}
+
+		if fieldDef.GetPattern() != "" {
+			if matched, _ := regexp.MatchString(fieldDef.GetPattern(), pcf.GetAlphaValue()); !matched {
+				violations.Add(path, fmt.Sprintf("Custom Field '%s' does not match pattern '%s'.", fieldDef.GetCaption(), fieldDef.GetPattern()))
+			}
+		}
+	}
+
+	if fieldDef.IsNumeric() {
+		value := pcf.GetNumberValue(fieldDef)
+
+		if minValue := fieldDef.GetMinValue(); minValue != nil && value.Cmp(*minValue) < 0 {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' can not be less than %s.", fieldDef.GetCaption(), minValue))
+		}
+
+		if maxValue := fieldDef.GetMaxValue(); maxValue != nil && value.Cmp(*maxValue) > 0 {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' can not be more than %s.", fieldDef.GetCaption(), maxValue))
+		}
+
+		if step := fieldDef.GetStep(); step != nil {
+			base := decimal.Decimal{}
+			if fieldDef.GetMinValue() != nil {
+				base = *fieldDef.GetMinValue()
+			}
+
+			if !value.Sub(base).IsMultipleOf(*step) {
+				violations.Add(path, fmt.Sprintf("Custom Field '%s' must be in steps of %s.", fieldDef.GetCaption(), step))
+			}
+		}
+	}
+
+	if fieldDef.GetType() == definitiontype.Date.String() {
+		if minDate := fieldDef.GetMinDate(); minDate != nil && pcf.GetDateValue().Before(*minDate) {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' can not be before %s.", fieldDef.GetCaption(), minDate.Format(configs.SHORTDATEFORMAT)))
+		}
+
+		if maxDate := fieldDef.GetMaxDate(); maxDate != nil && pcf.GetDateValue().After(*maxDate) {
+			violations.Add(path, fmt.Sprintf("Custom Field '%s' can not be after %s.", fieldDef.GetCaption(), maxDate.Format(configs.SHORTDATEFORMAT)))
+		}
+	}
+}
+
+// NewSchema - Creates json schema of product custom field, constraints of each definition apply to its value
+func NewSchema(fieldDefs []*customfielddefinition.CustomFieldDefinition) *jsonschema.Schema {
+	schema := jsonschema.FromModel(ProductCustomField{})
+	schema.Required = []string{"field_id"}
+
+	decimalValue := &jsonschema.Schema{Type: []string{"string", "number"}}
+	decimalValue.Pattern = `^-?[0-9]+(\.[0-9]+)?$`
+	schema.Properties["decimal_value"] = decimalValue
+
+	fieldIDs := make([]interface{}, 0)
+	for _, fieldDef := range fieldDefs {
+		fieldIDs = append(fieldIDs, fieldDef.GetID())
+
+		then := &jsonschema.Schema{
+			Properties: map[string]*jsonschema.Schema{valueProperty(fieldDef.GetType()): newValueSchema(fieldDef)},
+		}
+		// A boolean is always specified, even when left out
+		if fieldDef.GetMandatory() && !fieldDef.HasDefaultValue() && fieldDef.GetType() != definitiontype.Boolean.String() {
+			then.Required = []string{valueProperty(fieldDef.GetType())}
+		}
+
+		schema.AllOf = append(schema.AllOf, &jsonschema.Schema{
+			If: &jsonschema.Schema{
+				Properties: map[string]*jsonschema.Schema{"field_id": {Const: fieldDef.GetID()}},
+				Required:   []string{"field_id"},
+			},
+			Then: then,
+		})
+	}
+	schema.Properties["field_id"].Enum = fieldIDs
+
+	return schema
+}
+
+// newValueSchema - Creates json schema of value property holding the definition type
+func newValueSchema(fieldDef *customfielddefinition.CustomFieldDefinition) *jsonschema.Schema {
+	isRequired := fieldDef.GetMandatory() && !fieldDef.HasDefaultValue()
+	unspecified := unspecifiedSchema(fieldDef.GetType())
+
+	rules := &jsonschema.Schema{}
+	switch fieldDef.GetType() {
+	case definitiontype.Alphanumeric.String(), definitiontype.URL.String(), definitiontype.Email.String():
+		if fieldDef.GetMinLength() > 0 {
+			rules.MinLength = jsonschema.Int(fieldDef.GetMinLength())
+		}
+
+		if fieldDef.GetMaxLength() > 0 {
+			rules.MaxLength = jsonschema.Int(fieldDef.GetMaxLength())
+		}
+
+		rules.Pattern = fieldDef.GetPattern()
+
+		if fieldDef.GetType() == definitiontype.URL.String() {
+			rules.Format = "uri"
+			rules.AllOf = []*jsonschema.Schema{{Pattern: "^https?://[^/?#]+"}}
+		} else if fieldDef.GetType() == definitiontype.Email.String() {
+			rules.Format = "email"
+		}
+
+	case definitiontype.Picklist.String():
+		rules.Enum = activeOptionCodes(fieldDef)
+
+	case definitiontype.MultiSelect.String():
+		rules.Items = &jsonschema.Schema{Enum: activeOptionCodes(fieldDef)}
+		rules.UniqueItems = true
+		if isRequired {
+			rules.MinItems = jsonschema.Int(1)
+		}
+
+	case definitiontype.Numeric.String(), definitiontype.Integer.String(), definitiontype.Decimal.String():
+		if fieldDef.GetType() == definitiontype.Decimal.String() {
+			// Trailing zeros beyond precision are accepted, the value is normalized before checking scale.
+			// Pattern only applies to json strings, minimum and maximum to json numbers.
+			rules.Pattern = fmt.Sprintf(`^-?[0-9]+(\.[0-9]{0,%d}0*)?$`, fieldDef.GetPrecision())
+		}
+
+		if minValue := fieldDef.GetMinValue(); minValue != nil {
+			rules.Minimum = json.Number(minValue.String())
+		}
+
+		if maxValue := fieldDef.GetMaxValue(); maxValue != nil {
+			rules.Maximum = json.Number(maxValue.String())
+		}
+
+		// Steps are counted from minimum value, multipleOf only matches when minimum is itself a step
+		if step := fieldDef.GetStep(); step != nil {
+			base := decimal.Decimal{}
+			if fieldDef.GetMinValue() != nil {
+				base = *fieldDef.GetMinValue()
+			}
+
+			if base.IsMultipleOf(*step) {
+				rules.MultipleOf = json.Number(step.String())
+			}
+		}
+
+	}
+
+	value := rules
+	if unspecified != nil {
+		if isRequired {
+			rules.Not = unspecified
+		} else {
+			value = &jsonschema.Schema{AnyOf: []*jsonschema.Schema{unspecified, rules}}
+		}
+	}
+
+	value.Title = fieldDef.GetCaption()
+	value.Description = fieldDef.GetHelpText()
+	if fieldDef.HasDefaultValue() {
+		if defaultField, err := NewDefaultProductCustomField(fieldDef); err == nil {
+			value.Default = defaultField.getJSONValue(fieldDef.GetType())
+		}
+	}
+
+	return value
+}
+
+// unspecifiedSchema - Returns json schema matching values IsSpecified treats as not specified
+func unspecifiedSchema(fieldType string) *jsonschema.Schema {
+	switch fieldType {
+	case definitiontype.Numeric.String(), definitiontype.Integer.String():
+		return &jsonschema.Schema{Const: 0}
+
+	case definitiontype.Decimal.String():
+		return &jsonschema.Schema{AnyOf: []*jsonschema.Schema{{Const: 0}, {Const: ""}, {Type: "string", Pattern: `^-?0+(\.0*)?$`}}}
+
 	case definitiontype.Date.String():
+		defaultDate, _ := time.Parse(configs.DATEFORMAT, configs.DEFAULTDATE)
+		return &jsonschema.Schema{Const: defaultDate.Format(time.RFC3339)}
+
+	case definitiontype.Boolean.String(), definitiontype.MultiSelect.String():
+		return nil
+
+	}
+
+	return &jsonschema.Schema{Const: ""}
+}
+
+// valueProperty - Returns json property holding value of definition type
+func valueProperty(fieldType string) string {
+	switch fieldType {
+	case definitiontype.Numeric.String():
+		return "numeric_value"
+
+	case definitiontype.Date.String():
+		return "date_value"
+
+	case definitiontype.Boolean.String():
+		return "boolean_value"
+
+	case definitiontype.Integer.String():
+		return "integer_value"
+
+	case definitiontype.Decimal.String():
+		return "decimal_value"
+
+	case definitiontype.MultiSelect.String():
+		return "multi_value"
+
+	}
+
+	return "alpha_value"
+}
+
+// getJSONValue - Returns value of definition type as encoded in json
+func (pcf *ProductCustomField) getJSONValue(fieldType string) interface{} {
+	switch fieldType {
+	case definitiontype.Numeric.String():
+		return pcf.GetNumericValue()
+
+	case definitiontype.Date.String():
+		return pcf.GetDateValue()
+
+	case definitiontype.Boolean.String():
+		return pcf.GetBooleanValue()
+
+	case definitiontype.Integer.String():
+		return pcf.GetIntegerValue()
+
+	case definitiontype.Decimal.String():
+		return pcf.GetDecimalValue()
+
+	case definitiontype.MultiSelect.String():
+		return pcf.GetMultiValue()
+
+	}
+
+	return pcf.GetAlphaValue()
+}
+
+// activeOptionCodes - Returns codes of options new values can refer to
+func activeOptionCodes(fieldDef *customfielddefinition.CustomFieldDefinition) []interface{} {
+	codes := make([]interface{}, 0)
+	for _, option := range fieldDef.GetAllOptions() {
+		if option.IsActive() {
+			codes = append(codes, option.GetCode())
+		}
+	}
+
+	return codes
+}
+
+// clearValuesExcept - Resets values not used by the definition type
+func (pcf *ProductCustomField) clearValuesExcept(fieldType string) {
+	defaultDate, _ := time.Parse(configs.DATEFORMAT, configs.DEFAULTDATE)
+
+	if !isAlphaType(fieldType) {
 		pcf.AlphaValue = ""
+	}
+
+	if fieldType != definitiontype.Numeric.String() {
 		pcf.NumericValue = 0
+	}
 
-		if fieldDef.GetMandatory() && pcf.GetDateValue() == defaultDate {
-			return false, fmt.Sprintf("Custom Field '%d' must be specified.", pcf.GetFieldID())
-		}
+	if fieldType != definitiontype.Date.String() {
+		pcf.DateValue = defaultDate
+	}
+
+	if fieldType != definitiontype.Boolean.String() {
+		pcf.BooleanValue = false
+	}
+
+	if fieldType != definitiontype.Integer.String() {
+		pcf.IntegerValue = 0
+	}
+
+	if fieldType != definitiontype.Decimal.String() {
+		pcf.DecimalValue = decimal.Decimal{}
+	}
+
+	if fieldType != definitiontype.MultiSelect.String() {
+		pcf.MultiValue = nil
+	} else if pcf.MultiValue == nil {
+		pcf.MultiValue = make([]string, 0)
+	}
+}
+
+// validateOption - Validate code is an option of definition, retired options only when already held
+func validateOption(fieldDef *customfielddefinition.CustomFieldDefinition, code string, keepRetired bool) (bool, string) {
+	option := fieldDef.GetOptionByCode(code)
+	if option == nil {
+		return false, fmt.Sprintf("Custom Field '%s' value '%s' is not a valid option.", fieldDef.GetCaption(), code)
+	}
+
+	if !option.IsActive() && !keepRetired {
+		return false, fmt.Sprintf("Custom Field '%s' option '%s' is no longer active.", fieldDef.GetCaption(), code)
+	}
+
+	return true, ""
+}
+
+// isAlphaType - Whether definition type is stored as alpha value
+func isAlphaType(fieldType string) bool {
+	return fieldType == definitiontype.Alphanumeric.String() ||
+		fieldType == definitiontype.URL.String() ||
+		fieldType == definitiontype.Email.String() ||
+		fieldType == definitiontype.Picklist.String()
+}
+
+func isValidURL(value string) bool {
+	parsedURL, err := url.ParseRequestURI(value)
+	if err != nil {
+		return false
+	}
+
+	return (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != ""
+}
 
+func isValidEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	if err != nil {
+		return false
 	}
 
-	return pcf.DoValidateBase(*pcf)
+	return address.Address == value
 }
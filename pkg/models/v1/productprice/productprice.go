@@ -0,0 +1,82 @@
+package productprice
+
+import (
+	"strings"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/decimal"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+)
+
+// ProductPrice type, price of one unit of measure of product in price list from a minimum quantity
+type ProductPrice struct {
+	basemodel.BaseModel
+	ID       int64           `json:"id"`
+	ListCode string          `json:"list_code"`
+	ProdID   int64           `json:"prod_id"`
+	UomCode  string          `json:"uom_code" mandatory:"true" max_length:"16"`
+	MinQty   decimal.Decimal `json:"min_qty"`
+	Price    decimal.Decimal `json:"price"`
+}
+
+// NewProductPrice - Creates product price
+func NewProductPrice() *ProductPrice {
+	return &ProductPrice{}
+}
+
+// GetID - Returns product price id
+func (price *ProductPrice) GetID() int64 {
+	return price.ID
+}
+
+// GetListCode - Returns price list code
+func (price *ProductPrice) GetListCode() string {
+	return strings.ToUpper(price.ListCode)
+}
+
+// GetProdID - Returns prod id
+func (price *ProductPrice) GetProdID() int64 {
+	return price.ProdID
+}
+
+// GetUomCode - Returns uom code
+func (price *ProductPrice) GetUomCode() string {
+	return strings.ToUpper(price.UomCode)
+}
+
+// GetMinQty - Returns minimum quantity of tier
+func (price *ProductPrice) GetMinQty() decimal.Decimal {
+	return price.MinQty
+}
+
+// GetPrice - Returns unit price
+func (price *ProductPrice) GetPrice() decimal.Decimal {
+	return price.Price
+}
+
+// DoValidate - Validate product price
+func (price *ProductPrice) DoValidate() validation.Violations {
+	violations := validation.Validate(price)
+
+	if price.GetMinQty().Sign() < 0 {
+		violations.Add(validation.Pointer("min_qty"), "Minimum quantity can not be negative")
+	}
+
+	if price.GetPrice().Sign() < 0 {
+		violations.Add(validation.Pointer("price"), "Price can not be negative")
+	}
+
+	return violations
+}
+
+// EffectivePrice type, price of product resolved for quantity and date
+type EffectivePrice struct {
+	ProdID    int64           `json:"prod_id"`
+	ListCode  string          `json:"list_code"`
+	Currency  string          `json:"currency"`
+	UomCode   string          `json:"uom_code"`
+	Qty       decimal.Decimal `json:"qty"`
+	MinQty    decimal.Decimal `json:"min_qty"`
+	UnitPrice decimal.Decimal `json:"unit_price"`
+	Amount    decimal.Decimal `json:"amount"`
+}
@@ -0,0 +1,98 @@
+package productrelation
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/decimal"
+	"github.com/bungysheep/catalogue-api/pkg/commons/relationtype"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+)
+
+// ProductRelation type, directional relation from product to related product
+type ProductRelation struct {
+	basemodel.BaseModel
+	ID        int64           `json:"id"`
+	ProdID    int64           `json:"prod_id"`
+	Type      string          `json:"type" mandatory:"true" max_length:"1" valid_value:"A,S,R,B"`
+	RelatedID int64           `json:"related_id" mandatory:"true"`
+	Qty       decimal.Decimal `json:"qty"`
+	UomCode   string          `json:"uom_code" max_length:"16"`
+	CreatedBy string          `json:"created_by"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// NewProductRelation - Creates product relation
+func NewProductRelation() *ProductRelation {
+	return &ProductRelation{}
+}
+
+// GetID - Returns product relation id
+func (rel *ProductRelation) GetID() int64 {
+	return rel.ID
+}
+
+// GetProdID - Returns prod id
+func (rel *ProductRelation) GetProdID() int64 {
+	return rel.ProdID
+}
+
+// GetType - Returns relation type
+func (rel *ProductRelation) GetType() string {
+	return strings.ToUpper(rel.Type)
+}
+
+// GetRelatedID - Returns id of related product
+func (rel *ProductRelation) GetRelatedID() int64 {
+	return rel.RelatedID
+}
+
+// GetQty - Returns quantity of bundle component
+func (rel *ProductRelation) GetQty() decimal.Decimal {
+	return rel.Qty
+}
+
+// GetUomCode - Returns unit of measure of bundle component
+func (rel *ProductRelation) GetUomCode() string {
+	return strings.ToUpper(rel.UomCode)
+}
+
+// GetCreatedBy - Returns created by
+func (rel *ProductRelation) GetCreatedBy() string {
+	return rel.CreatedBy
+}
+
+// GetCreatedAt - Returns created at
+func (rel *ProductRelation) GetCreatedAt() time.Time {
+	return rel.CreatedAt
+}
+
+// IsBundle - Whether related product is a bundle component of product
+func (rel *ProductRelation) IsBundle() bool {
+	return rel.GetType() == relationtype.Bundle.String()
+}
+
+// DoValidate - Validate product relation, quantity and unit of measure are cleared when not a bundle component
+func (rel *ProductRelation) DoValidate() validation.Violations {
+	rel.Type = rel.GetType()
+	rel.UomCode = rel.GetUomCode()
+
+	violations := validation.Validate(rel)
+
+	if !rel.IsBundle() {
+		rel.Qty = decimal.Decimal{}
+		rel.UomCode = ""
+		return violations
+	}
+
+	if rel.GetQty().Sign() <= 0 {
+		violations.Add(validation.Pointer("qty"), "Quantity of bundle component must be more than zero")
+	}
+
+	if rel.GetUomCode() == "" {
+		violations.Add(validation.Pointer("uom_code"), "Unit of Measure of bundle component must be specified")
+	}
+
+	return violations
+}
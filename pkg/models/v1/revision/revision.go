@@ -0,0 +1,201 @@
+package revision
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+)
+
+// Revision type, immutable published snapshot of a catalogue
+type Revision struct {
+	basemodel.BaseModel
+	ID            int64     `json:"id"`
+	CatalogueCode string    `json:"clg_code"`
+	Revision      int64     `json:"revision"`
+	Label         string    `json:"label" max_length:"32"`
+	NbrProducts   int64     `json:"nbr_products"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	Snapshot      *Snapshot `json:"snapshot,omitempty"`
+}
+
+// Snapshot type, catalogue and its products as published
+type Snapshot struct {
+	Catalogue *catalogue.Catalogue `json:"catalogue"`
+	Products  []*product.Product   `json:"products"`
+}
+
+// Publish type, publish request
+type Publish struct {
+	Label string `json:"label"`
+}
+
+// Diff type, changes between two revisions, products are matched by id and listed by code
+type Diff struct {
+	From             int64            `json:"from"`
+	To               int64            `json:"to"`
+	CatalogueChanges []string         `json:"catalogue_changes"`
+	AddedProducts    []string         `json:"added_products"`
+	RemovedProducts  []string         `json:"removed_products"`
+	ChangedProducts  []*ProductChange `json:"changed_products"`
+}
+
+// ProductChange type, attributes of product changed between two revisions
+type ProductChange struct {
+	ID         int64    `json:"id"`
+	Code       string   `json:"code"`
+	Attributes []string `json:"attributes"`
+}
+
+// NewRevision - Creates revision
+func NewRevision() *Revision {
+	return &Revision{}
+}
+
+// GetID - Returns revision id
+func (rev *Revision) GetID() int64 {
+	return rev.ID
+}
+
+// GetCatalogueCode - Returns catalogue code
+func (rev *Revision) GetCatalogueCode() string {
+	return rev.CatalogueCode
+}
+
+// GetRevision - Returns revision number within catalogue
+func (rev *Revision) GetRevision() int64 {
+	return rev.Revision
+}
+
+// GetLabel - Returns version label
+func (rev *Revision) GetLabel() string {
+	return rev.Label
+}
+
+// GetNbrProducts - Returns number of products in snapshot
+func (rev *Revision) GetNbrProducts() int64 {
+	return rev.NbrProducts
+}
+
+// GetCreatedBy - Returns created by
+func (rev *Revision) GetCreatedBy() string {
+	return rev.CreatedBy
+}
+
+// GetCreatedAt - Returns created at
+func (rev *Revision) GetCreatedAt() time.Time {
+	return rev.CreatedAt
+}
+
+// GetSnapshot - Returns published snapshot, nil when not loaded
+func (rev *Revision) GetSnapshot() *Snapshot {
+	return rev.Snapshot
+}
+
+// GetProduct - Returns product of snapshot
+func (snapshot *Snapshot) GetProduct(id int64) *product.Product {
+	for _, prod := range snapshot.Products {
+		if prod.GetID() == id {
+			return prod
+		}
+	}
+
+	return nil
+}
+
+// DoValidate - Validate revision
+func (rev *Revision) DoValidate() validation.Violations {
+	return validation.Validate(rev)
+}
+
+// NewDiff - Returns changes from one revision to another, from nil an empty catalogue
+func NewDiff(from *Revision, to *Revision) *Diff {
+	diff := &Diff{
+		To:               to.GetRevision(),
+		CatalogueChanges: make([]string, 0),
+		AddedProducts:    make([]string, 0),
+		RemovedProducts:  make([]string, 0),
+		ChangedProducts:  make([]*ProductChange, 0),
+	}
+
+	fromSnapshot := &Snapshot{Catalogue: catalogue.NewCatalogue()}
+	if from != nil {
+		diff.From = from.GetRevision()
+		fromSnapshot = from.GetSnapshot()
+	}
+	toSnapshot := to.GetSnapshot()
+
+	fromClg, toClg := fromSnapshot.Catalogue, toSnapshot.Catalogue
+	diff.CatalogueChanges = changedAttributes(map[string][2]interface{}{
+		"description":       {fromClg.GetDescription(), toClg.GetDescription()},
+		"details":           {fromClg.GetDetails(), toClg.GetDetails()},
+		"status":            {fromClg.GetStatus(), toClg.GetStatus()},
+		"field_definitions": {fromClg.GetAllCustomFieldDefinitions(), toClg.GetAllCustomFieldDefinitions()},
+		"field_groups":      {fromClg.GetAllFieldGroups(), toClg.GetAllFieldGroups()},
+		"categories":        {fromClg.GetAllCategories(), toClg.GetAllCategories()},
+	})
+
+	for _, toProd := range toSnapshot.Products {
+		fromProd := fromSnapshot.GetProduct(toProd.GetID())
+		if fromProd == nil {
+			diff.AddedProducts = append(diff.AddedProducts, toProd.GetCode())
+			continue
+		}
+
+		attributes := changedAttributes(map[string][2]interface{}{
+			"code":           {fromProd.GetCode(), toProd.GetCode()},
+			"description":    {fromProd.GetDescription(), toProd.GetDescription()},
+			"details":        {fromProd.GetDetails(), toProd.GetDetails()},
+			"status":         {fromProd.GetStatus(), toProd.GetStatus()},
+			"effective_from": {fromProd.GetEffectiveFrom(), toProd.GetEffectiveFrom()},
+			"effective_to":   {fromProd.GetEffectiveTo(), toProd.GetEffectiveTo()},
+			"uoms":           {fromProd.GetAllUoms(), toProd.GetAllUoms()},
+			"custom_fields":  {fromProd.GetAllCustomFields(), toProd.GetAllCustomFields()},
+			"category_ids":   {fromProd.GetCategoryIDs(), toProd.GetCategoryIDs()},
+		})
+
+		if len(attributes) > 0 {
+			diff.ChangedProducts = append(diff.ChangedProducts, &ProductChange{ID: toProd.GetID(), Code: toProd.GetCode(), Attributes: attributes})
+		}
+	}
+
+	for _, fromProd := range fromSnapshot.Products {
+		if toSnapshot.GetProduct(fromProd.GetID()) == nil {
+			diff.RemovedProducts = append(diff.RemovedProducts, fromProd.GetCode())
+		}
+	}
+
+	return diff
+}
+
+// changedAttributes - Returns names of attributes whose json encoded values differ
+func changedAttributes(values map[string][2]interface{}) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changed := make([]string, 0)
+	for _, name := range names {
+		if encode(values[name][0]) != encode(values[name][1]) {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
+
+func encode(value interface{}) string {
+	data, _ := json.Marshal(value)
+	if string(data) == "null" || string(data) == "[]" {
+		return ""
+	}
+
+	return string(data)
+}
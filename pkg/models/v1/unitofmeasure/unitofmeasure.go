@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
 )
 
@@ -14,7 +15,7 @@ type UnitOfMeasure struct {
 	ProdID      int64                 `json:"prod_id"`
 	Code        string                `json:"code" mandatory:"true" max_length:"16"`
 	Description string                `json:"description" mandatory:"true" max_length:"32"`
-	Ratio       float64               `json:"ratio"`
+	Ratio       float64               `json:"ratio" mandatory:"true" min:"0"`
 	Vers        int64                 `json:"vers"`
 	ChangeMode  changemode.ChangeMode `json:"change_mode"`
 }
@@ -73,6 +74,15 @@ func (uom *UnitOfMeasure) IsEqual(otherUom *UnitOfMeasure) bool {
 }
 
 // DoValidate - Validate uom
-func (uom *UnitOfMeasure) DoValidate() (bool, string) {
-	return uom.DoValidateBase(*uom)
+func (uom *UnitOfMeasure) DoValidate() validation.Violations {
+	return validation.Validate(uom)
+}
+
+// Conversion type, quantity of product converted between units of measure
+type Conversion struct {
+	ProdID int64   `json:"prod_id"`
+	Qty    float64 `json:"qty"`
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Result float64 `json:"result"`
 }
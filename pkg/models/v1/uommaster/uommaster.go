@@ -0,0 +1,95 @@
+package uommaster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
+)
+
+// UomMaster type, unit of measure code shared by all products, factor converts it to the base unit
+type UomMaster struct {
+	basemodel.BaseModel
+	Code        string    `json:"code" mandatory:"true" max_length:"16"`
+	Description string    `json:"description" mandatory:"true" max_length:"32"`
+	Dimension   string    `json:"dimension" mandatory:"true" valid_value:"C,M,L,V"`
+	Factor      float64   `json:"factor" min:"0"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	ModifiedBy  string    `json:"modified_by"`
+	ModifiedAt  time.Time `json:"modified_at"`
+	Vers        int64     `json:"vers"`
+}
+
+// NewUomMaster - Creates unit of measure master
+func NewUomMaster() *UomMaster {
+	return &UomMaster{}
+}
+
+// GetCode - Returns uom code
+func (master *UomMaster) GetCode() string {
+	return strings.ToUpper(master.Code)
+}
+
+// GetDescription - Returns uom description
+func (master *UomMaster) GetDescription() string {
+	return master.Description
+}
+
+// GetDimension - Returns uom dimension
+func (master *UomMaster) GetDimension() string {
+	return master.Dimension
+}
+
+// GetFactor - Returns factor to base unit of dimension
+func (master *UomMaster) GetFactor() float64 {
+	return master.Factor
+}
+
+// GetCreatedBy - Returns created by
+func (master *UomMaster) GetCreatedBy() string {
+	return master.CreatedBy
+}
+
+// GetCreatedAt - Returns created at
+func (master *UomMaster) GetCreatedAt() time.Time {
+	return master.CreatedAt
+}
+
+// GetModifiedBy - Returns modified by
+func (master *UomMaster) GetModifiedBy() string {
+	return master.ModifiedBy
+}
+
+// GetModifiedAt - Returns modified at
+func (master *UomMaster) GetModifiedAt() time.Time {
+	return master.ModifiedAt
+}
+
+// GetVers - Returns vers
+func (master *UomMaster) GetVers() int64 {
+	return master.Vers
+}
+
+// IsStandard - Whether uom has a standard conversion within its dimension
+func (master *UomMaster) IsStandard() bool {
+	return master.Factor > 0
+}
+
+// IsConvertible - Whether uom converts to other uom by their standard conversions
+func (master *UomMaster) IsConvertible(otherMaster *UomMaster) bool {
+	return master.IsStandard() && otherMaster.IsStandard() && master.GetDimension() == otherMaster.GetDimension()
+}
+
+// DoValidate - Validate uom master, oldMaster is nil on create
+func (master *UomMaster) DoValidate(oldMaster *UomMaster) validation.Violations {
+	violations := validation.Validate(master)
+
+	if oldMaster != nil && oldMaster.GetDimension() != master.GetDimension() {
+		violations.Add(validation.Pointer("dimension"), fmt.Sprintf("Dimension of Unit of Measure '%s' can not be changed.", master.GetCode()))
+	}
+
+	return violations
+}
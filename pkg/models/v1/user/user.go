@@ -1,9 +1,11 @@
 package user
 
 import (
-	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
 	"strings"
 	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/basemodel"
 )
 
 // User type
@@ -77,6 +79,6 @@ func (usr *User) GetVers() int64 {
 }
 
 // DoValidate - Validate user
-func (usr *User) DoValidate() (bool, string) {
-	return usr.DoValidateBase(*usr)
+func (usr *User) DoValidate() validation.Violations {
+	return validation.Validate(usr)
 }
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"log"
 
@@ -12,6 +13,23 @@ var (
 	DbConnection *sql.DB
 )
 
+// IConnection type, connection statements are prepared on
+type IConnection interface {
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	Close() error
+}
+
+type txKey struct{}
+
+// txConnection type, connection of a transaction, which is released when the transaction ends
+type txConnection struct {
+	*sql.Tx
+}
+
+func (txConn *txConnection) Close() error {
+	return nil
+}
+
 // CreateDbConnection - Creates connection to database
 func CreateDbConnection() error {
 	log.Printf("Creating database connection...")
@@ -25,3 +43,36 @@ func CreateDbConnection() error {
 
 	return DbConnection.Ping()
 }
+
+// Conn - Returns connection of transaction of context, or a connection of the pool when there is none
+func Conn(ctx context.Context) (IConnection, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return &txConnection{tx}, nil
+	}
+
+	return DbConnection.Conn(ctx)
+}
+
+// WithTransaction - Runs fn in a transaction, or in the transaction of context when there is one
+func WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return WithTransactionOptions(ctx, nil, fn)
+}
+
+// WithTransactionOptions - Runs fn as WithTransaction does, in a transaction begun with opts
+func WithTransactionOptions(ctx context.Context, opts *sql.TxOptions, fn func(context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := DbConnection.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
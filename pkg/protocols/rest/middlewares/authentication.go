@@ -2,12 +2,12 @@ package middlewares
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net/http"
 	"strings"
 
 	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
 	"github.com/bungysheep/catalogue-api/pkg/configs"
 	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
 	"github.com/dgrijalva/jwt-go"
@@ -21,21 +21,13 @@ func AuthenticationMiddleware(next http.Handler) http.Handler {
 		authToken := r.Header.Get("Authorization")
 		authToken = strings.TrimSpace(authToken)
 		if authToken == "" {
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"success": false,
-				"message": "Missing auth token.",
-			})
+			problem.Write(w, problem.NewProblem(http.StatusUnauthorized, problem.Unauthorized, "Missing auth token."))
 			return
 		}
 
 		splittedToken := strings.Split(authToken, " ")
 		if len(splittedToken) != 2 {
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"success": false,
-				"message": "Invalid auth token.",
-			})
+			problem.Write(w, problem.NewProblem(http.StatusUnauthorized, problem.Unauthorized, "Invalid auth token."))
 			return
 		}
 
@@ -45,20 +37,12 @@ func AuthenticationMiddleware(next http.Handler) http.Handler {
 		})
 
 		if err != nil {
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"success": false,
-				"message": err.Error(),
-			})
+			problem.Write(w, problem.NewProblem(http.StatusUnauthorized, problem.Unauthorized, err.Error()))
 			return
 		}
 
 		if !token.Valid {
-			w.WriteHeader(http.StatusUnauthorized)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"success": false,
-				"message": "Invalid auth token.",
-			})
+			problem.Write(w, problem.NewProblem(http.StatusUnauthorized, problem.Unauthorized, "Invalid auth token."))
 			return
 		}
 
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/contextkey"
+	"github.com/bungysheep/catalogue-api/pkg/commons/problem"
+)
+
+var correlationIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// CorrelationMiddleware - Correlation middleware, echoes correlation id of request or generates a new one
+func CorrelationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID := r.Header.Get(problem.CorrelationIDHeader)
+		if !correlationIDPattern.MatchString(correlationID) {
+			correlationID = newCorrelationID()
+		}
+
+		log.Printf("Applying correlation middleware, correlation id: %s.\n", correlationID)
+
+		w.Header().Set(problem.CorrelationIDHeader, correlationID)
+
+		ctx := context.WithValue(r.Context(), contextkey.CorrelationID, correlationID) //nolint
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// newCorrelationID - Returns random version 4 uuid
+func newCorrelationID() string {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		log.Printf("Failed generating correlation id, error: %v.\n", err)
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
@@ -12,7 +12,9 @@ func DefaultMiddleware(next http.Handler) http.Handler {
 
 		w.Header().Add("Content-Type", "application/json")
 		w.Header().Add("Access-Control-Allow-Origin", "*")
-		w.Header().Add("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Add("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Add("Access-Control-Allow-Headers", "Authorization, Content-Type, If-Match, X-Correlation-ID")
+		w.Header().Add("Access-Control-Expose-Headers", "ETag, X-Correlation-ID")
 
 		next.ServeHTTP(w, r)
 	})
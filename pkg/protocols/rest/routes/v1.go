@@ -2,8 +2,15 @@ package routes
 
 import (
 	authcontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/authcontroller"
+	backfillcontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/backfillcontroller"
 	cataloguecontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/cataloguecontroller"
+	categorycontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/categorycontroller"
+	exportcontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/exportcontroller"
+	importcontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/importcontroller"
+	pricelistcontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/pricelistcontroller"
 	productcontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/productcontroller"
+	revisioncontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/revisioncontroller"
+	uomcontrollerv1 "github.com/bungysheep/catalogue-api/pkg/controllers/v1/uomcontroller"
 	"github.com/bungysheep/catalogue-api/pkg/protocols/rest/middlewares"
 	"github.com/gorilla/mux"
 )
@@ -12,6 +19,7 @@ import (
 func APIV1RouteHandler() *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
 	router.Use(middlewares.DefaultMiddleware)
+	router.Use(middlewares.CorrelationMiddleware)
 
 	v1Router := router.PathPrefix("/v1").Subrouter()
 
@@ -27,18 +35,85 @@ func APIV1RouteHandler() *mux.Router {
 	clgRouter.Use(middlewares.AuthenticationMiddleware)
 	clgRouter.HandleFunc("/catalogues", catalogueController.GetAll).Methods("GET")
 	clgRouter.HandleFunc("/catalogues/{id}", catalogueController.GetByID).Methods("GET")
+	clgRouter.HandleFunc("/catalogues/{id}/schema", catalogueController.GetSchema).Methods("GET")
 	clgRouter.HandleFunc("/catalogues", catalogueController.Create).Methods("POST")
 	clgRouter.HandleFunc("/catalogues/{id}", catalogueController.Update).Methods("PUT")
+	clgRouter.HandleFunc("/catalogues/{id}", catalogueController.Patch).Methods("PATCH")
 	clgRouter.HandleFunc("/catalogues/{id}", catalogueController.Delete).Methods("DELETE")
+	clgRouter.HandleFunc("/catalogues/{id}/clone", catalogueController.Clone).Methods("POST")
+	clgRouter.HandleFunc("/catalogues/{id}/field_definitions/order", catalogueController.ReorderFieldDefinitions).Methods("PUT")
+
+	importController := importcontrollerv1.NewImportController()
+	clgRouter.HandleFunc("/catalogues/{id}/imports", importController.Create).Methods("POST")
+	clgRouter.HandleFunc("/catalogues/{id}/imports/{job_id}", importController.GetByID).Methods("GET")
+	clgRouter.HandleFunc("/catalogues/{id}/imports/{job_id}/errors", importController.GetErrors).Methods("GET")
+
+	backfillController := backfillcontrollerv1.NewBackfillController()
+	clgRouter.HandleFunc("/catalogues/{id}/field_definitions/{field_id}/backfill", backfillController.Create).Methods("POST")
+	clgRouter.HandleFunc("/catalogues/{id}/backfills", backfillController.GetByCatalogue).Methods("GET")
+	clgRouter.HandleFunc("/catalogues/{id}/backfills/{job_id}", backfillController.GetByID).Methods("GET")
+
+	exportController := exportcontrollerv1.NewExportController()
+	clgRouter.HandleFunc("/catalogues/{id}/export", exportController.Export).Methods("GET")
+
+	categoryController := categorycontrollerv1.NewCategoryController()
+	clgRouter.HandleFunc("/catalogues/{id}/categories", categoryController.GetByCatalogue).Methods("GET")
+	clgRouter.HandleFunc("/catalogues/{id}/categories", categoryController.Create).Methods("POST")
+	clgRouter.HandleFunc("/categories/{id}", categoryController.GetByID).Methods("GET")
+	clgRouter.HandleFunc("/categories/{id}/breadcrumb", categoryController.GetBreadcrumb).Methods("GET")
+	clgRouter.HandleFunc("/categories/{id}/products", categoryController.GetProducts).Methods("GET")
+	clgRouter.HandleFunc("/categories/{id}", categoryController.Update).Methods("PUT")
+	clgRouter.HandleFunc("/categories/{id}/move", categoryController.Move).Methods("POST")
+	clgRouter.HandleFunc("/categories/{id}", categoryController.Delete).Methods("DELETE")
+
+	revisionController := revisioncontrollerv1.NewRevisionController()
+	clgRouter.HandleFunc("/catalogues/{id}/publish", revisionController.Publish).Methods("POST")
+	clgRouter.HandleFunc("/catalogues/{id}/revisions", revisionController.GetByCatalogue).Methods("GET")
+	clgRouter.HandleFunc("/catalogues/{id}/revisions/{revision}", revisionController.GetByRevision).Methods("GET")
+	clgRouter.HandleFunc("/catalogues/{id}/revisions/{revision}/diff", revisionController.GetDiff).Methods("GET")
+
+	uomController := uomcontrollerv1.NewUomController()
+	uomRouter := v1Router.PathPrefix("").Subrouter()
+	uomRouter.Use(middlewares.AuthenticationMiddleware)
+	uomRouter.HandleFunc("/uoms", uomController.GetAll).Methods("GET")
+	uomRouter.HandleFunc("/uoms/{code}", uomController.GetByID).Methods("GET")
+	uomRouter.HandleFunc("/uoms", uomController.Create).Methods("POST")
+	uomRouter.HandleFunc("/uoms/{code}", uomController.Update).Methods("PUT")
+	uomRouter.HandleFunc("/uoms/{code}", uomController.Delete).Methods("DELETE")
+
+	priceListController := pricelistcontrollerv1.NewPriceListController()
+	priceRouter := v1Router.PathPrefix("").Subrouter()
+	priceRouter.Use(middlewares.AuthenticationMiddleware)
+	priceRouter.HandleFunc("/pricelists", priceListController.GetAll).Methods("GET")
+	priceRouter.HandleFunc("/pricelists/{code}", priceListController.GetByID).Methods("GET")
+	priceRouter.HandleFunc("/pricelists", priceListController.Create).Methods("POST")
+	priceRouter.HandleFunc("/pricelists/{code}", priceListController.Update).Methods("PUT")
+	priceRouter.HandleFunc("/pricelists/{code}", priceListController.Delete).Methods("DELETE")
+	priceRouter.HandleFunc("/pricelists/{code}/prices", priceListController.GetPrices).Methods("GET")
+	priceRouter.HandleFunc("/pricelists/{code}/products/{id}/prices", priceListController.SetProductPrices).Methods("PUT")
 
 	productController := productcontrollerv1.NewProductController()
 	prodRouter := v1Router.PathPrefix("").Subrouter()
 	prodRouter.Use(middlewares.AuthenticationMiddleware)
 	prodRouter.HandleFunc("/products/bycatalogue/{clg_code}", productController.GetByCatalogue).Methods("GET")
 	prodRouter.HandleFunc("/products/{id}", productController.GetByID).Methods("GET")
+	prodRouter.HandleFunc("/products/{id}/convert", productController.Convert).Methods("GET")
+	prodRouter.HandleFunc("/products/{id}/price", productController.GetPrice).Methods("GET")
+	prodRouter.HandleFunc("/products/{id}/variants", productController.GenerateVariants).Methods("POST")
+	prodRouter.HandleFunc("/products/{id}/relations", productController.GetRelations).Methods("GET")
+	prodRouter.HandleFunc("/products/{id}/relations", productController.SetRelations).Methods("PUT")
+	prodRouter.HandleFunc("/products/{id}/copy", productController.Copy).Methods("POST")
+	prodRouter.HandleFunc("/products/{id}/move", productController.Move).Methods("POST")
 	prodRouter.HandleFunc("/products", productController.Create).Methods("POST")
 	prodRouter.HandleFunc("/products/{id}", productController.Update).Methods("PUT")
+	prodRouter.HandleFunc("/products/{id}", productController.Patch).Methods("PATCH")
 	prodRouter.HandleFunc("/products/{id}", productController.Delete).Methods("DELETE")
+	prodRouter.HandleFunc("/products/{id}/changes", productController.GetChanges).Methods("GET")
+	prodRouter.HandleFunc("/products/{id}/changes/{change_id}/approve", productController.ApproveChange).Methods("POST")
+	prodRouter.HandleFunc("/products/{id}/changes/{change_id}/reject", productController.RejectChange).Methods("POST")
+	prodRouter.HandleFunc("/catalogues/{id}/changes", productController.GetChangesByCatalogue).Methods("GET")
+	prodRouter.HandleFunc("/catalogues/{clg_code}/products/{code}", productController.GetByCode).Methods("GET")
+	prodRouter.HandleFunc("/catalogues/{clg_code}/products/{code}", productController.Upsert).Methods("PUT")
 
 	return router
 }
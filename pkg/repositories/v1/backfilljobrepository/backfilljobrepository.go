@@ -0,0 +1,177 @@
+package backfilljobrepository
+
+import (
+	"context"
+	"fmt"
+
+	backfilljobmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/backfilljob"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IBackfillJobRepository type
+type IBackfillJobRepository interface {
+	GetByID(context.Context, int64) (*backfilljobmodel.BackfillJob, error)
+	ForEachByCatalogue(context.Context, string, func(*backfilljobmodel.BackfillJob) error) error
+	Create(context.Context, *backfilljobmodel.BackfillJob) (int64, error)
+	Update(context.Context, *backfilljobmodel.BackfillJob) (int64, error)
+}
+
+type backfillJobRepository struct {
+}
+
+// NewBackfillJobRepository - Create backfill job repository
+func NewBackfillJobRepository() IBackfillJobRepository {
+	return &backfillJobRepository{}
+}
+
+func (jobRepo *backfillJobRepository) GetByID(ctx context.Context, id int64) (*backfilljobmodel.BackfillJob, error) {
+	result := backfilljobmodel.NewBackfillJob()
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT id, clg_code, field_id, status, total_rows, processed_rows, updated_rows, message, created_by, created_at, modified_at
+		FROM backfill_jobs
+		WHERE id=$1`)
+	if err != nil {
+		return nil, fmt.Errorf("Failed preparing read backfill job, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading backfill job, error: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("Failed retrieve backfill job record, error: %w", err)
+		}
+		return nil, nil
+	}
+
+	if err := rows.Scan(
+		&result.ID,
+		&result.CatalogueCode,
+		&result.FieldID,
+		&result.Status,
+		&result.TotalRows,
+		&result.ProcessedRows,
+		&result.UpdatedRows,
+		&result.Message,
+		&result.CreatedBy,
+		&result.CreatedAt,
+		&result.ModifiedAt); err != nil {
+		return nil, fmt.Errorf("Failed retrieve backfill job record value, error: %w", err)
+	}
+
+	return result, nil
+}
+
+func (jobRepo *backfillJobRepository) ForEachByCatalogue(ctx context.Context, clgCode string, fn func(*backfilljobmodel.BackfillJob) error) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT id, clg_code, field_id, status, total_rows, processed_rows, updated_rows, message, created_by, created_at, modified_at
+		FROM backfill_jobs
+		WHERE clg_code=$1
+		ORDER BY id ASC`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing read backfill job, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, clgCode)
+	if err != nil {
+		return fmt.Errorf("Failed reading backfill job, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("Failed retrieve backfill job record, error: %w", err)
+			}
+			break
+		}
+
+		job := backfilljobmodel.NewBackfillJob()
+		if err := rows.Scan(
+			&job.ID,
+			&job.CatalogueCode,
+			&job.FieldID,
+			&job.Status,
+			&job.TotalRows,
+			&job.ProcessedRows,
+			&job.UpdatedRows,
+			&job.Message,
+			&job.CreatedBy,
+			&job.CreatedAt,
+			&job.ModifiedAt); err != nil {
+			return fmt.Errorf("Failed retrieve backfill job record value, error: %w", err)
+		}
+
+		if err := fn(job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (jobRepo *backfillJobRepository) Create(ctx context.Context, data *backfilljobmodel.BackfillJob) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO backfill_jobs
+			(clg_code, field_id, status, total_rows, processed_rows, updated_rows, message, created_by, created_at, modified_at)
+		VALUES ($1, $2, $3, 0, 0, 0, '', $4, $5, $6) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert backfill job, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetFieldID(), data.GetStatus(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedAt()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting backfill job, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+func (jobRepo *backfillJobRepository) Update(ctx context.Context, data *backfilljobmodel.BackfillJob) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`UPDATE backfill_jobs SET status=$1, total_rows=$2, processed_rows=$3, updated_rows=$4, message=$5, modified_at=$6
+		WHERE id=$7`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing update backfill job, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetStatus(), data.GetTotalRows(), data.GetProcessedRows(), data.GetUpdatedRows(), data.GetMessage(), data.GetModifiedAt(), data.GetID())
+	if err != nil {
+		return 0, fmt.Errorf("Failed updating backfill job, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -6,16 +6,21 @@ import (
 
 	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
 	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/categoryrepository"
 	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfielddefinitionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfieldgrouprepository"
+	"github.com/lib/pq"
 )
 
 // ICatalogueRepository type
 type ICatalogueRepository interface {
 	GetByID(context.Context, string) (*cataloguemodel.Catalogue, error)
 	GetAll(context.Context) ([]*cataloguemodel.Catalogue, error)
+	ForEachAll(context.Context, func(*cataloguemodel.Catalogue) error) error
 	Create(context.Context, *cataloguemodel.Catalogue) (int64, error)
 	Update(context.Context, *cataloguemodel.Catalogue) (int64, error)
-	Delete(context.Context, string) (int64, error)
+	Delete(context.Context, string, int64) (int64, error)
+	Lock(context.Context, string) error
 }
 
 type catalogueRepository struct {
@@ -29,30 +34,30 @@ func NewCatalogueRepository() ICatalogueRepository {
 func (clgRepo *catalogueRepository) GetByID(ctx context.Context, code string) (*cataloguemodel.Catalogue, error) {
 	result := cataloguemodel.NewCatalogue()
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`SELECT code, descr, details, status, created_by, created_at, modified_by, modified_at, vers
+		`SELECT code, descr, details, status, approval_required, reviewers, created_by, created_at, modified_by, modified_at, vers
 		FROM catalogues 
 		WHERE code=$1`)
 	if err != nil {
-		return nil, fmt.Errorf("Failed preparing read catalogue, error: %v", err)
+		return nil, fmt.Errorf("Failed preparing read catalogue, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, code)
 	if err != nil {
-		return nil, fmt.Errorf("Failed reading catalogue, error: %v", err)
+		return nil, fmt.Errorf("Failed reading catalogue, error: %w", err)
 	}
 	defer rows.Close()
 
 	if !rows.Next() {
 		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("Failed retrieve catalogue record, error: %v", err)
+			return nil, fmt.Errorf("Failed retrieve catalogue record, error: %w", err)
 		}
 		return nil, nil
 	}
@@ -62,14 +67,18 @@ func (clgRepo *catalogueRepository) GetByID(ctx context.Context, code string) (*
 		&result.Description,
 		&result.Details,
 		&result.Status,
+		&result.ApprovalRequired,
+		pq.Array(&result.Reviewers),
 		&result.CreatedBy,
 		&result.CreatedAt,
 		&result.ModifiedBy,
 		&result.ModifiedAt,
 		&result.Vers); err != nil {
-		return nil, fmt.Errorf("Failed retrieve catalogue record value, error: %v", err)
+		return nil, fmt.Errorf("Failed retrieve catalogue record value, error: %w", err)
 	}
 
+	rows.Close()
+
 	fieldDefRepo := customfielddefinitionrepository.NewCustomFieldDefinitionRepository()
 	fieldDefs, err := fieldDefRepo.GetByCatalogue(ctx, code)
 	if err != nil {
@@ -78,36 +87,61 @@ func (clgRepo *catalogueRepository) GetByID(ctx context.Context, code string) (*
 
 	result.CustomFieldDefinitions = fieldDefs
 
+	grpRepo := customfieldgrouprepository.NewCustomFieldGroupRepository()
+	groups, err := grpRepo.GetByCatalogue(ctx, code)
+	if err != nil {
+		return result, err
+	}
+
+	result.FieldGroups = groups
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	categories, err := catRepo.GetByCatalogue(ctx, code)
+	if err != nil {
+		return result, err
+	}
+
+	result.Categories = categories
+
 	return result, nil
 }
 
 func (clgRepo *catalogueRepository) GetAll(ctx context.Context) ([]*cataloguemodel.Catalogue, error) {
 	result := make([]*cataloguemodel.Catalogue, 0)
 
-	conn, err := database.DbConnection.Conn(ctx)
+	err := clgRepo.ForEachAll(ctx, func(catalogue *cataloguemodel.Catalogue) error {
+		result = append(result, catalogue)
+		return nil
+	})
+
+	return result, err
+}
+
+func (clgRepo *catalogueRepository) ForEachAll(ctx context.Context, fn func(*cataloguemodel.Catalogue) error) error {
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return result, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`SELECT code, descr, details, status, created_by, created_at, modified_by, modified_at, vers
+		`SELECT code, descr, details, status, approval_required, reviewers, created_by, created_at, modified_by, modified_at, vers
 		FROM catalogues`)
 	if err != nil {
-		return result, fmt.Errorf("Failed preparing read catalogue, error: %v", err)
+		return fmt.Errorf("Failed preparing read catalogue, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
-		return result, fmt.Errorf("Failed reading catalogue, error: %v", err)
+		return fmt.Errorf("Failed reading catalogue, error: %w", err)
 	}
 	defer rows.Close()
 
 	for {
 		if !rows.Next() {
 			if err := rows.Err(); err != nil {
-				return result, fmt.Errorf("Failed retrieve catalogue record, error: %v", err)
+				return fmt.Errorf("Failed retrieve catalogue record, error: %w", err)
 			}
 			break
 		}
@@ -118,86 +152,117 @@ func (clgRepo *catalogueRepository) GetAll(ctx context.Context) ([]*cataloguemod
 			&catalogue.Description,
 			&catalogue.Details,
 			&catalogue.Status,
+			&catalogue.ApprovalRequired,
+			pq.Array(&catalogue.Reviewers),
 			&catalogue.CreatedBy,
 			&catalogue.CreatedAt,
 			&catalogue.ModifiedBy,
 			&catalogue.ModifiedAt,
 			&catalogue.Vers); err != nil {
-			return result, fmt.Errorf("Failed retrieve catalogue record value, error: %v", err)
+			return fmt.Errorf("Failed retrieve catalogue record value, error: %w", err)
 		}
 
-		result = append(result, catalogue)
+		if err := fn(catalogue); err != nil {
+			return err
+		}
 	}
 
-	return result, nil
+	return nil
 }
 
 func (clgRepo *catalogueRepository) Create(ctx context.Context, data *cataloguemodel.Catalogue) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
 		`INSERT INTO catalogues 
-			(code, descr, details, status, created_by, created_at, modified_by, modified_at, vers) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1)`)
+			(code, descr, details, status, approval_required, reviewers, created_by, created_at, modified_by, modified_at, vers) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 1)`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing insert catalogue, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing insert catalogue, error: %w", err)
 	}
 	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetDescription(), data.GetDetails(), data.GetStatus(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt())
+	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetDescription(), data.GetDetails(), data.GetStatus(), data.GetApprovalRequired(), pq.Array(data.GetAllReviewers()), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt())
 	if err != nil {
-		return 0, fmt.Errorf("Failed inserting catalogue, error: %v", err)
+		return 0, fmt.Errorf("Failed inserting catalogue, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
 func (clgRepo *catalogueRepository) Update(ctx context.Context, data *cataloguemodel.Catalogue) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`UPDATE catalogues SET descr=$1, details=$2, status=$3, modified_by=$4, modified_at=$5, vers=vers+1 
-		WHERE code=$6`)
+		`UPDATE catalogues SET descr=$1, details=$2, status=$3, approval_required=$4, reviewers=$5, modified_by=$6, modified_at=$7, vers=vers+1 
+		WHERE code=$8 AND vers=$9`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing update catalogue, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing update catalogue, error: %w", err)
 	}
 	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, data.GetDescription(), data.GetDetails(), data.GetStatus(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetCode())
+	result, err := stmt.ExecContext(ctx, data.GetDescription(), data.GetDetails(), data.GetStatus(), data.GetApprovalRequired(), pq.Array(data.GetAllReviewers()), data.GetModifiedBy(), data.GetModifiedAt(), data.GetCode(), data.GetVers())
 	if err != nil {
-		return 0, fmt.Errorf("Failed updating catalogue, error: %v", err)
+		return 0, fmt.Errorf("Failed updating catalogue, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
-func (clgRepo *catalogueRepository) Delete(ctx context.Context, code string) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+// Delete - Deletes catalogue of version vers, of any version when vers is 0
+func (clgRepo *catalogueRepository) Delete(ctx context.Context, code string, vers int64) (int64, error) {
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
 		`DELETE FROM catalogues 
-		WHERE code=$1`)
+		WHERE code=$1 AND (vers=$2 OR $2=0)`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing delete catalogue, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing delete catalogue, error: %w", err)
 	}
 	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, code)
+	result, err := stmt.ExecContext(ctx, code, vers)
 	if err != nil {
-		return 0, fmt.Errorf("Failed deleting catalogue, error: %v", err)
+		return 0, fmt.Errorf("Failed deleting catalogue, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
+
+// Lock - Locks catalogue row until the transaction of context ends, writers of catalogue taking the lock are serialised
+func (clgRepo *catalogueRepository) Lock(ctx context.Context, code string) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT code
+		FROM catalogues
+		WHERE code=$1
+		FOR UPDATE`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing lock catalogue, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, code)
+	if err != nil {
+		return fmt.Errorf("Failed locking catalogue, error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package categoryrepository
+
+import (
+	"context"
+	"fmt"
+
+	categorymodel "github.com/bungysheep/catalogue-api/pkg/models/v1/category"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// ICategoryRepository type
+type ICategoryRepository interface {
+	GetByID(context.Context, int64) (*categorymodel.Category, error)
+	GetByCode(context.Context, string, string) (*categorymodel.Category, error)
+	GetByCatalogue(context.Context, string) ([]*categorymodel.Category, error)
+	GetAncestors(context.Context, *categorymodel.Category) ([]*categorymodel.Category, error)
+	CountByParent(context.Context, int64) (int64, error)
+	Create(context.Context, *categorymodel.Category) (int64, error)
+	UpdatePath(context.Context, int64, string) (int64, error)
+	Update(context.Context, *categorymodel.Category) (int64, error)
+	Move(context.Context, *categorymodel.Category, string) (int64, error)
+	Delete(context.Context, int64) (int64, error)
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type categoryRepository struct {
+}
+
+// NewCategoryRepository - Create category repository
+func NewCategoryRepository() ICategoryRepository {
+	return &categoryRepository{}
+}
+
+func (catRepo *categoryRepository) GetByID(ctx context.Context, id int64) (*categorymodel.Category, error) {
+	result, err := catRepo.read(ctx,
+		`SELECT id, clg_code, parent_id, code, descr, path, created_by, created_at, modified_by, modified_at, vers
+		FROM categories
+		WHERE id=$1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result[0], nil
+}
+
+func (catRepo *categoryRepository) GetByCode(ctx context.Context, clgCode string, code string) (*categorymodel.Category, error) {
+	result, err := catRepo.read(ctx,
+		`SELECT id, clg_code, parent_id, code, descr, path, created_by, created_at, modified_by, modified_at, vers
+		FROM categories
+		WHERE clg_code=$1 AND code=$2`, clgCode, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result[0], nil
+}
+
+// GetByCatalogue - Returns categories of catalogue ordered by path, each parent precedes its children
+func (catRepo *categoryRepository) GetByCatalogue(ctx context.Context, clgCode string) ([]*categorymodel.Category, error) {
+	return catRepo.read(ctx,
+		`SELECT id, clg_code, parent_id, code, descr, path, created_by, created_at, modified_by, modified_at, vers
+		FROM categories
+		WHERE clg_code=$1
+		ORDER BY path ASC`, clgCode)
+}
+
+// GetAncestors - Returns categories from the root down to category itself
+func (catRepo *categoryRepository) GetAncestors(ctx context.Context, data *categorymodel.Category) ([]*categorymodel.Category, error) {
+	return catRepo.read(ctx,
+		`SELECT id, clg_code, parent_id, code, descr, path, created_by, created_at, modified_by, modified_at, vers
+		FROM categories
+		WHERE clg_code=$1 AND $2 LIKE path || '%'
+		ORDER BY LENGTH(path) ASC`, data.GetCatalogueCode(), data.GetPath())
+}
+
+func (catRepo *categoryRepository) read(ctx context.Context, query string, args ...interface{}) ([]*categorymodel.Category, error) {
+	result := make([]*categorymodel.Category, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read category, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading category, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve category record, error: %w", err)
+			}
+			break
+		}
+
+		cat := categorymodel.NewCategory()
+		if err := rows.Scan(
+			&cat.ID,
+			&cat.CatalogueCode,
+			&cat.ParentID,
+			&cat.Code,
+			&cat.Description,
+			&cat.Path,
+			&cat.CreatedBy,
+			&cat.CreatedAt,
+			&cat.ModifiedBy,
+			&cat.ModifiedAt,
+			&cat.Vers); err != nil {
+			return result, fmt.Errorf("Failed retrieve category record value, error: %w", err)
+		}
+
+		result = append(result, cat)
+	}
+
+	return result, nil
+}
+
+func (catRepo *categoryRepository) CountByParent(ctx context.Context, parentID int64) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT COUNT(*)
+		FROM categories
+		WHERE parent_id=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing read category, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, parentID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading category, error: %w", err)
+	}
+
+	return count, nil
+}
+
+// Create - Inserts category, its path is only known once the id is, see UpdatePath
+func (catRepo *categoryRepository) Create(ctx context.Context, data *categorymodel.Category) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO categories
+			(clg_code, parent_id, code, descr, path, created_by, created_at, modified_by, modified_at, vers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 1) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert category, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetParentID(), data.GetCode(), data.GetDescription(), data.GetPath(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting category, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+func (catRepo *categoryRepository) UpdatePath(ctx context.Context, id int64, path string) (int64, error) {
+	return catRepo.exec(ctx,
+		`UPDATE categories SET path=$1
+		WHERE id=$2`, path, id)
+}
+
+func (catRepo *categoryRepository) Update(ctx context.Context, data *categorymodel.Category) (int64, error) {
+	return catRepo.exec(ctx,
+		`UPDATE categories SET code=$1, descr=$2, modified_by=$3, modified_at=$4, vers=vers+1
+		WHERE id=$5 AND vers=$6`, data.GetCode(), data.GetDescription(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetID(), data.GetVers())
+}
+
+// Move - Re-parents category and rewrites paths of its descendants from old path in one transaction
+func (catRepo *categoryRepository) Move(ctx context.Context, data *categorymodel.Category, oldPath string) (int64, error) {
+	var nbrRows int64
+	err := database.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		nbrRows, err = catRepo.exec(ctx,
+			`UPDATE categories SET parent_id=$1, path=$2, modified_by=$3, modified_at=$4, vers=vers+1
+			WHERE id=$5 AND vers=$6`, data.GetParentID(), data.GetPath(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetID(), data.GetVers())
+		if err != nil || nbrRows == 0 {
+			return err
+		}
+
+		_, err = catRepo.exec(ctx,
+			`UPDATE categories SET path=$1 || SUBSTR(path, LENGTH($2) + 1)
+			WHERE clg_code=$3 AND path LIKE $2 || '%' AND id<>$4`, data.GetPath(), oldPath, data.GetCatalogueCode(), data.GetID())
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return nbrRows, nil
+}
+
+func (catRepo *categoryRepository) Delete(ctx context.Context, id int64) (int64, error) {
+	return catRepo.exec(ctx,
+		`DELETE FROM categories
+		WHERE id=$1`, id)
+}
+
+func (catRepo *categoryRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	_, err := catRepo.exec(ctx,
+		`DELETE FROM categories
+		WHERE clg_code=$1`, clgCode)
+
+	return err
+}
+
+func (catRepo *categoryRepository) exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing write category, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Failed writing category, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
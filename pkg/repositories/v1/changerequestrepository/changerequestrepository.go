@@ -0,0 +1,210 @@
+package changerequestrepository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/reviewstatus"
+	changerequestmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/changerequest"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IChangeRequestRepository type
+type IChangeRequestRepository interface {
+	GetByID(context.Context, int64) (*changerequestmodel.ChangeRequest, error)
+	GetPendingByProduct(context.Context, int64) (*changerequestmodel.ChangeRequest, error)
+	ForEachByProduct(context.Context, int64, func(*changerequestmodel.ChangeRequest) error) error
+	ForEachByCatalogue(context.Context, string, string, func(*changerequestmodel.ChangeRequest) error) error
+	Create(context.Context, *changerequestmodel.ChangeRequest) (int64, error)
+	Review(context.Context, *changerequestmodel.ChangeRequest) (int64, error)
+	DeleteByProduct(context.Context, int64) error
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type changeRequestRepository struct {
+}
+
+// NewChangeRequestRepository - Create change request repository
+func NewChangeRequestRepository() IChangeRequestRepository {
+	return &changeRequestRepository{}
+}
+
+func (changeRepo *changeRequestRepository) GetByID(ctx context.Context, id int64) (*changerequestmodel.ChangeRequest, error) {
+	var result *changerequestmodel.ChangeRequest
+	err := changeRepo.forEach(ctx, func(change *changerequestmodel.ChangeRequest) error {
+		result = change
+		return nil
+	}, `SELECT id, prod_id, clg_code, base_vers, status, product, submitted_by, submitted_at, reviewed_by, reviewed_at, comment
+		FROM product_change_requests
+		WHERE id=$1`, id)
+
+	return result, err
+}
+
+// GetPendingByProduct - Returns change request of product awaiting a reviewer, nil when there is none
+func (changeRepo *changeRequestRepository) GetPendingByProduct(ctx context.Context, prodID int64) (*changerequestmodel.ChangeRequest, error) {
+	var result *changerequestmodel.ChangeRequest
+	err := changeRepo.forEach(ctx, func(change *changerequestmodel.ChangeRequest) error {
+		result = change
+		return nil
+	}, `SELECT id, prod_id, clg_code, base_vers, status, product, submitted_by, submitted_at, reviewed_by, reviewed_at, comment
+		FROM product_change_requests
+		WHERE prod_id=$1 AND status=$2`, prodID, reviewstatus.Pending.String())
+
+	return result, err
+}
+
+// ForEachByProduct - Iterates change requests of product from the latest one, which is its review history
+func (changeRepo *changeRequestRepository) ForEachByProduct(ctx context.Context, prodID int64, fn func(*changerequestmodel.ChangeRequest) error) error {
+	return changeRepo.forEach(ctx, fn,
+		`SELECT id, prod_id, clg_code, base_vers, status, product, submitted_by, submitted_at, reviewed_by, reviewed_at, comment
+		FROM product_change_requests
+		WHERE prod_id=$1
+		ORDER BY id DESC`, prodID)
+}
+
+// ForEachByCatalogue - Iterates change requests of catalogue in status from the oldest one, empty status for all of them
+func (changeRepo *changeRequestRepository) ForEachByCatalogue(ctx context.Context, clgCode string, status string, fn func(*changerequestmodel.ChangeRequest) error) error {
+	return changeRepo.forEach(ctx, fn,
+		`SELECT id, prod_id, clg_code, base_vers, status, product, submitted_by, submitted_at, reviewed_by, reviewed_at, comment
+		FROM product_change_requests
+		WHERE clg_code=$1 AND ($2='' OR status=$2)
+		ORDER BY id ASC`, clgCode, status)
+}
+
+func (changeRepo *changeRequestRepository) forEach(ctx context.Context, fn func(*changerequestmodel.ChangeRequest) error, query string, args ...interface{}) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("Failed preparing read product change request, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("Failed reading product change request, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("Failed retrieve product change request record, error: %w", err)
+			}
+			break
+		}
+
+		var product []byte
+		change := changerequestmodel.NewChangeRequest()
+		if err := rows.Scan(
+			&change.ID,
+			&change.ProdID,
+			&change.CatalogueCode,
+			&change.BaseVers,
+			&change.Status,
+			&product,
+			&change.SubmittedBy,
+			&change.SubmittedAt,
+			&change.ReviewedBy,
+			&change.ReviewedAt,
+			&change.Comment); err != nil {
+			return fmt.Errorf("Failed retrieve product change request record value, error: %w", err)
+		}
+
+		if err := json.Unmarshal(product, &change.Product); err != nil {
+			return fmt.Errorf("Failed decoding product change request, error: %w", err)
+		}
+
+		if err := fn(change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (changeRepo *changeRequestRepository) Create(ctx context.Context, data *changerequestmodel.ChangeRequest) (int64, error) {
+	product, err := json.Marshal(data.GetProduct())
+	if err != nil {
+		return 0, fmt.Errorf("Failed encoding product change request, error: %w", err)
+	}
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO product_change_requests
+			(prod_id, clg_code, base_vers, status, product, submitted_by, submitted_at, reviewed_by, reviewed_at, comment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, '', NULL, '') RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert product change request, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetProdID(), data.GetCatalogueCode(), data.GetBaseVers(), data.GetStatus(), product, data.GetSubmittedBy(), data.GetSubmittedAt()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting product change request, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+// Review - Records outcome of review, only a pending change request can be reviewed
+func (changeRepo *changeRequestRepository) Review(ctx context.Context, data *changerequestmodel.ChangeRequest) (int64, error) {
+	reviewedAt := time.Now()
+	if data.GetReviewedAt() != nil {
+		reviewedAt = *data.GetReviewedAt()
+	}
+
+	return changeRepo.exec(ctx,
+		`UPDATE product_change_requests SET status=$1, reviewed_by=$2, reviewed_at=$3, comment=$4
+		WHERE id=$5 AND status=$6`, data.GetStatus(), data.GetReviewedBy(), reviewedAt, data.GetComment(), data.GetID(), reviewstatus.Pending.String())
+}
+
+func (changeRepo *changeRequestRepository) DeleteByProduct(ctx context.Context, prodID int64) error {
+	_, err := changeRepo.exec(ctx,
+		`DELETE FROM product_change_requests
+		WHERE prod_id=$1`, prodID)
+
+	return err
+}
+
+func (changeRepo *changeRequestRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	_, err := changeRepo.exec(ctx,
+		`DELETE FROM product_change_requests
+		WHERE clg_code=$1`, clgCode)
+
+	return err
+}
+
+func (changeRepo *changeRequestRepository) exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing write product change request, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Failed writing product change request, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -6,6 +6,7 @@ import (
 
 	customfielddefinitionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfielddefinition"
 	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfieldoptionrepository"
 )
 
 // ICustomFieldDefinitionRepository type
@@ -14,6 +15,7 @@ type ICustomFieldDefinitionRepository interface {
 	GetByCatalogue(context.Context, string) ([]*customfielddefinitionmodel.CustomFieldDefinition, error)
 	Create(context.Context, *customfielddefinitionmodel.CustomFieldDefinition) (int64, error)
 	Update(context.Context, *customfielddefinitionmodel.CustomFieldDefinition) (int64, error)
+	UpdateSortOrder(context.Context, int64, int) (int64, error)
 	Delete(context.Context, int64) (int64, error)
 	DeleteByCatalogue(context.Context, string) error
 }
@@ -29,30 +31,30 @@ func NewCustomFieldDefinitionRepository() ICustomFieldDefinitionRepository {
 func (fieldDefRepo *customFieldDefinitionRepository) GetByID(ctx context.Context, id int64) (*customfielddefinitionmodel.CustomFieldDefinition, error) {
 	result := customfielddefinitionmodel.NewCustomFieldDefinition()
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`SELECT id, clg_code, caption, type, mandatory, created_by, created_at, modified_by, modified_at, vers
+		`SELECT id, clg_code, caption, type, mandatory, precision, min_length, max_length, pattern, min_value, max_value, step_value, min_date, max_date, is_unique, default_value, group_code, sort_order, help_text, placeholder, unit_label, hidden, read_only, variant_axis, category_id, created_by, created_at, modified_by, modified_at, vers
 		FROM custom_field_definitions 
 		WHERE id=$1`)
 	if err != nil {
-		return nil, fmt.Errorf("Failed preparing read custom field definition, error: %v", err)
+		return nil, fmt.Errorf("Failed preparing read custom field definition, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("Failed reading custom field definition, error: %v", err)
+		return nil, fmt.Errorf("Failed reading custom field definition, error: %w", err)
 	}
 	defer rows.Close()
 
 	if !rows.Next() {
 		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("Failed retrieve custom field definition record, error: %v", err)
+			return nil, fmt.Errorf("Failed retrieve custom field definition record, error: %w", err)
 		}
 		return nil, nil
 	}
@@ -63,45 +65,74 @@ func (fieldDefRepo *customFieldDefinitionRepository) GetByID(ctx context.Context
 		&result.Caption,
 		&result.Type,
 		&result.Mandatory,
+		&result.Precision,
+		&result.MinLength,
+		&result.MaxLength,
+		&result.Pattern,
+		&result.MinValue,
+		&result.MaxValue,
+		&result.Step,
+		&result.MinDate,
+		&result.MaxDate,
+		&result.Unique,
+		&result.DefaultValue,
+		&result.GroupCode,
+		&result.SortOrder,
+		&result.HelpText,
+		&result.Placeholder,
+		&result.UnitLabel,
+		&result.Hidden,
+		&result.ReadOnly,
+		&result.VariantAxis,
+		&result.CategoryID,
 		&result.CreatedBy,
 		&result.CreatedAt,
 		&result.ModifiedBy,
 		&result.ModifiedAt,
 		&result.Vers); err != nil {
-		return nil, fmt.Errorf("Failed retrieve custom field definition record value, error: %v", err)
+		return nil, fmt.Errorf("Failed retrieve custom field definition record value, error: %w", err)
 	}
 
+	optRepo := customfieldoptionrepository.NewCustomFieldOptionRepository()
+	options, err := optRepo.GetByField(ctx, result.GetID())
+	if err != nil {
+		return result, err
+	}
+
+	result.Options = options
+
 	return result, nil
 }
 
 func (fieldDefRepo *customFieldDefinitionRepository) GetByCatalogue(ctx context.Context, clgCode string) ([]*customfielddefinitionmodel.CustomFieldDefinition, error) {
 	result := make([]*customfielddefinitionmodel.CustomFieldDefinition, 0)
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return result, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`SELECT id, clg_code, caption, type, mandatory, created_by, created_at, modified_by, modified_at, vers
+		`SELECT id, clg_code, caption, type, mandatory, precision, min_length, max_length, pattern, min_value, max_value, step_value, min_date, max_date, is_unique, default_value, group_code, sort_order, help_text, placeholder, unit_label, hidden, read_only, variant_axis, category_id, created_by, created_at, modified_by, modified_at, vers
 		FROM custom_field_definitions
-		WHERE clg_code=$1`)
+		WHERE clg_code=$1
+		ORDER BY sort_order, id ASC`)
 	if err != nil {
-		return result, fmt.Errorf("Failed preparing read custom field definition, error: %v", err)
+		return result, fmt.Errorf("Failed preparing read custom field definition, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, clgCode)
 	if err != nil {
-		return result, fmt.Errorf("Failed reading custom field definition, error: %v", err)
+		return result, fmt.Errorf("Failed reading custom field definition, error: %w", err)
 	}
 	defer rows.Close()
 
 	for {
 		if !rows.Next() {
 			if err := rows.Err(); err != nil {
-				return result, fmt.Errorf("Failed retrieve custom field definition record, error: %v", err)
+				return result, fmt.Errorf("Failed retrieve custom field definition record, error: %w", err)
 			}
 			break
 		}
@@ -113,72 +144,127 @@ func (fieldDefRepo *customFieldDefinitionRepository) GetByCatalogue(ctx context.
 			&fieldDef.Caption,
 			&fieldDef.Type,
 			&fieldDef.Mandatory,
+			&fieldDef.Precision,
+			&fieldDef.MinLength,
+			&fieldDef.MaxLength,
+			&fieldDef.Pattern,
+			&fieldDef.MinValue,
+			&fieldDef.MaxValue,
+			&fieldDef.Step,
+			&fieldDef.MinDate,
+			&fieldDef.MaxDate,
+			&fieldDef.Unique,
+			&fieldDef.DefaultValue,
+			&fieldDef.GroupCode,
+			&fieldDef.SortOrder,
+			&fieldDef.HelpText,
+			&fieldDef.Placeholder,
+			&fieldDef.UnitLabel,
+			&fieldDef.Hidden,
+			&fieldDef.ReadOnly,
+			&fieldDef.VariantAxis,
+			&fieldDef.CategoryID,
 			&fieldDef.CreatedBy,
 			&fieldDef.CreatedAt,
 			&fieldDef.ModifiedBy,
 			&fieldDef.ModifiedAt,
 			&fieldDef.Vers); err != nil {
-			return result, fmt.Errorf("Failed retrieve custom field definition record value, error: %v", err)
+			return result, fmt.Errorf("Failed retrieve custom field definition record value, error: %w", err)
 		}
 
 		result = append(result, fieldDef)
 	}
 
+	optRepo := customfieldoptionrepository.NewCustomFieldOptionRepository()
+	for _, fieldDef := range result {
+		options, err := optRepo.GetByField(ctx, fieldDef.GetID())
+		if err != nil {
+			return result, err
+		}
+
+		fieldDef.Options = options
+	}
+
 	return result, nil
 }
 
 func (fieldDefRepo *customFieldDefinitionRepository) Create(ctx context.Context, data *customfielddefinitionmodel.CustomFieldDefinition) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
 		`INSERT INTO custom_field_definitions 
-			(clg_code, caption, type, mandatory, created_by, created_at, modified_by, modified_at, vers) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1) RETURNING id`)
+			(clg_code, caption, type, mandatory, precision, min_length, max_length, pattern, min_value, max_value, step_value, min_date, max_date, is_unique, default_value, group_code, sort_order, help_text, placeholder, unit_label, hidden, read_only, variant_axis, category_id, created_by, created_at, modified_by, modified_at, vers) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, 1) RETURNING id`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing insert custom field definition, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing insert custom field definition, error: %w", err)
 	}
 	defer stmt.Close()
 
 	var lastInsertID int64
-	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetCaption(), data.GetType(), data.GetMandatory(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt()).Scan(&lastInsertID)
+	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetCaption(), data.GetType(), data.GetMandatory(), data.GetPrecision(), data.GetMinLength(), data.GetMaxLength(), data.GetPattern(), data.GetMinValue(), data.GetMaxValue(), data.GetStep(), data.GetMinDate(), data.GetMaxDate(), data.GetUnique(), data.GetDefaultValue(), data.GetGroupCode(), data.GetSortOrder(), data.GetHelpText(), data.GetPlaceholder(), data.GetUnitLabel(), data.GetHidden(), data.GetReadOnly(), data.GetVariantAxis(), data.GetCategoryID(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt()).Scan(&lastInsertID)
 	if err != nil {
-		return 0, fmt.Errorf("Failed inserting custom field definition, error: %v", err)
+		return 0, fmt.Errorf("Failed inserting custom field definition, error: %w", err)
 	}
 
 	return lastInsertID, nil
 }
 
 func (fieldDefRepo *customFieldDefinitionRepository) Update(ctx context.Context, data *customfielddefinitionmodel.CustomFieldDefinition) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`UPDATE custom_field_definitions SET caption=$1, type=$2, mandatory=$3, precision=$4, min_length=$5, max_length=$6, pattern=$7, 
+			min_value=$8, max_value=$9, step_value=$10, min_date=$11, max_date=$12, is_unique=$13, default_value=$14, 
+			group_code=$15, sort_order=$16, help_text=$17, placeholder=$18, unit_label=$19, hidden=$20, read_only=$21, variant_axis=$22, category_id=$23, modified_by=$24, modified_at=$25, vers=vers+1 
+		WHERE id=$26`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing update custom field definition, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetCaption(), data.GetType(), data.GetMandatory(), data.GetPrecision(), data.GetMinLength(), data.GetMaxLength(), data.GetPattern(), data.GetMinValue(), data.GetMaxValue(), data.GetStep(), data.GetMinDate(), data.GetMaxDate(), data.GetUnique(), data.GetDefaultValue(), data.GetGroupCode(), data.GetSortOrder(), data.GetHelpText(), data.GetPlaceholder(), data.GetUnitLabel(), data.GetHidden(), data.GetReadOnly(), data.GetVariantAxis(), data.GetCategoryID(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetID())
+	if err != nil {
+		return 0, fmt.Errorf("Failed updating custom field definition, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (fieldDefRepo *customFieldDefinitionRepository) UpdateSortOrder(ctx context.Context, id int64, sortOrder int) (int64, error) {
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`UPDATE custom_field_definitions SET caption=$1, type=$2, mandatory=$3, modified_by=$4, modified_at=$5, vers=vers+1 
-		WHERE id=$6`)
+		`UPDATE custom_field_definitions SET sort_order=$1, vers=vers+1 
+		WHERE id=$2`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing update custom field definition, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing update custom field definition, error: %w", err)
 	}
 	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, data.GetCaption(), data.GetType(), data.GetMandatory(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetID())
+	result, err := stmt.ExecContext(ctx, sortOrder, id)
 	if err != nil {
-		return 0, fmt.Errorf("Failed updating custom field definition, error: %v", err)
+		return 0, fmt.Errorf("Failed updating custom field definition, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
 func (fieldDefRepo *customFieldDefinitionRepository) Delete(ctx context.Context, id int64) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -186,22 +272,22 @@ func (fieldDefRepo *customFieldDefinitionRepository) Delete(ctx context.Context,
 		`DELETE FROM custom_field_definitions 
 		WHERE id=$1`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing delete custom field definition, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing delete custom field definition, error: %w", err)
 	}
 	defer stmt.Close()
 
 	result, err := stmt.ExecContext(ctx, id)
 	if err != nil {
-		return 0, fmt.Errorf("Failed deleting custom field definition, error: %v", err)
+		return 0, fmt.Errorf("Failed deleting custom field definition, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
 func (fieldDefRepo *customFieldDefinitionRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return fmt.Errorf("Failed connecting to database, error: %v", err)
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -209,13 +295,13 @@ func (fieldDefRepo *customFieldDefinitionRepository) DeleteByCatalogue(ctx conte
 		`DELETE FROM custom_field_definitions 
 		WHERE clg_code=$1`)
 	if err != nil {
-		return fmt.Errorf("Failed preparing delete custom field definition, error: %v", err)
+		return fmt.Errorf("Failed preparing delete custom field definition, error: %w", err)
 	}
 	defer stmt.Close()
 
 	_, err = stmt.ExecContext(ctx, clgCode)
 	if err != nil {
-		return fmt.Errorf("Failed deleting custom field definition, error: %v", err)
+		return fmt.Errorf("Failed deleting custom field definition, error: %w", err)
 	}
 
 	return nil
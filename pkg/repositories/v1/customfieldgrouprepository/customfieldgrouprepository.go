@@ -0,0 +1,169 @@
+package customfieldgrouprepository
+
+import (
+	"context"
+	"fmt"
+
+	customfieldgroupmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfieldgroup"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// ICustomFieldGroupRepository type
+type ICustomFieldGroupRepository interface {
+	GetByCatalogue(context.Context, string) ([]*customfieldgroupmodel.CustomFieldGroup, error)
+	Create(context.Context, *customfieldgroupmodel.CustomFieldGroup) (int64, error)
+	Update(context.Context, *customfieldgroupmodel.CustomFieldGroup) (int64, error)
+	Delete(context.Context, int64) (int64, error)
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type customFieldGroupRepository struct {
+}
+
+// NewCustomFieldGroupRepository - Create custom field group repository
+func NewCustomFieldGroupRepository() ICustomFieldGroupRepository {
+	return &customFieldGroupRepository{}
+}
+
+func (grpRepo *customFieldGroupRepository) GetByCatalogue(ctx context.Context, clgCode string) ([]*customfieldgroupmodel.CustomFieldGroup, error) {
+	result := make([]*customfieldgroupmodel.CustomFieldGroup, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT id, clg_code, code, name, sort_order
+		FROM custom_field_groups
+		WHERE clg_code=$1
+		ORDER BY sort_order, code ASC`)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read custom field group, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, clgCode)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading custom field group, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve custom field group record, error: %w", err)
+			}
+			break
+		}
+
+		group := customfieldgroupmodel.NewCustomFieldGroup()
+		if err := rows.Scan(
+			&group.ID,
+			&group.CatalogueCode,
+			&group.Code,
+			&group.Name,
+			&group.SortOrder); err != nil {
+			return result, fmt.Errorf("Failed retrieve custom field group record value, error: %w", err)
+		}
+
+		result = append(result, group)
+	}
+
+	return result, nil
+}
+
+func (grpRepo *customFieldGroupRepository) Create(ctx context.Context, data *customfieldgroupmodel.CustomFieldGroup) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO custom_field_groups 
+			(clg_code, code, name, sort_order) 
+		VALUES ($1, $2, $3, $4) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert custom field group, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetCode(), data.GetName(), data.GetSortOrder()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting custom field group, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+func (grpRepo *customFieldGroupRepository) Update(ctx context.Context, data *customfieldgroupmodel.CustomFieldGroup) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`UPDATE custom_field_groups SET code=$1, name=$2, sort_order=$3 
+		WHERE id=$4`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing update custom field group, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetName(), data.GetSortOrder(), data.GetID())
+	if err != nil {
+		return 0, fmt.Errorf("Failed updating custom field group, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (grpRepo *customFieldGroupRepository) Delete(ctx context.Context, id int64) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM custom_field_groups 
+		WHERE id=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing delete custom field group, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("Failed deleting custom field group, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (grpRepo *customFieldGroupRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM custom_field_groups 
+		WHERE clg_code=$1`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing delete custom field group, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, clgCode)
+	if err != nil {
+		return fmt.Errorf("Failed deleting custom field group, error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package customfieldoptionrepository
+
+import (
+	"context"
+	"fmt"
+
+	customfieldoptionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfieldoption"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// ICustomFieldOptionRepository type
+type ICustomFieldOptionRepository interface {
+	GetByField(context.Context, int64) ([]*customfieldoptionmodel.CustomFieldOption, error)
+	Create(context.Context, *customfieldoptionmodel.CustomFieldOption) (int64, error)
+	Update(context.Context, *customfieldoptionmodel.CustomFieldOption) (int64, error)
+	Delete(context.Context, int64) (int64, error)
+	DeleteByField(context.Context, int64) error
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type customFieldOptionRepository struct {
+}
+
+// NewCustomFieldOptionRepository - Create custom field option repository
+func NewCustomFieldOptionRepository() ICustomFieldOptionRepository {
+	return &customFieldOptionRepository{}
+}
+
+func (optRepo *customFieldOptionRepository) GetByField(ctx context.Context, fieldID int64) ([]*customfieldoptionmodel.CustomFieldOption, error) {
+	result := make([]*customfieldoptionmodel.CustomFieldOption, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT id, field_id, code, label, sort_order, active
+		FROM custom_field_options
+		WHERE field_id=$1
+		ORDER BY sort_order, code ASC`)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read custom field option, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, fieldID)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading custom field option, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve custom field option record, error: %w", err)
+			}
+			break
+		}
+
+		option := customfieldoptionmodel.NewCustomFieldOption()
+		if err := rows.Scan(
+			&option.ID,
+			&option.FieldID,
+			&option.Code,
+			&option.Label,
+			&option.SortOrder,
+			&option.Active); err != nil {
+			return result, fmt.Errorf("Failed retrieve custom field option record value, error: %w", err)
+		}
+
+		result = append(result, option)
+	}
+
+	return result, nil
+}
+
+func (optRepo *customFieldOptionRepository) Create(ctx context.Context, data *customfieldoptionmodel.CustomFieldOption) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO custom_field_options 
+			(field_id, code, label, sort_order, active) 
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert custom field option, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetFieldID(), data.GetCode(), data.GetLabel(), data.GetSortOrder(), data.IsActive()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting custom field option, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+func (optRepo *customFieldOptionRepository) Update(ctx context.Context, data *customfieldoptionmodel.CustomFieldOption) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`UPDATE custom_field_options SET code=$1, label=$2, sort_order=$3, active=$4 
+		WHERE id=$5`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing update custom field option, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetLabel(), data.GetSortOrder(), data.IsActive(), data.GetID())
+	if err != nil {
+		return 0, fmt.Errorf("Failed updating custom field option, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (optRepo *customFieldOptionRepository) Delete(ctx context.Context, id int64) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM custom_field_options 
+		WHERE id=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing delete custom field option, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("Failed deleting custom field option, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (optRepo *customFieldOptionRepository) DeleteByField(ctx context.Context, fieldID int64) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM custom_field_options 
+		WHERE field_id=$1`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing delete custom field option, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, fieldID)
+	if err != nil {
+		return fmt.Errorf("Failed deleting custom field option, error: %w", err)
+	}
+
+	return nil
+}
+
+func (optRepo *customFieldOptionRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM custom_field_options 
+		WHERE field_id IN (SELECT id FROM custom_field_definitions WHERE clg_code=$1)`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing delete custom field option, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, clgCode)
+	if err != nil {
+		return fmt.Errorf("Failed deleting custom field option, error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package importjobrepository
+
+import (
+	"context"
+	"fmt"
+
+	importjobmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/importjob"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IImportJobRepository type
+type IImportJobRepository interface {
+	GetByID(context.Context, int64) (*importjobmodel.ImportJob, error)
+	Create(context.Context, *importjobmodel.ImportJob) (int64, error)
+	Update(context.Context, *importjobmodel.ImportJob) (int64, error)
+	GetErrors(context.Context, int64) ([]*importjobmodel.ImportError, error)
+	CreateError(context.Context, *importjobmodel.ImportError) (int64, error)
+}
+
+type importJobRepository struct {
+}
+
+// NewImportJobRepository - Create import job repository
+func NewImportJobRepository() IImportJobRepository {
+	return &importJobRepository{}
+}
+
+func (jobRepo *importJobRepository) GetByID(ctx context.Context, id int64) (*importjobmodel.ImportJob, error) {
+	result := importjobmodel.NewImportJob()
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT id, clg_code, format, dry_run, status, total_rows, processed_rows, created_rows, updated_rows, failed_rows, message, created_by, created_at, modified_at
+		FROM import_jobs 
+		WHERE id=$1`)
+	if err != nil {
+		return nil, fmt.Errorf("Failed preparing read import job, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading import job, error: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("Failed retrieve import job record, error: %w", err)
+		}
+		return nil, nil
+	}
+
+	if err := rows.Scan(
+		&result.ID,
+		&result.CatalogueCode,
+		&result.Format,
+		&result.DryRun,
+		&result.Status,
+		&result.TotalRows,
+		&result.ProcessedRows,
+		&result.CreatedRows,
+		&result.UpdatedRows,
+		&result.FailedRows,
+		&result.Message,
+		&result.CreatedBy,
+		&result.CreatedAt,
+		&result.ModifiedAt); err != nil {
+		return nil, fmt.Errorf("Failed retrieve import job record value, error: %w", err)
+	}
+
+	return result, nil
+}
+
+func (jobRepo *importJobRepository) Create(ctx context.Context, data *importjobmodel.ImportJob) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO import_jobs 
+			(clg_code, format, dry_run, status, total_rows, processed_rows, created_rows, updated_rows, failed_rows, message, created_by, created_at, modified_at) 
+		VALUES ($1, $2, $3, $4, 0, 0, 0, 0, 0, '', $5, $6, $7) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert import job, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetFormat(), data.GetDryRun(), data.GetStatus(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedAt()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting import job, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+func (jobRepo *importJobRepository) Update(ctx context.Context, data *importjobmodel.ImportJob) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`UPDATE import_jobs SET status=$1, total_rows=$2, processed_rows=$3, created_rows=$4, updated_rows=$5, failed_rows=$6, message=$7, modified_at=$8 
+		WHERE id=$9`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing update import job, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetStatus(), data.GetTotalRows(), data.GetProcessedRows(), data.GetCreatedRows(), data.GetUpdatedRows(), data.GetFailedRows(), data.GetMessage(), data.GetModifiedAt(), data.GetID())
+	if err != nil {
+		return 0, fmt.Errorf("Failed updating import job, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (jobRepo *importJobRepository) GetErrors(ctx context.Context, jobID int64) ([]*importjobmodel.ImportError, error) {
+	result := make([]*importjobmodel.ImportError, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT id, job_id, row_no, code, message
+		FROM import_job_errors
+		WHERE job_id=$1
+		ORDER BY row_no, id ASC`)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read import job error, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, jobID)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading import job error, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve import job error record, error: %w", err)
+			}
+			break
+		}
+
+		importError := importjobmodel.NewImportError()
+		if err := rows.Scan(
+			&importError.ID,
+			&importError.JobID,
+			&importError.RowNo,
+			&importError.Code,
+			&importError.Message); err != nil {
+			return result, fmt.Errorf("Failed retrieve import job error record value, error: %w", err)
+		}
+
+		result = append(result, importError)
+	}
+
+	return result, nil
+}
+
+func (jobRepo *importJobRepository) CreateError(ctx context.Context, data *importjobmodel.ImportError) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO import_job_errors 
+			(job_id, row_no, code, message) 
+		VALUES ($1, $2, $3, $4) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert import job error, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetJobID(), data.GetRowNo(), data.GetCode(), data.GetMessage()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting import job error, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
@@ -0,0 +1,171 @@
+package pricelistrepository
+
+import (
+	"context"
+	"fmt"
+
+	pricelistmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/pricelist"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IPriceListRepository type
+type IPriceListRepository interface {
+	GetByID(context.Context, string) (*pricelistmodel.PriceList, error)
+	GetAll(context.Context) ([]*pricelistmodel.PriceList, error)
+	Create(context.Context, *pricelistmodel.PriceList) (int64, error)
+	Update(context.Context, *pricelistmodel.PriceList) (int64, error)
+	Delete(context.Context, string) (int64, error)
+}
+
+type priceListRepository struct {
+}
+
+// NewPriceListRepository - Create price list repository
+func NewPriceListRepository() IPriceListRepository {
+	return &priceListRepository{}
+}
+
+func (listRepo *priceListRepository) GetByID(ctx context.Context, code string) (*pricelistmodel.PriceList, error) {
+	result, err := listRepo.read(ctx,
+		`SELECT code, descr, currency, segment, valid_from, valid_to, status, created_by, created_at, modified_by, modified_at, vers
+		FROM price_lists
+		WHERE code=$1`, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result[0], nil
+}
+
+func (listRepo *priceListRepository) GetAll(ctx context.Context) ([]*pricelistmodel.PriceList, error) {
+	return listRepo.read(ctx,
+		`SELECT code, descr, currency, segment, valid_from, valid_to, status, created_by, created_at, modified_by, modified_at, vers
+		FROM price_lists
+		ORDER BY code ASC`)
+}
+
+func (listRepo *priceListRepository) read(ctx context.Context, query string, args ...interface{}) ([]*pricelistmodel.PriceList, error) {
+	result := make([]*pricelistmodel.PriceList, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read price list, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading price list, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve price list record, error: %w", err)
+			}
+			break
+		}
+
+		list := pricelistmodel.NewPriceList()
+		if err := rows.Scan(
+			&list.Code,
+			&list.Description,
+			&list.Currency,
+			&list.Segment,
+			&list.ValidFrom,
+			&list.ValidTo,
+			&list.Status,
+			&list.CreatedBy,
+			&list.CreatedAt,
+			&list.ModifiedBy,
+			&list.ModifiedAt,
+			&list.Vers); err != nil {
+			return result, fmt.Errorf("Failed retrieve price list record value, error: %w", err)
+		}
+
+		result = append(result, list)
+	}
+
+	return result, nil
+}
+
+func (listRepo *priceListRepository) Create(ctx context.Context, data *pricelistmodel.PriceList) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO price_lists 
+			(code, descr, currency, segment, valid_from, valid_to, status, created_by, created_at, modified_by, modified_at, vers) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 1)`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert price list, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetDescription(), data.GetCurrency(), data.GetSegment(), data.GetValidFrom(), data.GetValidTo(), data.GetStatus(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt())
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting price list, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (listRepo *priceListRepository) Update(ctx context.Context, data *pricelistmodel.PriceList) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`UPDATE price_lists SET descr=$1, currency=$2, segment=$3, valid_from=$4, valid_to=$5, status=$6, modified_by=$7, modified_at=$8, vers=vers+1 
+		WHERE code=$9 AND vers=$10`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing update price list, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetDescription(), data.GetCurrency(), data.GetSegment(), data.GetValidFrom(), data.GetValidTo(), data.GetStatus(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetCode(), data.GetVers())
+	if err != nil {
+		return 0, fmt.Errorf("Failed updating price list, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (listRepo *priceListRepository) Delete(ctx context.Context, code string) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM price_lists 
+		WHERE code=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing delete price list, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, code)
+	if err != nil {
+		return 0, fmt.Errorf("Failed deleting price list, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,152 @@
+package productcategoryrepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IProductCategoryRepository type
+type IProductCategoryRepository interface {
+	GetByProduct(context.Context, int64) ([]int64, error)
+	CountByCategory(context.Context, int64) (int64, error)
+	Create(context.Context, int64, int64) (int64, error)
+	DeleteByProduct(context.Context, int64) error
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type productCategoryRepository struct {
+}
+
+// NewProductCategoryRepository - Create product category repository
+func NewProductCategoryRepository() IProductCategoryRepository {
+	return &productCategoryRepository{}
+}
+
+// GetByProduct - Returns ids of categories product is assigned to
+func (pcRepo *productCategoryRepository) GetByProduct(ctx context.Context, prodID int64) ([]int64, error) {
+	result := make([]int64, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT category_id
+		FROM product_categories
+		WHERE prod_id=$1
+		ORDER BY category_id ASC`)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read product category, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, prodID)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading product category, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve product category record, error: %w", err)
+			}
+			break
+		}
+
+		var categoryID int64
+		if err := rows.Scan(&categoryID); err != nil {
+			return result, fmt.Errorf("Failed retrieve product category record value, error: %w", err)
+		}
+
+		result = append(result, categoryID)
+	}
+
+	return result, nil
+}
+
+func (pcRepo *productCategoryRepository) CountByCategory(ctx context.Context, categoryID int64) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT COUNT(*)
+		FROM product_categories
+		WHERE category_id=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing read product category, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, categoryID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading product category, error: %w", err)
+	}
+
+	return count, nil
+}
+
+func (pcRepo *productCategoryRepository) Create(ctx context.Context, prodID int64, categoryID int64) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO product_categories
+			(prod_id, category_id)
+		VALUES ($1, $2)`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert product category, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, prodID, categoryID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting product category, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (pcRepo *productCategoryRepository) DeleteByProduct(ctx context.Context, prodID int64) error {
+	return pcRepo.delete(ctx,
+		`DELETE FROM product_categories
+		WHERE prod_id=$1`, prodID)
+}
+
+func (pcRepo *productCategoryRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	return pcRepo.delete(ctx,
+		`DELETE FROM product_categories
+		WHERE category_id IN (SELECT id FROM categories WHERE clg_code=$1)`, clgCode)
+}
+
+func (pcRepo *productCategoryRepository) delete(ctx context.Context, query string, args ...interface{}) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("Failed preparing delete product category, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("Failed deleting product category, error: %w", err)
+	}
+
+	return nil
+}
@@ -4,18 +4,24 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/bungysheep/catalogue-api/pkg/commons/definitiontype"
+	customfielddefinitionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfielddefinition"
 	productcustomfieldmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/productcustomfield"
 	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/lib/pq"
 )
 
 // IProductCustomFieldRepository type
 type IProductCustomFieldRepository interface {
 	GetByID(context.Context, int64) (*productcustomfieldmodel.ProductCustomField, error)
 	GetByProduct(context.Context, int64) ([]*productcustomfieldmodel.ProductCustomField, error)
+	ForEachByField(context.Context, int64, func(string, *productcustomfieldmodel.ProductCustomField) error) error
 	Create(context.Context, *productcustomfieldmodel.ProductCustomField) (int64, error)
 	Update(context.Context, *productcustomfieldmodel.ProductCustomField) (int64, error)
 	Delete(context.Context, int64) (int64, error)
 	DeleteByProduct(context.Context, int64) error
+	CountByOption(context.Context, int64, string) (int64, error)
+	GetProductCodeByValue(context.Context, *customfielddefinitionmodel.CustomFieldDefinition, *productcustomfieldmodel.ProductCustomField, int64) (string, error)
 }
 
 type productCustomFieldRepository struct {
@@ -29,30 +35,30 @@ func NewProductCustomFieldRepository() IProductCustomFieldRepository {
 func (pcfRepo *productCustomFieldRepository) GetByID(ctx context.Context, id int64) (*productcustomfieldmodel.ProductCustomField, error) {
 	result := productcustomfieldmodel.NewProductCustomField()
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`SELECT id, prod_id, field_id, alpha_value, numeric_value, date_value
+		`SELECT id, prod_id, field_id, alpha_value, numeric_value, date_value, boolean_value, integer_value, decimal_value, multi_value
 		FROM product_custom_fields 
 		WHERE id=$1`)
 	if err != nil {
-		return nil, fmt.Errorf("Failed preparing read product custom field, error: %v", err)
+		return nil, fmt.Errorf("Failed preparing read product custom field, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("Failed reading product custom field, error: %v", err)
+		return nil, fmt.Errorf("Failed reading product custom field, error: %w", err)
 	}
 	defer rows.Close()
 
 	if !rows.Next() {
 		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("Failed retrieve product custom field record, error: %v", err)
+			return nil, fmt.Errorf("Failed retrieve product custom field record, error: %w", err)
 		}
 		return nil, nil
 	}
@@ -63,8 +69,12 @@ func (pcfRepo *productCustomFieldRepository) GetByID(ctx context.Context, id int
 		&result.FieldID,
 		&result.AlphaValue,
 		&result.NumericValue,
-		&result.DateValue); err != nil {
-		return nil, fmt.Errorf("Failed retrieve product custom field record value, error: %v", err)
+		&result.DateValue,
+		&result.BooleanValue,
+		&result.IntegerValue,
+		&result.DecimalValue,
+		pq.Array(&result.MultiValue)); err != nil {
+		return nil, fmt.Errorf("Failed retrieve product custom field record value, error: %w", err)
 	}
 
 	return result, nil
@@ -73,32 +83,32 @@ func (pcfRepo *productCustomFieldRepository) GetByID(ctx context.Context, id int
 func (pcfRepo *productCustomFieldRepository) GetByProduct(ctx context.Context, prodID int64) ([]*productcustomfieldmodel.ProductCustomField, error) {
 	result := make([]*productcustomfieldmodel.ProductCustomField, 0)
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return result, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`SELECT id, prod_id, field_id, alpha_value, numeric_value, date_value
+		`SELECT id, prod_id, field_id, alpha_value, numeric_value, date_value, boolean_value, integer_value, decimal_value, multi_value
 		FROM product_custom_fields
 		WHERE prod_id=$1
 		ORDER BY field_id, id ASC`)
 	if err != nil {
-		return result, fmt.Errorf("Failed preparing read product custom field, error: %v", err)
+		return result, fmt.Errorf("Failed preparing read product custom field, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, prodID)
 	if err != nil {
-		return result, fmt.Errorf("Failed reading product custom field, error: %v", err)
+		return result, fmt.Errorf("Failed reading product custom field, error: %w", err)
 	}
 	defer rows.Close()
 
 	for {
 		if !rows.Next() {
 			if err := rows.Err(); err != nil {
-				return result, fmt.Errorf("Failed retrieve product custom field record, error: %v", err)
+				return result, fmt.Errorf("Failed retrieve product custom field record, error: %w", err)
 			}
 			break
 		}
@@ -110,8 +120,12 @@ func (pcfRepo *productCustomFieldRepository) GetByProduct(ctx context.Context, p
 			&field.FieldID,
 			&field.AlphaValue,
 			&field.NumericValue,
-			&field.DateValue); err != nil {
-			return result, fmt.Errorf("Failed retrieve product custom field record value, error: %v", err)
+			&field.DateValue,
+			&field.BooleanValue,
+			&field.IntegerValue,
+			&field.DecimalValue,
+			pq.Array(&field.MultiValue)); err != nil {
+			return result, fmt.Errorf("Failed retrieve product custom field record value, error: %w", err)
 		}
 
 		result = append(result, field)
@@ -120,58 +134,115 @@ func (pcfRepo *productCustomFieldRepository) GetByProduct(ctx context.Context, p
 	return result, nil
 }
 
+func (pcfRepo *productCustomFieldRepository) ForEachByField(ctx context.Context, fieldID int64, fn func(string, *productcustomfieldmodel.ProductCustomField) error) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT p.code, f.id, f.prod_id, f.field_id, f.alpha_value, f.numeric_value, f.date_value, f.boolean_value, f.integer_value, f.decimal_value, f.multi_value
+		FROM product_custom_fields f
+		INNER JOIN products p ON p.id=f.prod_id
+		WHERE f.field_id=$1
+		ORDER BY f.prod_id, f.id ASC`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing read product custom field, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, fieldID)
+	if err != nil {
+		return fmt.Errorf("Failed reading product custom field, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("Failed retrieve product custom field record, error: %w", err)
+			}
+			break
+		}
+
+		var prodCode string
+		field := productcustomfieldmodel.NewProductCustomField()
+		if err := rows.Scan(
+			&prodCode,
+			&field.ID,
+			&field.ProdID,
+			&field.FieldID,
+			&field.AlphaValue,
+			&field.NumericValue,
+			&field.DateValue,
+			&field.BooleanValue,
+			&field.IntegerValue,
+			&field.DecimalValue,
+			pq.Array(&field.MultiValue)); err != nil {
+			return fmt.Errorf("Failed retrieve product custom field record value, error: %w", err)
+		}
+
+		if err := fn(prodCode, field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (pcfRepo *productCustomFieldRepository) Create(ctx context.Context, data *productcustomfieldmodel.ProductCustomField) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
 		`INSERT INTO product_custom_fields 
-			(prod_id, field_id, alpha_value, numeric_value, date_value) 
-		VALUES ($1, $2, $3, $4, $5) RETURNING id`)
+			(prod_id, field_id, alpha_value, numeric_value, date_value, boolean_value, integer_value, decimal_value, multi_value) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing insert product custom field, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing insert product custom field, error: %w", err)
 	}
 	defer stmt.Close()
 
 	var lastInsertID int64
-	err = stmt.QueryRowContext(ctx, data.GetProdID(), data.GetFieldID(), data.GetAlphaValue(), data.GetNumericValue(), data.GetDateValue()).Scan(&lastInsertID)
+	err = stmt.QueryRowContext(ctx, data.GetProdID(), data.GetFieldID(), data.GetAlphaValue(), data.GetNumericValue(), data.GetDateValue(), data.GetBooleanValue(), data.GetIntegerValue(), data.GetDecimalValue(), pq.Array(data.GetMultiValue())).Scan(&lastInsertID)
 	if err != nil {
-		return 0, fmt.Errorf("Failed inserting product custom field, error: %v", err)
+		return 0, fmt.Errorf("Failed inserting product custom field, error: %w", err)
 	}
 
 	return lastInsertID, nil
 }
 
 func (pcfRepo *productCustomFieldRepository) Update(ctx context.Context, data *productcustomfieldmodel.ProductCustomField) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`UPDATE product_custom_fields SET alpha_value=$1, numeric_value=$2, date_value=$3 
-		WHERE id=$4`)
+		`UPDATE product_custom_fields SET alpha_value=$1, numeric_value=$2, date_value=$3, boolean_value=$4, integer_value=$5, decimal_value=$6, multi_value=$7 
+		WHERE id=$8`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing update product custom field, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing update product custom field, error: %w", err)
 	}
 	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, data.GetAlphaValue(), data.GetNumericValue(), data.GetDateValue(), data.GetID())
+	result, err := stmt.ExecContext(ctx, data.GetAlphaValue(), data.GetNumericValue(), data.GetDateValue(), data.GetBooleanValue(), data.GetIntegerValue(), data.GetDecimalValue(), pq.Array(data.GetMultiValue()), data.GetID())
 	if err != nil {
-		return 0, fmt.Errorf("Failed updating product custom field, error: %v", err)
+		return 0, fmt.Errorf("Failed updating product custom field, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
 func (pcfRepo *productCustomFieldRepository) Delete(ctx context.Context, id int64) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -179,22 +250,22 @@ func (pcfRepo *productCustomFieldRepository) Delete(ctx context.Context, id int6
 		`DELETE FROM product_custom_fields 
 		WHERE id=$1`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing delete product custom field, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing delete product custom field, error: %w", err)
 	}
 	defer stmt.Close()
 
 	result, err := stmt.ExecContext(ctx, id)
 	if err != nil {
-		return 0, fmt.Errorf("Failed deleting product custom field, error: %v", err)
+		return 0, fmt.Errorf("Failed deleting product custom field, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
 func (pcfRepo *productCustomFieldRepository) DeleteByProduct(ctx context.Context, prodID int64) error {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return fmt.Errorf("Failed connecting to database, error: %v", err)
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -202,14 +273,99 @@ func (pcfRepo *productCustomFieldRepository) DeleteByProduct(ctx context.Context
 		`DELETE FROM product_custom_fields 
 		WHERE prod_id=$1`)
 	if err != nil {
-		return fmt.Errorf("Failed preparing delete product custom field, error: %v", err)
+		return fmt.Errorf("Failed preparing delete product custom field, error: %w", err)
 	}
 	defer stmt.Close()
 
 	_, err = stmt.ExecContext(ctx, prodID)
 	if err != nil {
-		return fmt.Errorf("Failed deleting product custom field, error: %v", err)
+		return fmt.Errorf("Failed deleting product custom field, error: %w", err)
 	}
 
 	return nil
 }
+
+func (pcfRepo *productCustomFieldRepository) CountByOption(ctx context.Context, fieldID int64, code string) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT COUNT(*)
+		FROM product_custom_fields
+		WHERE field_id=$1 AND (alpha_value=$2 OR $2=ANY(multi_value))`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing read product custom field, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, fieldID, code).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading product custom field, error: %w", err)
+	}
+
+	return count, nil
+}
+
+func (pcfRepo *productCustomFieldRepository) GetProductCodeByValue(ctx context.Context, fieldDef *customfielddefinitionmodel.CustomFieldDefinition, data *productcustomfieldmodel.ProductCustomField, excludeProdID int64) (string, error) {
+	var column string
+	var value interface{}
+
+	switch fieldDef.GetType() {
+	case definitiontype.Numeric.String():
+		column, value = "numeric_value", data.GetNumericValue()
+
+	case definitiontype.Date.String():
+		column, value = "date_value", data.GetDateValue()
+
+	case definitiontype.Integer.String():
+		column, value = "integer_value", data.GetIntegerValue()
+
+	case definitiontype.Decimal.String():
+		column, value = "decimal_value", data.GetDecimalValue()
+
+	default:
+		column, value = "alpha_value", data.GetAlphaValue()
+
+	}
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return "", fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, fmt.Sprintf(
+		`SELECT p.code
+		FROM product_custom_fields f
+		INNER JOIN products p ON p.id=f.prod_id
+		WHERE f.field_id=$1 AND f.%s=$2 AND f.prod_id<>$3
+		LIMIT 1`, column))
+	if err != nil {
+		return "", fmt.Errorf("Failed preparing read product custom field, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, fieldDef.GetID(), value, excludeProdID)
+	if err != nil {
+		return "", fmt.Errorf("Failed reading product custom field, error: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", fmt.Errorf("Failed retrieve product custom field record, error: %w", err)
+		}
+		return "", nil
+	}
+
+	var code string
+	if err := rows.Scan(&code); err != nil {
+		return "", fmt.Errorf("Failed retrieve product custom field record value, error: %w", err)
+	}
+
+	return code, nil
+}
@@ -0,0 +1,160 @@
+package productpricerepository
+
+import (
+	"context"
+	"fmt"
+
+	productpricemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/productprice"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IProductPriceRepository type
+type IProductPriceRepository interface {
+	GetByList(context.Context, string) ([]*productpricemodel.ProductPrice, error)
+	GetByListProduct(context.Context, string, int64) ([]*productpricemodel.ProductPrice, error)
+	Create(context.Context, *productpricemodel.ProductPrice) (int64, error)
+	DeleteByListProduct(context.Context, string, int64) error
+	DeleteByList(context.Context, string) error
+	DeleteByProduct(context.Context, int64) error
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type productPriceRepository struct {
+}
+
+// NewProductPriceRepository - Create product price repository
+func NewProductPriceRepository() IProductPriceRepository {
+	return &productPriceRepository{}
+}
+
+func (priceRepo *productPriceRepository) GetByList(ctx context.Context, listCode string) ([]*productpricemodel.ProductPrice, error) {
+	return priceRepo.read(ctx,
+		`SELECT id, list_code, prod_id, uom_code, min_qty, price
+		FROM product_prices
+		WHERE list_code=$1
+		ORDER BY prod_id, uom_code, min_qty ASC`, listCode)
+}
+
+func (priceRepo *productPriceRepository) GetByListProduct(ctx context.Context, listCode string, prodID int64) ([]*productpricemodel.ProductPrice, error) {
+	return priceRepo.read(ctx,
+		`SELECT id, list_code, prod_id, uom_code, min_qty, price
+		FROM product_prices
+		WHERE list_code=$1 AND prod_id=$2
+		ORDER BY uom_code, min_qty ASC`, listCode, prodID)
+}
+
+func (priceRepo *productPriceRepository) read(ctx context.Context, query string, args ...interface{}) ([]*productpricemodel.ProductPrice, error) {
+	result := make([]*productpricemodel.ProductPrice, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read product price, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading product price, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve product price record, error: %w", err)
+			}
+			break
+		}
+
+		price := productpricemodel.NewProductPrice()
+		if err := rows.Scan(
+			&price.ID,
+			&price.ListCode,
+			&price.ProdID,
+			&price.UomCode,
+			&price.MinQty,
+			&price.Price); err != nil {
+			return result, fmt.Errorf("Failed retrieve product price record value, error: %w", err)
+		}
+
+		result = append(result, price)
+	}
+
+	return result, nil
+}
+
+func (priceRepo *productPriceRepository) Create(ctx context.Context, data *productpricemodel.ProductPrice) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO product_prices 
+			(list_code, prod_id, uom_code, min_qty, price) 
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert product price, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetListCode(), data.GetProdID(), data.GetUomCode(), data.GetMinQty(), data.GetPrice()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting product price, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+func (priceRepo *productPriceRepository) DeleteByListProduct(ctx context.Context, listCode string, prodID int64) error {
+	return priceRepo.delete(ctx,
+		`DELETE FROM product_prices 
+		WHERE list_code=$1 AND prod_id=$2`, listCode, prodID)
+}
+
+func (priceRepo *productPriceRepository) DeleteByList(ctx context.Context, listCode string) error {
+	return priceRepo.delete(ctx,
+		`DELETE FROM product_prices 
+		WHERE list_code=$1`, listCode)
+}
+
+func (priceRepo *productPriceRepository) DeleteByProduct(ctx context.Context, prodID int64) error {
+	return priceRepo.delete(ctx,
+		`DELETE FROM product_prices 
+		WHERE prod_id=$1`, prodID)
+}
+
+func (priceRepo *productPriceRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	return priceRepo.delete(ctx,
+		`DELETE FROM product_prices 
+		WHERE prod_id IN (SELECT id FROM products WHERE clg_code=$1)`, clgCode)
+}
+
+func (priceRepo *productPriceRepository) delete(ctx context.Context, query string, args ...interface{}) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("Failed preparing delete product price, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("Failed deleting product price, error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package productrelationrepository
+
+import (
+	"context"
+	"fmt"
+
+	productrelationmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/productrelation"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IProductRelationRepository type
+type IProductRelationRepository interface {
+	GetByProduct(context.Context, int64) ([]*productrelationmodel.ProductRelation, error)
+	GetByRelated(context.Context, int64) ([]*productrelationmodel.ProductRelation, error)
+	Create(context.Context, *productrelationmodel.ProductRelation) (int64, error)
+	DeleteByProduct(context.Context, int64) error
+	DeleteByProductOrRelated(context.Context, int64) error
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type productRelationRepository struct {
+}
+
+// NewProductRelationRepository - Create product relation repository
+func NewProductRelationRepository() IProductRelationRepository {
+	return &productRelationRepository{}
+}
+
+func (relRepo *productRelationRepository) GetByProduct(ctx context.Context, prodID int64) ([]*productrelationmodel.ProductRelation, error) {
+	return relRepo.read(ctx,
+		`SELECT id, prod_id, type, related_id, qty, uom_code, created_by, created_at
+		FROM product_relations
+		WHERE prod_id=$1
+		ORDER BY type, id ASC`, prodID)
+}
+
+func (relRepo *productRelationRepository) GetByRelated(ctx context.Context, relatedID int64) ([]*productrelationmodel.ProductRelation, error) {
+	return relRepo.read(ctx,
+		`SELECT id, prod_id, type, related_id, qty, uom_code, created_by, created_at
+		FROM product_relations
+		WHERE related_id=$1
+		ORDER BY type, id ASC`, relatedID)
+}
+
+func (relRepo *productRelationRepository) read(ctx context.Context, query string, args ...interface{}) ([]*productrelationmodel.ProductRelation, error) {
+	result := make([]*productrelationmodel.ProductRelation, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read product relation, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading product relation, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve product relation record, error: %w", err)
+			}
+			break
+		}
+
+		rel := productrelationmodel.NewProductRelation()
+		if err := rows.Scan(
+			&rel.ID,
+			&rel.ProdID,
+			&rel.Type,
+			&rel.RelatedID,
+			&rel.Qty,
+			&rel.UomCode,
+			&rel.CreatedBy,
+			&rel.CreatedAt); err != nil {
+			return result, fmt.Errorf("Failed retrieve product relation record value, error: %w", err)
+		}
+
+		result = append(result, rel)
+	}
+
+	return result, nil
+}
+
+func (relRepo *productRelationRepository) Create(ctx context.Context, data *productrelationmodel.ProductRelation) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO product_relations 
+			(prod_id, type, related_id, qty, uom_code, created_by, created_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert product relation, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetProdID(), data.GetType(), data.GetRelatedID(), data.GetQty(), data.GetUomCode(), data.GetCreatedBy(), data.GetCreatedAt()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting product relation, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+func (relRepo *productRelationRepository) DeleteByProduct(ctx context.Context, prodID int64) error {
+	return relRepo.delete(ctx,
+		`DELETE FROM product_relations 
+		WHERE prod_id=$1`, prodID)
+}
+
+func (relRepo *productRelationRepository) DeleteByProductOrRelated(ctx context.Context, prodID int64) error {
+	return relRepo.delete(ctx,
+		`DELETE FROM product_relations 
+		WHERE prod_id=$1 OR related_id=$1`, prodID)
+}
+
+func (relRepo *productRelationRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	return relRepo.delete(ctx,
+		`DELETE FROM product_relations 
+		WHERE prod_id IN (SELECT id FROM products WHERE clg_code=$1) 
+			OR related_id IN (SELECT id FROM products WHERE clg_code=$1)`, clgCode)
+}
+
+func (relRepo *productRelationRepository) delete(ctx context.Context, query string, args ...interface{}) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("Failed preparing delete product relation, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("Failed deleting product relation, error: %w", err)
+	}
+
+	return nil
+}
@@ -3,20 +3,35 @@ package productrepository
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/bungysheep/catalogue-api/pkg/commons/lifecycle"
 	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
 	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcategoryrepository"
 	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcustomfieldrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrelationrepository"
 	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/unitofmeasurerepository"
+	"github.com/lib/pq"
 )
 
 // IProductRepository type
 type IProductRepository interface {
 	GetByID(context.Context, int64) (*productmodel.Product, error)
+	GetByCode(context.Context, string, string) (*productmodel.Product, error)
 	GetByCatalogue(context.Context, string) ([]*productmodel.Product, error)
+	GetByParent(context.Context, int64) ([]*productmodel.Product, error)
+	ForEachByCatalogue(context.Context, string, func(*productmodel.Product) error) error
+	ForEachFamilyByCatalogue(context.Context, string, func(*productmodel.Product) error) error
+	ForEachByCategoryPath(context.Context, string, func(*productmodel.Product) error) error
+	CountByCatalogue(context.Context, string) (int64, error)
+	CountByParent(context.Context, int64) (int64, error)
 	Create(context.Context, *productmodel.Product) (int64, error)
 	Update(context.Context, *productmodel.Product) (int64, error)
-	Delete(context.Context, int64) (int64, error)
+	Move(context.Context, *productmodel.Product) (int64, error)
+	ActivateEffective(context.Context, []string, string, time.Time, string) (int64, error)
+	RetireExpired(context.Context, []string, string, time.Time, string) (int64, error)
+	Delete(context.Context, int64, int64) (int64, error)
 	DeleteByCatalogue(context.Context, string) error
 }
 
@@ -31,30 +46,30 @@ func NewProductRepository() IProductRepository {
 func (prodRepo *productRepository) GetByID(ctx context.Context, id int64) (*productmodel.Product, error) {
 	result := productmodel.NewProduct()
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`SELECT id, clg_code, code, descr, details, status, created_by, created_at, modified_by, modified_at, vers
+		`SELECT id, clg_code, parent_id, code, descr, details, status, effective_from, effective_to, created_by, created_at, modified_by, modified_at, vers
 		FROM products 
 		WHERE id=$1`)
 	if err != nil {
-		return nil, fmt.Errorf("Failed preparing read product, error: %v", err)
+		return nil, fmt.Errorf("Failed preparing read product, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("Failed reading product, error: %v", err)
+		return nil, fmt.Errorf("Failed reading product, error: %w", err)
 	}
 	defer rows.Close()
 
 	if !rows.Next() {
 		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("Failed retrieve product record, error: %v", err)
+			return nil, fmt.Errorf("Failed retrieve product record, error: %w", err)
 		}
 		return nil, nil
 	}
@@ -62,18 +77,25 @@ func (prodRepo *productRepository) GetByID(ctx context.Context, id int64) (*prod
 	if err := rows.Scan(
 		&result.ID,
 		&result.CatalogueCode,
+		&result.ParentID,
 		&result.Code,
 		&result.Description,
 		&result.Details,
 		&result.Status,
+		&result.EffectiveFrom,
+		&result.EffectiveTo,
 		&result.CreatedBy,
 		&result.CreatedAt,
 		&result.ModifiedBy,
 		&result.ModifiedAt,
 		&result.Vers); err != nil {
-		return nil, fmt.Errorf("Failed retrieve product record value, error: %v", err)
+		return nil, fmt.Errorf("Failed retrieve product record value, error: %w", err)
 	}
 
+	result.Status = lifecycle.FromStored(result.GetStatus())
+
+	rows.Close()
+
 	uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
 	uoms, err := uomRepo.GetByProduct(ctx, id)
 	if err != nil {
@@ -90,37 +112,205 @@ func (prodRepo *productRepository) GetByID(ctx context.Context, id int64) (*prod
 
 	result.CustomFields = fields
 
+	pcRepo := productcategoryrepository.NewProductCategoryRepository()
+	categoryIDs, err := pcRepo.GetByProduct(ctx, id)
+	if err != nil {
+		return result, err
+	}
+
+	result.CategoryIDs = categoryIDs
+
 	return result, nil
 }
 
-func (prodRepo *productRepository) GetByCatalogue(ctx context.Context, clgCode string) ([]*productmodel.Product, error) {
-	result := make([]*productmodel.Product, 0)
+func (prodRepo *productRepository) GetByCode(ctx context.Context, clgCode string, code string) (*productmodel.Product, error) {
+	result := productmodel.NewProduct()
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return result, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`SELECT id, clg_code, code, descr, details, status, created_by, created_at, modified_by, modified_at, vers
+		`SELECT id, clg_code, parent_id, code, descr, details, status, effective_from, effective_to, created_by, created_at, modified_by, modified_at, vers
+		FROM products 
+		WHERE clg_code=$1 AND code=$2`)
+	if err != nil {
+		return nil, fmt.Errorf("Failed preparing read product, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, clgCode, code)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading product, error: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("Failed retrieve product record, error: %w", err)
+		}
+		return nil, nil
+	}
+
+	if err := rows.Scan(
+		&result.ID,
+		&result.CatalogueCode,
+		&result.ParentID,
+		&result.Code,
+		&result.Description,
+		&result.Details,
+		&result.Status,
+		&result.EffectiveFrom,
+		&result.EffectiveTo,
+		&result.CreatedBy,
+		&result.CreatedAt,
+		&result.ModifiedBy,
+		&result.ModifiedAt,
+		&result.Vers); err != nil {
+		return nil, fmt.Errorf("Failed retrieve product record value, error: %w", err)
+	}
+
+	result.Status = lifecycle.FromStored(result.GetStatus())
+
+	rows.Close()
+
+	uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
+	uoms, err := uomRepo.GetByProduct(ctx, result.GetID())
+	if err != nil {
+		return result, err
+	}
+
+	result.UnitOfMeasures = uoms
+
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+	fields, err := fieldRepo.GetByProduct(ctx, result.GetID())
+	if err != nil {
+		return result, err
+	}
+
+	result.CustomFields = fields
+
+	pcRepo := productcategoryrepository.NewProductCategoryRepository()
+	categoryIDs, err := pcRepo.GetByProduct(ctx, result.GetID())
+	if err != nil {
+		return result, err
+	}
+
+	result.CategoryIDs = categoryIDs
+
+	return result, nil
+}
+
+func (prodRepo *productRepository) GetByCatalogue(ctx context.Context, clgCode string) ([]*productmodel.Product, error) {
+	result := make([]*productmodel.Product, 0)
+
+	err := prodRepo.ForEachByCatalogue(ctx, clgCode, func(product *productmodel.Product) error {
+		result = append(result, product)
+		return nil
+	})
+
+	return result, err
+}
+
+func (prodRepo *productRepository) GetByParent(ctx context.Context, parentID int64) ([]*productmodel.Product, error) {
+	result := make([]*productmodel.Product, 0)
+
+	err := prodRepo.forEach(ctx, func(product *productmodel.Product) error {
+		result = append(result, product)
+		return nil
+	}, `SELECT id, clg_code, parent_id, code, descr, details, status, effective_from, effective_to, created_by, created_at, modified_by, modified_at, vers
 		FROM products
-		WHERE clg_code=$1`)
+		WHERE parent_id=$1
+		ORDER BY id ASC`, parentID)
 	if err != nil {
-		return result, fmt.Errorf("Failed preparing read product, error: %v", err)
+		return result, err
+	}
+
+	uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+	pcRepo := productcategoryrepository.NewProductCategoryRepository()
+	for _, product := range result {
+		uoms, err := uomRepo.GetByProduct(ctx, product.GetID())
+		if err != nil {
+			return result, err
+		}
+
+		product.UnitOfMeasures = uoms
+
+		fields, err := fieldRepo.GetByProduct(ctx, product.GetID())
+		if err != nil {
+			return result, err
+		}
+
+		product.CustomFields = fields
+
+		categoryIDs, err := pcRepo.GetByProduct(ctx, product.GetID())
+		if err != nil {
+			return result, err
+		}
+
+		product.CategoryIDs = categoryIDs
+	}
+
+	return result, nil
+}
+
+func (prodRepo *productRepository) ForEachByCatalogue(ctx context.Context, clgCode string, fn func(*productmodel.Product) error) error {
+	return prodRepo.forEach(ctx, fn,
+		`SELECT id, clg_code, parent_id, code, descr, details, status, effective_from, effective_to, created_by, created_at, modified_by, modified_at, vers
+		FROM products
+		WHERE clg_code=$1
+		ORDER BY id ASC`, clgCode)
+}
+
+// ForEachFamilyByCatalogue - Iterates products of catalogue, each parent is followed by its variants
+func (prodRepo *productRepository) ForEachFamilyByCatalogue(ctx context.Context, clgCode string, fn func(*productmodel.Product) error) error {
+	return prodRepo.forEach(ctx, fn,
+		`SELECT id, clg_code, parent_id, code, descr, details, status, effective_from, effective_to, created_by, created_at, modified_by, modified_at, vers
+		FROM products
+		WHERE clg_code=$1
+		ORDER BY CASE WHEN parent_id=0 THEN id ELSE parent_id END, parent_id, id ASC`, clgCode)
+}
+
+// ForEachByCategoryPath - Iterates products assigned to the category of path or any of its descendants
+func (prodRepo *productRepository) ForEachByCategoryPath(ctx context.Context, path string, fn func(*productmodel.Product) error) error {
+	return prodRepo.forEach(ctx, fn,
+		`SELECT id, clg_code, parent_id, code, descr, details, status, effective_from, effective_to, created_by, created_at, modified_by, modified_at, vers
+		FROM products
+		WHERE id IN (
+			SELECT pc.prod_id
+			FROM product_categories pc
+			INNER JOIN categories c ON c.id=pc.category_id
+			WHERE c.path LIKE $1 || '%')
+		ORDER BY id ASC`, path)
+}
+
+// forEach streams products of query to fn, which can not query within a transaction as rows hold its connection
+func (prodRepo *productRepository) forEach(ctx context.Context, fn func(*productmodel.Product) error, query string, args ...interface{}) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("Failed preparing read product, error: %w", err)
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, clgCode)
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
-		return result, fmt.Errorf("Failed reading product, error: %v", err)
+		return fmt.Errorf("Failed reading product, error: %w", err)
 	}
 	defer rows.Close()
 
 	for {
 		if !rows.Next() {
 			if err := rows.Err(); err != nil {
-				return result, fmt.Errorf("Failed retrieve product record, error: %v", err)
+				return fmt.Errorf("Failed retrieve product record, error: %w", err)
 			}
 			break
 		}
@@ -129,99 +319,205 @@ func (prodRepo *productRepository) GetByCatalogue(ctx context.Context, clgCode s
 		if err := rows.Scan(
 			&product.ID,
 			&product.CatalogueCode,
+			&product.ParentID,
 			&product.Code,
 			&product.Description,
 			&product.Details,
 			&product.Status,
+			&product.EffectiveFrom,
+			&product.EffectiveTo,
 			&product.CreatedBy,
 			&product.CreatedAt,
 			&product.ModifiedBy,
 			&product.ModifiedAt,
 			&product.Vers); err != nil {
-			return result, fmt.Errorf("Failed retrieve product record value, error: %v", err)
+			return fmt.Errorf("Failed retrieve product record value, error: %w", err)
 		}
 
-		result = append(result, product)
+		product.Status = lifecycle.FromStored(product.GetStatus())
+
+		if err := fn(product); err != nil {
+			return err
+		}
 	}
 
-	return result, nil
+	return nil
+}
+
+func (prodRepo *productRepository) CountByCatalogue(ctx context.Context, clgCode string) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT COUNT(*)
+		FROM products
+		WHERE clg_code=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing read product, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, clgCode).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading product, error: %w", err)
+	}
+
+	return count, nil
+}
+
+func (prodRepo *productRepository) CountByParent(ctx context.Context, parentID int64) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT COUNT(*)
+		FROM products
+		WHERE parent_id=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing read product, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, parentID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading product, error: %w", err)
+	}
+
+	return count, nil
 }
 
 func (prodRepo *productRepository) Create(ctx context.Context, data *productmodel.Product) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
 		`INSERT INTO products 
-			(clg_code, code, descr, details, status, created_by, created_at, modified_by, modified_at, vers) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 1) RETURNING id`)
+			(clg_code, parent_id, code, descr, details, status, effective_from, effective_to, created_by, created_at, modified_by, modified_at, vers) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, 1) RETURNING id`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing insert product, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing insert product, error: %w", err)
 	}
 	defer stmt.Close()
 
 	var lastInsertID int64
-	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetCode(), data.GetDescription(), data.GetDetails(), data.GetStatus(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt()).Scan(&lastInsertID)
+	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetParentID(), data.GetCode(), data.GetDescription(), data.GetDetails(), data.GetStatus(), data.GetEffectiveFrom(), data.GetEffectiveTo(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt()).Scan(&lastInsertID)
 	if err != nil {
-		return 0, fmt.Errorf("Failed inserting product, error: %v", err)
+		return 0, fmt.Errorf("Failed inserting product, error: %w", err)
 	}
 
 	return lastInsertID, nil
 }
 
 func (prodRepo *productRepository) Update(ctx context.Context, data *productmodel.Product) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
-		`UPDATE products SET code=$1, descr=$2, details=$3, status=$4, modified_by=$5, modified_at=$6, vers=vers+1 
-		WHERE id=$7`)
+		`UPDATE products SET code=$1, descr=$2, details=$3, status=$4, effective_from=$5, effective_to=$6, modified_by=$7, modified_at=$8, vers=vers+1 
+		WHERE id=$9 AND vers=$10`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing update product, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing update product, error: %w", err)
 	}
 	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetDescription(), data.GetDetails(), data.GetStatus(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetID())
+	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetDescription(), data.GetDetails(), data.GetStatus(), data.GetEffectiveFrom(), data.GetEffectiveTo(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetID(), data.GetVers())
 	if err != nil {
-		return 0, fmt.Errorf("Failed updating product, error: %v", err)
+		return 0, fmt.Errorf("Failed updating product, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
-func (prodRepo *productRepository) Delete(ctx context.Context, id int64) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+// Move - Moves product to the catalogue of data, which is the only way catalogue of product changes
+func (prodRepo *productRepository) Move(ctx context.Context, data *productmodel.Product) (int64, error) {
+	return prodRepo.exec(ctx,
+		`UPDATE products SET clg_code=$1, modified_by=$2, modified_at=$3, vers=vers+1
+		WHERE id=$4 AND vers=$5`, data.GetCatalogueCode(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetID(), data.GetVers())
+}
+
+// ActivateEffective - Changes products in from statuses to status once their effective from date is reached
+func (prodRepo *productRepository) ActivateEffective(ctx context.Context, from []string, status string, at time.Time, modifiedBy string) (int64, error) {
+	return prodRepo.exec(ctx,
+		`UPDATE products SET status=$1, modified_by=$2, modified_at=$3, vers=vers+1 
+		WHERE status=ANY($4) AND effective_from<=$3`, status, modifiedBy, at, pq.Array(from))
+}
+
+// RetireExpired - Changes products in from statuses to status once their effective to date has passed
+func (prodRepo *productRepository) RetireExpired(ctx context.Context, from []string, status string, at time.Time, modifiedBy string) (int64, error) {
+	return prodRepo.exec(ctx,
+		`UPDATE products SET status=$1, modified_by=$2, modified_at=$3, vers=vers+1 
+		WHERE status=ANY($4) AND effective_to<$3`, status, modifiedBy, at, pq.Array(from))
+}
+
+func (prodRepo *productRepository) exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing write product, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Failed writing product, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// Delete - Deletes product of version vers, of any version when vers is 0
+func (prodRepo *productRepository) Delete(ctx context.Context, id int64, vers int64) (int64, error) {
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
 	stmt, err := conn.PrepareContext(ctx,
 		`DELETE FROM products 
-		WHERE id=$1`)
+		WHERE id=$1 AND (vers=$2 OR $2=0)`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing delete product, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing delete product, error: %w", err)
 	}
 	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, id)
+	result, err := stmt.ExecContext(ctx, id, vers)
 	if err != nil {
-		return 0, fmt.Errorf("Failed deleting product, error: %v", err)
+		return 0, fmt.Errorf("Failed deleting product, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
 func (prodRepo *productRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
-	conn, err := database.DbConnection.Conn(ctx)
+	// Relations are found through products, so they go first
+	relRepo := productrelationrepository.NewProductRelationRepository()
+	if err := relRepo.DeleteByCatalogue(ctx, clgCode); err != nil {
+		return err
+	}
+
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return fmt.Errorf("Failed connecting to database, error: %v", err)
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -229,13 +525,13 @@ func (prodRepo *productRepository) DeleteByCatalogue(ctx context.Context, clgCod
 		`DELETE FROM products 
 		WHERE clg_code=$1`)
 	if err != nil {
-		return fmt.Errorf("Failed preparing delete product, error: %v", err)
+		return fmt.Errorf("Failed preparing delete product, error: %w", err)
 	}
 	defer stmt.Close()
 
 	_, err = stmt.ExecContext(ctx, clgCode)
 	if err != nil {
-		return fmt.Errorf("Failed deleting product, error: %v", err)
+		return fmt.Errorf("Failed deleting product, error: %w", err)
 	}
 
 	return nil
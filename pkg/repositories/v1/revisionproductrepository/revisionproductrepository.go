@@ -0,0 +1,186 @@
+package revisionproductrepository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IRevisionProductRepository type
+type IRevisionProductRepository interface {
+	GetByID(context.Context, int64, int64) (*productmodel.Product, error)
+	GetByCode(context.Context, int64, string) (*productmodel.Product, error)
+	ForEachByRevision(context.Context, int64, func(*productmodel.Product) error) error
+	Create(context.Context, int64, int64, *productmodel.Product) error
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type revisionProductRepository struct {
+}
+
+// NewRevisionProductRepository - Create revision product repository
+func NewRevisionProductRepository() IRevisionProductRepository {
+	return &revisionProductRepository{}
+}
+
+// GetByID - Returns product as published in revision
+func (revProdRepo *revisionProductRepository) GetByID(ctx context.Context, revID int64, prodID int64) (*productmodel.Product, error) {
+	return revProdRepo.read(ctx,
+		`SELECT product
+		FROM catalogue_revision_products
+		WHERE rev_id=$1 AND prod_id=$2`, revID, prodID)
+}
+
+// GetByCode - Returns product of code as published in revision
+func (revProdRepo *revisionProductRepository) GetByCode(ctx context.Context, revID int64, code string) (*productmodel.Product, error) {
+	return revProdRepo.read(ctx,
+		`SELECT product
+		FROM catalogue_revision_products
+		WHERE rev_id=$1 AND code=$2`, revID, code)
+}
+
+func (revProdRepo *revisionProductRepository) read(ctx context.Context, query string, args ...interface{}) (*productmodel.Product, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Failed preparing read catalogue revision product, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading catalogue revision product, error: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("Failed retrieve catalogue revision product record, error: %w", err)
+		}
+		return nil, nil
+	}
+
+	var data []byte
+	if err := rows.Scan(&data); err != nil {
+		return nil, fmt.Errorf("Failed retrieve catalogue revision product record value, error: %w", err)
+	}
+
+	result := productmodel.NewProduct()
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("Failed decoding catalogue revision product, error: %w", err)
+	}
+
+	return result, nil
+}
+
+// ForEachByRevision - Iterates products of revision, each parent followed by its variants
+func (revProdRepo *revisionProductRepository) ForEachByRevision(ctx context.Context, revID int64, fn func(*productmodel.Product) error) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT product
+		FROM catalogue_revision_products
+		WHERE rev_id=$1
+		ORDER BY seq`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing read catalogue revision product, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, revID)
+	if err != nil {
+		return fmt.Errorf("Failed reading catalogue revision product, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("Failed retrieve catalogue revision product record, error: %w", err)
+			}
+			break
+		}
+
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return fmt.Errorf("Failed retrieve catalogue revision product record value, error: %w", err)
+		}
+
+		prod := productmodel.NewProduct()
+		if err := json.Unmarshal(data, prod); err != nil {
+			return fmt.Errorf("Failed decoding catalogue revision product, error: %w", err)
+		}
+
+		if err := fn(prod); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Create - Inserts product into revision at position seq
+func (revProdRepo *revisionProductRepository) Create(ctx context.Context, revID int64, seq int64, data *productmodel.Product) error {
+	product, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("Failed encoding catalogue revision product, error: %w", err)
+	}
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO catalogue_revision_products
+			(rev_id, seq, prod_id, parent_id, code, product)
+		VALUES ($1, $2, $3, $4, $5, $6)`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing insert catalogue revision product, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, revID, seq, data.GetID(), data.GetParentID(), data.GetCode(), product)
+	if err != nil {
+		return fmt.Errorf("Failed inserting catalogue revision product, error: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByCatalogue - Deletes products of all revisions of catalogue
+func (revProdRepo *revisionProductRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM catalogue_revision_products
+		WHERE rev_id IN (SELECT id FROM catalogue_revisions WHERE clg_code=$1)`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing delete catalogue revision product, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, clgCode)
+	if err != nil {
+		return fmt.Errorf("Failed deleting catalogue revision product, error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package revisionrepository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	revisionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/revision"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+)
+
+// IRevisionRepository type
+type IRevisionRepository interface {
+	GetByRevision(context.Context, string, int64) (*revisionmodel.Revision, error)
+	GetByLabel(context.Context, string, string) (*revisionmodel.Revision, error)
+	GetLatest(context.Context, string) (*revisionmodel.Revision, error)
+	ForEachByCatalogue(context.Context, string, func(*revisionmodel.Revision) error) error
+	GetLastRevision(context.Context, string) (int64, error)
+	Create(context.Context, *revisionmodel.Revision) (int64, error)
+	Lock(context.Context) error
+	DeleteByCatalogue(context.Context, string) error
+}
+
+type revisionRepository struct {
+}
+
+// NewRevisionRepository - Create revision repository
+func NewRevisionRepository() IRevisionRepository {
+	return &revisionRepository{}
+}
+
+// GetByRevision - Returns revision of catalogue along with its published catalogue
+func (revRepo *revisionRepository) GetByRevision(ctx context.Context, clgCode string, revision int64) (*revisionmodel.Revision, error) {
+	return revRepo.read(ctx,
+		`SELECT id, clg_code, revision, label, nbr_products, created_by, created_at, snapshot
+		FROM catalogue_revisions
+		WHERE clg_code=$1 AND revision=$2`, clgCode, revision)
+}
+
+// GetByLabel - Returns revision of catalogue labelled label along with its published catalogue
+func (revRepo *revisionRepository) GetByLabel(ctx context.Context, clgCode string, label string) (*revisionmodel.Revision, error) {
+	return revRepo.read(ctx,
+		`SELECT id, clg_code, revision, label, nbr_products, created_by, created_at, snapshot
+		FROM catalogue_revisions
+		WHERE clg_code=$1 AND label=$2`, clgCode, label)
+}
+
+// GetLatest - Returns latest revision of catalogue, nil when never published
+func (revRepo *revisionRepository) GetLatest(ctx context.Context, clgCode string) (*revisionmodel.Revision, error) {
+	return revRepo.read(ctx,
+		`SELECT id, clg_code, revision, label, nbr_products, created_by, created_at, snapshot
+		FROM catalogue_revisions
+		WHERE clg_code=$1
+		ORDER BY revision DESC
+		LIMIT 1`, clgCode)
+}
+
+func (revRepo *revisionRepository) read(ctx context.Context, query string, args ...interface{}) (*revisionmodel.Revision, error) {
+	result := revisionmodel.NewRevision()
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Failed preparing read catalogue revision, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading catalogue revision, error: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("Failed retrieve catalogue revision record, error: %w", err)
+		}
+		return nil, nil
+	}
+
+	var snapshot []byte
+	if err := rows.Scan(
+		&result.ID,
+		&result.CatalogueCode,
+		&result.Revision,
+		&result.Label,
+		&result.NbrProducts,
+		&result.CreatedBy,
+		&result.CreatedAt,
+		&snapshot); err != nil {
+		return nil, fmt.Errorf("Failed retrieve catalogue revision record value, error: %w", err)
+	}
+
+	// Products are rows of their own, read through revision product repository
+	result.Snapshot = &revisionmodel.Snapshot{}
+	if err := json.Unmarshal(snapshot, &result.Snapshot.Catalogue); err != nil {
+		return nil, fmt.Errorf("Failed decoding catalogue revision snapshot, error: %w", err)
+	}
+
+	return result, nil
+}
+
+// ForEachByCatalogue - Iterates revisions of catalogue from the latest one, snapshots are not loaded
+func (revRepo *revisionRepository) ForEachByCatalogue(ctx context.Context, clgCode string, fn func(*revisionmodel.Revision) error) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT id, clg_code, revision, label, nbr_products, created_by, created_at
+		FROM catalogue_revisions
+		WHERE clg_code=$1
+		ORDER BY revision DESC`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing read catalogue revision, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, clgCode)
+	if err != nil {
+		return fmt.Errorf("Failed reading catalogue revision, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("Failed retrieve catalogue revision record, error: %w", err)
+			}
+			break
+		}
+
+		rev := revisionmodel.NewRevision()
+		if err := rows.Scan(
+			&rev.ID,
+			&rev.CatalogueCode,
+			&rev.Revision,
+			&rev.Label,
+			&rev.NbrProducts,
+			&rev.CreatedBy,
+			&rev.CreatedAt); err != nil {
+			return fmt.Errorf("Failed retrieve catalogue revision record value, error: %w", err)
+		}
+
+		if err := fn(rev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetLastRevision - Returns number of latest revision of catalogue, zero when catalogue was never published
+func (revRepo *revisionRepository) GetLastRevision(ctx context.Context, clgCode string) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT COALESCE(MAX(revision), 0)
+		FROM catalogue_revisions
+		WHERE clg_code=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing read catalogue revision, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var revision int64
+	err = stmt.QueryRowContext(ctx, clgCode).Scan(&revision)
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading catalogue revision, error: %w", err)
+	}
+
+	return revision, nil
+}
+
+// Create - Inserts revision with the catalogue of its snapshot
+func (revRepo *revisionRepository) Create(ctx context.Context, data *revisionmodel.Revision) (int64, error) {
+	snapshot, err := json.Marshal(data.GetSnapshot().Catalogue)
+	if err != nil {
+		return 0, fmt.Errorf("Failed encoding catalogue revision snapshot, error: %w", err)
+	}
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO catalogue_revisions
+			(clg_code, revision, label, nbr_products, snapshot, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert catalogue revision, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var lastInsertID int64
+	err = stmt.QueryRowContext(ctx, data.GetCatalogueCode(), data.GetRevision(), data.GetLabel(), data.GetNbrProducts(), snapshot, data.GetCreatedBy(), data.GetCreatedAt()).Scan(&lastInsertID)
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting catalogue revision, error: %w", err)
+	}
+
+	return lastInsertID, nil
+}
+
+// Lock - Holds off other publishing until transaction ends, to run before any other statement
+func (revRepo *revisionRepository) Lock(ctx context.Context) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, `LOCK TABLE catalogue_revisions IN SHARE ROW EXCLUSIVE MODE`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing lock catalogue revision, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed locking catalogue revision, error: %w", err)
+	}
+
+	return nil
+}
+
+func (revRepo *revisionRepository) DeleteByCatalogue(ctx context.Context, clgCode string) error {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM catalogue_revisions
+		WHERE clg_code=$1`)
+	if err != nil {
+		return fmt.Errorf("Failed preparing delete catalogue revision, error: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, clgCode)
+	if err != nil {
+		return fmt.Errorf("Failed deleting catalogue revision, error: %w", err)
+	}
+
+	return nil
+}
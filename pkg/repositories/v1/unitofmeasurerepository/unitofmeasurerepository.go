@@ -12,6 +12,7 @@ import (
 type IUnitOfMeasureRepository interface {
 	GetByID(context.Context, int64) (*unitofmeasuremodel.UnitOfMeasure, error)
 	GetByProduct(context.Context, int64) ([]*unitofmeasuremodel.UnitOfMeasure, error)
+	CountByCode(context.Context, string) (int64, error)
 	Create(context.Context, *unitofmeasuremodel.UnitOfMeasure) (int64, error)
 	Update(context.Context, *unitofmeasuremodel.UnitOfMeasure) (int64, error)
 	Delete(context.Context, int64) (int64, error)
@@ -29,9 +30,9 @@ func NewUnitOfMeasureRepository() IUnitOfMeasureRepository {
 func (uomRepo *unitOfMeasureRepository) GetByID(ctx context.Context, id int64) (*unitofmeasuremodel.UnitOfMeasure, error) {
 	result := unitofmeasuremodel.NewUnitOfMeasure()
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -40,19 +41,19 @@ func (uomRepo *unitOfMeasureRepository) GetByID(ctx context.Context, id int64) (
 		FROM product_uoms 
 		WHERE id=$1`)
 	if err != nil {
-		return nil, fmt.Errorf("Failed preparing read unit of measure, error: %v", err)
+		return nil, fmt.Errorf("Failed preparing read unit of measure, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("Failed reading unit of measure, error: %v", err)
+		return nil, fmt.Errorf("Failed reading unit of measure, error: %w", err)
 	}
 	defer rows.Close()
 
 	if !rows.Next() {
 		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("Failed retrieve unit of measure record, error: %v", err)
+			return nil, fmt.Errorf("Failed retrieve unit of measure record, error: %w", err)
 		}
 		return nil, nil
 	}
@@ -64,7 +65,7 @@ func (uomRepo *unitOfMeasureRepository) GetByID(ctx context.Context, id int64) (
 		&result.Description,
 		&result.Ratio,
 		&result.Vers); err != nil {
-		return nil, fmt.Errorf("Failed retrieve unit of measure record value, error: %v", err)
+		return nil, fmt.Errorf("Failed retrieve unit of measure record value, error: %w", err)
 	}
 
 	return result, nil
@@ -73,9 +74,9 @@ func (uomRepo *unitOfMeasureRepository) GetByID(ctx context.Context, id int64) (
 func (uomRepo *unitOfMeasureRepository) GetByProduct(ctx context.Context, prodID int64) ([]*unitofmeasuremodel.UnitOfMeasure, error) {
 	result := make([]*unitofmeasuremodel.UnitOfMeasure, 0)
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return result, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -85,20 +86,20 @@ func (uomRepo *unitOfMeasureRepository) GetByProduct(ctx context.Context, prodID
 		WHERE prod_id=$1
 		ORDER BY ratio ASC`)
 	if err != nil {
-		return result, fmt.Errorf("Failed preparing read unit of measure, error: %v", err)
+		return result, fmt.Errorf("Failed preparing read unit of measure, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, prodID)
 	if err != nil {
-		return result, fmt.Errorf("Failed reading unit of measure, error: %v", err)
+		return result, fmt.Errorf("Failed reading unit of measure, error: %w", err)
 	}
 	defer rows.Close()
 
 	for {
 		if !rows.Next() {
 			if err := rows.Err(); err != nil {
-				return result, fmt.Errorf("Failed retrieve unit of measure record, error: %v", err)
+				return result, fmt.Errorf("Failed retrieve unit of measure record, error: %w", err)
 			}
 			break
 		}
@@ -111,7 +112,7 @@ func (uomRepo *unitOfMeasureRepository) GetByProduct(ctx context.Context, prodID
 			&uom.Description,
 			&uom.Ratio,
 			&uom.Vers); err != nil {
-			return result, fmt.Errorf("Failed retrieve unit of measure record value, error: %v", err)
+			return result, fmt.Errorf("Failed retrieve unit of measure record value, error: %w", err)
 		}
 
 		result = append(result, uom)
@@ -120,10 +121,35 @@ func (uomRepo *unitOfMeasureRepository) GetByProduct(ctx context.Context, prodID
 	return result, nil
 }
 
+func (uomRepo *unitOfMeasureRepository) CountByCode(ctx context.Context, code string) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`SELECT COUNT(*)
+		FROM product_uoms
+		WHERE code=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing read unit of measure, error: %w", err)
+	}
+	defer stmt.Close()
+
+	var count int64
+	err = stmt.QueryRowContext(ctx, code).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Failed reading unit of measure, error: %w", err)
+	}
+
+	return count, nil
+}
+
 func (uomRepo *unitOfMeasureRepository) Create(ctx context.Context, data *unitofmeasuremodel.UnitOfMeasure) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -132,23 +158,23 @@ func (uomRepo *unitOfMeasureRepository) Create(ctx context.Context, data *unitof
 			(prod_id, code, descr, ratio, vers) 
 		VALUES ($1, $2, $3, $4, 1) RETURNING id`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing insert unit or measure, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing insert unit or measure, error: %w", err)
 	}
 	defer stmt.Close()
 
 	var lastInsertID int64
 	err = stmt.QueryRowContext(ctx, data.GetProdID(), data.GetCode(), data.GetDescription(), data.GetRatio()).Scan(&lastInsertID)
 	if err != nil {
-		return 0, fmt.Errorf("Failed inserting unit or measure, error: %v", err)
+		return 0, fmt.Errorf("Failed inserting unit or measure, error: %w", err)
 	}
 
 	return lastInsertID, nil
 }
 
 func (uomRepo *unitOfMeasureRepository) Update(ctx context.Context, data *unitofmeasuremodel.UnitOfMeasure) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -156,22 +182,22 @@ func (uomRepo *unitOfMeasureRepository) Update(ctx context.Context, data *unitof
 		`UPDATE product_uoms SET code=$1, descr=$2, ratio=$3, vers=vers+1 
 		WHERE id=$4`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing update unit or measure, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing update unit or measure, error: %w", err)
 	}
 	defer stmt.Close()
 
 	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetDescription(), data.GetRatio(), data.GetID())
 	if err != nil {
-		return 0, fmt.Errorf("Failed updating unit or measure, error: %v", err)
+		return 0, fmt.Errorf("Failed updating unit or measure, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
 func (uomRepo *unitOfMeasureRepository) Delete(ctx context.Context, id int64) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -179,22 +205,22 @@ func (uomRepo *unitOfMeasureRepository) Delete(ctx context.Context, id int64) (i
 		`DELETE FROM product_uoms 
 		WHERE id=$1`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing delete unit or measure, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing delete unit or measure, error: %w", err)
 	}
 	defer stmt.Close()
 
 	result, err := stmt.ExecContext(ctx, id)
 	if err != nil {
-		return 0, fmt.Errorf("Failed deleting unit or measure, error: %v", err)
+		return 0, fmt.Errorf("Failed deleting unit or measure, error: %w", err)
 	}
 
 	return result.RowsAffected()
 }
 
 func (uomRepo *unitOfMeasureRepository) DeleteByProduct(ctx context.Context, prodID int64) error {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return fmt.Errorf("Failed connecting to database, error: %v", err)
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -202,13 +228,13 @@ func (uomRepo *unitOfMeasureRepository) DeleteByProduct(ctx context.Context, pro
 		`DELETE FROM product_uoms 
 		WHERE prod_id=$1`)
 	if err != nil {
-		return fmt.Errorf("Failed preparing delete unit or measure, error: %v", err)
+		return fmt.Errorf("Failed preparing delete unit or measure, error: %w", err)
 	}
 	defer stmt.Close()
 
 	_, err = stmt.ExecContext(ctx, prodID)
 	if err != nil {
-		return fmt.Errorf("Failed deleting unit or measure, error: %v", err)
+		return fmt.Errorf("Failed deleting unit or measure, error: %w", err)
 	}
 
 	return nil
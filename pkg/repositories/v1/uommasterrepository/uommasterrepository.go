@@ -0,0 +1,188 @@
+package uommasterrepository
+
+import (
+	"context"
+	"fmt"
+
+	uommastermodel "github.com/bungysheep/catalogue-api/pkg/models/v1/uommaster"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/lib/pq"
+)
+
+// IUomMasterRepository type
+type IUomMasterRepository interface {
+	GetByID(context.Context, string) (*uommastermodel.UomMaster, error)
+	GetAll(context.Context) ([]*uommastermodel.UomMaster, error)
+	GetByCodes(context.Context, []string) (map[string]*uommastermodel.UomMaster, error)
+	Create(context.Context, *uommastermodel.UomMaster) (int64, error)
+	Update(context.Context, *uommastermodel.UomMaster) (int64, error)
+	Delete(context.Context, string) (int64, error)
+}
+
+type uomMasterRepository struct {
+}
+
+// NewUomMasterRepository - Create unit of measure master repository
+func NewUomMasterRepository() IUomMasterRepository {
+	return &uomMasterRepository{}
+}
+
+func (masterRepo *uomMasterRepository) GetByID(ctx context.Context, code string) (*uommastermodel.UomMaster, error) {
+	result, err := masterRepo.read(ctx,
+		`SELECT code, descr, dimension, factor, created_by, created_at, modified_by, modified_at, vers
+		FROM uom_masters
+		WHERE code=$1`, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result[0], nil
+}
+
+func (masterRepo *uomMasterRepository) GetAll(ctx context.Context) ([]*uommastermodel.UomMaster, error) {
+	return masterRepo.read(ctx,
+		`SELECT code, descr, dimension, factor, created_by, created_at, modified_by, modified_at, vers
+		FROM uom_masters
+		ORDER BY dimension, code ASC`)
+}
+
+func (masterRepo *uomMasterRepository) GetByCodes(ctx context.Context, codes []string) (map[string]*uommastermodel.UomMaster, error) {
+	result := make(map[string]*uommastermodel.UomMaster)
+
+	masters, err := masterRepo.read(ctx,
+		`SELECT code, descr, dimension, factor, created_by, created_at, modified_by, modified_at, vers
+		FROM uom_masters
+		WHERE code=ANY($1)`, pq.Array(codes))
+	if err != nil {
+		return result, err
+	}
+
+	for _, master := range masters {
+		result[master.GetCode()] = master
+	}
+
+	return result, nil
+}
+
+func (masterRepo *uomMasterRepository) read(ctx context.Context, query string, args ...interface{}) ([]*uommastermodel.UomMaster, error) {
+	result := make([]*uommastermodel.UomMaster, 0)
+
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return result, fmt.Errorf("Failed preparing read unit of measure master, error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return result, fmt.Errorf("Failed reading unit of measure master, error: %w", err)
+	}
+	defer rows.Close()
+
+	for {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return result, fmt.Errorf("Failed retrieve unit of measure master record, error: %w", err)
+			}
+			break
+		}
+
+		master := uommastermodel.NewUomMaster()
+		if err := rows.Scan(
+			&master.Code,
+			&master.Description,
+			&master.Dimension,
+			&master.Factor,
+			&master.CreatedBy,
+			&master.CreatedAt,
+			&master.ModifiedBy,
+			&master.ModifiedAt,
+			&master.Vers); err != nil {
+			return result, fmt.Errorf("Failed retrieve unit of measure master record value, error: %w", err)
+		}
+
+		result = append(result, master)
+	}
+
+	return result, nil
+}
+
+func (masterRepo *uomMasterRepository) Create(ctx context.Context, data *uommastermodel.UomMaster) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`INSERT INTO uom_masters 
+			(code, descr, dimension, factor, created_by, created_at, modified_by, modified_at, vers) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1)`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing insert unit of measure master, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetCode(), data.GetDescription(), data.GetDimension(), data.GetFactor(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt())
+	if err != nil {
+		return 0, fmt.Errorf("Failed inserting unit of measure master, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (masterRepo *uomMasterRepository) Update(ctx context.Context, data *uommastermodel.UomMaster) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`UPDATE uom_masters SET descr=$1, dimension=$2, factor=$3, modified_by=$4, modified_at=$5, vers=vers+1 
+		WHERE code=$6 AND vers=$7`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing update unit of measure master, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, data.GetDescription(), data.GetDimension(), data.GetFactor(), data.GetModifiedBy(), data.GetModifiedAt(), data.GetCode(), data.GetVers())
+	if err != nil {
+		return 0, fmt.Errorf("Failed updating unit of measure master, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (masterRepo *uomMasterRepository) Delete(ctx context.Context, code string) (int64, error) {
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
+	}
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(ctx,
+		`DELETE FROM uom_masters 
+		WHERE code=$1`)
+	if err != nil {
+		return 0, fmt.Errorf("Failed preparing delete unit of measure master, error: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, code)
+	if err != nil {
+		return 0, fmt.Errorf("Failed deleting unit of measure master, error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -11,6 +11,7 @@ import (
 // IUserRepository type
 type IUserRepository interface {
 	GetAll(context.Context) ([]*usermodel.User, error)
+	ForEachAll(context.Context, func(*usermodel.User) error) error
 	GetByUsername(context.Context, string) (*usermodel.User, error)
 	Create(context.Context, *usermodel.User) (int64, error)
 }
@@ -26,9 +27,18 @@ func NewUserRepository() IUserRepository {
 func (usrRepo *userRepository) GetAll(ctx context.Context) ([]*usermodel.User, error) {
 	result := make([]*usermodel.User, 0)
 
-	conn, err := database.DbConnection.Conn(ctx)
+	err := usrRepo.ForEachAll(ctx, func(user *usermodel.User) error {
+		result = append(result, user)
+		return nil
+	})
+
+	return result, err
+}
+
+func (usrRepo *userRepository) ForEachAll(ctx context.Context, fn func(*usermodel.User) error) error {
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return result, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -36,20 +46,20 @@ func (usrRepo *userRepository) GetAll(ctx context.Context) ([]*usermodel.User, e
 		`SELECT username, name, email, password, status, created_by, created_at, modified_by, modified_at, vers
 		FROM users`)
 	if err != nil {
-		return result, fmt.Errorf("Failed preparing read user, error: %v", err)
+		return fmt.Errorf("Failed preparing read user, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
-		return result, fmt.Errorf("Failed reading user, error: %v", err)
+		return fmt.Errorf("Failed reading user, error: %w", err)
 	}
 	defer rows.Close()
 
 	for {
 		if !rows.Next() {
 			if err := rows.Err(); err != nil {
-				return result, fmt.Errorf("Failed retrieve catalogue record, error: %v", err)
+				return fmt.Errorf("Failed retrieve catalogue record, error: %w", err)
 			}
 			break
 		}
@@ -66,21 +76,23 @@ func (usrRepo *userRepository) GetAll(ctx context.Context) ([]*usermodel.User, e
 			&user.ModifiedBy,
 			&user.ModifiedAt,
 			&user.Vers); err != nil {
-			return result, fmt.Errorf("Failed retrieve user record value, error: %v", err)
+			return fmt.Errorf("Failed retrieve user record value, error: %w", err)
 		}
 
-		result = append(result, user)
+		if err := fn(user); err != nil {
+			return err
+		}
 	}
 
-	return result, nil
+	return nil
 }
 
 func (usrRepo *userRepository) GetByUsername(ctx context.Context, username string) (*usermodel.User, error) {
 	result := usermodel.NewUser()
 
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return nil, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -89,19 +101,19 @@ func (usrRepo *userRepository) GetByUsername(ctx context.Context, username strin
 		FROM users
 		WHERE username=$1`)
 	if err != nil {
-		return nil, fmt.Errorf("Failed preparing read user, error: %v", err)
+		return nil, fmt.Errorf("Failed preparing read user, error: %w", err)
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("Failed reading user, error: %v", err)
+		return nil, fmt.Errorf("Failed reading user, error: %w", err)
 	}
 	defer rows.Close()
 
 	if !rows.Next() {
 		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("Failed retrieve user record, error: %v", err)
+			return nil, fmt.Errorf("Failed retrieve user record, error: %w", err)
 		}
 		return nil, nil
 	}
@@ -117,16 +129,16 @@ func (usrRepo *userRepository) GetByUsername(ctx context.Context, username strin
 		&result.ModifiedBy,
 		&result.ModifiedAt,
 		&result.Vers); err != nil {
-		return nil, fmt.Errorf("Failed retrieve user record value, error: %v", err)
+		return nil, fmt.Errorf("Failed retrieve user record value, error: %w", err)
 	}
 
 	return result, nil
 }
 
 func (usrRepo *userRepository) Create(ctx context.Context, data *usermodel.User) (int64, error) {
-	conn, err := database.DbConnection.Conn(ctx)
+	conn, err := database.Conn(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("Failed connecting to database, error: %v", err)
+		return 0, fmt.Errorf("Failed connecting to database, error: %w", err)
 	}
 	defer conn.Close()
 
@@ -134,13 +146,13 @@ func (usrRepo *userRepository) Create(ctx context.Context, data *usermodel.User)
 		`INSERT INTO users (username, name, email, password, status, created_by, created_at, modified_by, modified_at, vers) 
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 1)`)
 	if err != nil {
-		return 0, fmt.Errorf("Failed preparing create user, error: %v", err)
+		return 0, fmt.Errorf("Failed preparing create user, error: %w", err)
 	}
 	defer stmt.Close()
 
 	result, err := stmt.ExecContext(ctx, data.GetUsername(), data.GetName(), data.GetEmail(), data.GetPassword(), data.GetStatus(), data.GetCreatedBy(), data.GetCreatedAt(), data.GetModifiedBy(), data.GetModifiedAt())
 	if err != nil {
-		return 0, fmt.Errorf("Failed inserting user, error: %v", err)
+		return 0, fmt.Errorf("Failed inserting user, error: %w", err)
 	}
 
 	return result.RowsAffected()
@@ -0,0 +1,217 @@
+package backfillservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/jobstatus"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	backfilljobmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/backfilljob"
+	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	customfielddefinitionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfielddefinition"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/productcustomfield"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/backfilljobrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfielddefinitionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcategoryrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcustomfieldrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+)
+
+var (
+	// ErrBackfillJobNotCreated - Backfill job was not created
+	ErrBackfillJobNotCreated = errors.New("Backfill Job was not created.")
+)
+
+// IBackfillService type
+type IBackfillService interface {
+	Submit(context.Context, *customfielddefinitionmodel.CustomFieldDefinition, string) (*backfilljobmodel.BackfillJob, error)
+	Run(context.Context, *backfilljobmodel.BackfillJob)
+}
+
+type backfillService struct {
+}
+
+// NewBackfillService - Create backfill service
+func NewBackfillService() IBackfillService {
+	return &backfillService{}
+}
+
+// Submit - Creates backfill job of custom field definition and runs it in background
+func (backfillSvc *backfillService) Submit(ctx context.Context, fieldDef *customfielddefinitionmodel.CustomFieldDefinition, username string) (*backfilljobmodel.BackfillJob, error) {
+	newJob := backfilljobmodel.NewBackfillJob()
+	newJob.CatalogueCode = fieldDef.GetCatalogueCode()
+	newJob.FieldID = fieldDef.GetID()
+	newJob.Status = jobstatus.Pending.String()
+	newJob.CreatedBy = username
+	newJob.CreatedAt = time.Now()
+	newJob.ModifiedAt = time.Now()
+
+	jobRepo := backfilljobrepository.NewBackfillJobRepository()
+	lastID, err := jobRepo.Create(ctx, newJob)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastID == 0 {
+		return nil, ErrBackfillJobNotCreated
+	}
+
+	newJob.ID = lastID
+
+	// Request context is cancelled as soon as response is written
+	go backfillSvc.Run(context.Background(), newJob)
+
+	return newJob, nil
+}
+
+// Run - Applies default value of custom field definition to products not having the value specified
+func (backfillSvc *backfillService) Run(ctx context.Context, job *backfilljobmodel.BackfillJob) {
+	log.Printf("Running Backfill Job '%v'.\n", job.GetID())
+
+	job.Status = jobstatus.Running.String()
+	if err := backfillSvc.saveProgress(ctx, job); err != nil {
+		log.Printf("Failed updating Backfill Job '%v', error: %v.\n", job.GetID(), err)
+		return
+	}
+
+	fieldDefRepo := customfielddefinitionrepository.NewCustomFieldDefinitionRepository()
+	fieldDef, err := fieldDefRepo.GetByID(ctx, job.GetFieldID())
+	if err != nil || fieldDef == nil || fieldDef.GetCatalogueCode() != job.GetCatalogueCode() {
+		backfillSvc.fail(ctx, job, "Invalid custom field definition.")
+		return
+	}
+
+	if !fieldDef.HasDefaultValue() {
+		backfillSvc.fail(ctx, job, "Custom Field has no default value.")
+		return
+	}
+
+	clg, err := cataloguerepository.NewCatalogueRepository().GetByID(ctx, job.GetCatalogueCode())
+	if err != nil || clg == nil {
+		backfillSvc.fail(ctx, job, "Invalid catalogue code.")
+		return
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	job.TotalRows, err = prodRepo.CountByCatalogue(ctx, job.GetCatalogueCode())
+	if err != nil {
+		backfillSvc.fail(ctx, job, err.Error())
+		return
+	}
+
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+	err = prodRepo.ForEachByCatalogue(ctx, job.GetCatalogueCode(), func(prod *productmodel.Product) error {
+		// Variants inherit the value of their parent
+		updated := false
+		if !prod.IsVariant() {
+			applicable, err := backfillSvc.isApplicable(ctx, clg, prod, fieldDef)
+			if err != nil {
+				return err
+			}
+
+			if applicable {
+				fields, err := fieldRepo.GetByProduct(ctx, prod.GetID())
+				if err != nil {
+					return err
+				}
+				prod.CustomFields = fields
+
+				updated, err = backfillSvc.applyDefault(ctx, prod, fieldDef)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		job.ProcessedRows++
+		if updated {
+			job.UpdatedRows++
+		}
+
+		if job.GetProcessedRows()%configs.BACKFILLPROGRESSINTERVAL == 0 {
+			return backfillSvc.saveProgress(ctx, job)
+		}
+
+		return nil
+	})
+	if err != nil {
+		backfillSvc.fail(ctx, job, err.Error())
+		return
+	}
+
+	job.Status = jobstatus.Completed.String()
+	if err := backfillSvc.saveProgress(ctx, job); err != nil {
+		log.Printf("Failed updating Backfill Job '%v', error: %v.\n", job.GetID(), err)
+	}
+}
+
+// isApplicable - Whether definition applies to product, definitions attached to a category only apply to its products
+func (backfillSvc *backfillService) isApplicable(ctx context.Context, clg *cataloguemodel.Catalogue, prod *productmodel.Product, fieldDef *customfielddefinitionmodel.CustomFieldDefinition) (bool, error) {
+	if fieldDef.GetCategoryID() == 0 {
+		return true, nil
+	}
+
+	categoryIDs, err := productcategoryrepository.NewProductCategoryRepository().GetByProduct(ctx, prod.GetID())
+	if err != nil {
+		return false, err
+	}
+
+	return clg.IsFieldDefinitionApplicable(fieldDef, categoryIDs), nil
+}
+
+// applyDefault - Stores default value when product has no value specified, returning whether product was changed
+func (backfillSvc *backfillService) applyDefault(ctx context.Context, prod *productmodel.Product, fieldDef *customfielddefinitionmodel.CustomFieldDefinition) (bool, error) {
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+
+	field := prod.GetCustomFieldByFieldID(fieldDef.GetID())
+	if field == nil {
+		defaultField, err := productcustomfield.NewDefaultProductCustomField(fieldDef)
+		if err != nil {
+			return false, err
+		}
+		defaultField.ProdID = prod.GetID()
+
+		if _, err := fieldRepo.Create(ctx, defaultField); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	if field.IsSpecified(fieldDef) {
+		return false, nil
+	}
+
+	if err := field.SetValueString(fieldDef, fieldDef.GetDefaultValue()); err != nil {
+		return false, err
+	}
+
+	if _, err := fieldRepo.Update(ctx, field); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (backfillSvc *backfillService) fail(ctx context.Context, job *backfilljobmodel.BackfillJob, message string) {
+	log.Printf("Backfill Job '%v' has failed, error: %v.\n", job.GetID(), message)
+
+	job.Status = jobstatus.Failed.String()
+	job.Message = message
+	if err := backfillSvc.saveProgress(ctx, job); err != nil {
+		log.Printf("Failed updating Backfill Job '%v', error: %v.\n", job.GetID(), err)
+	}
+}
+
+func (backfillSvc *backfillService) saveProgress(ctx context.Context, job *backfilljobmodel.BackfillJob) error {
+	job.ModifiedAt = time.Now()
+
+	jobRepo := backfilljobrepository.NewBackfillJobRepository()
+	_, err := jobRepo.Update(ctx, job)
+
+	return err
+}
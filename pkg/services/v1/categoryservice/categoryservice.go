@@ -0,0 +1,89 @@
+package categoryservice
+
+import (
+	"context"
+	"errors"
+
+	categorymodel "github.com/bungysheep/catalogue-api/pkg/models/v1/category"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/categoryrepository"
+)
+
+var (
+	// ErrCategoryNotCreated - Category was not created
+	ErrCategoryNotCreated = errors.New("Category was not created.")
+
+	// ErrCategoryNotMoved - Category was not moved, either missing or modified by another user
+	ErrCategoryNotMoved = errors.New("Category was not moved.")
+)
+
+// ICategoryService type
+type ICategoryService interface {
+	GetParent(context.Context, *categorymodel.Category) (*categorymodel.Category, error)
+	Create(context.Context, *categorymodel.Category, *categorymodel.Category) (*categorymodel.Category, error)
+	Move(context.Context, *categorymodel.Category, *categorymodel.Category) (*categorymodel.Category, error)
+}
+
+type categoryService struct {
+}
+
+// NewCategoryService - Create category service
+func NewCategoryService() ICategoryService {
+	return &categoryService{}
+}
+
+// GetParent - Returns parent of category, nil for a root category or a missing parent
+func (catSvc *categoryService) GetParent(ctx context.Context, cat *categorymodel.Category) (*categorymodel.Category, error) {
+	if cat.IsRoot() {
+		return nil, nil
+	}
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	return catRepo.GetByID(ctx, cat.GetParentID())
+}
+
+// Create - Creates category beneath parent, parent is nil for a root category
+func (catSvc *categoryService) Create(ctx context.Context, newCat *categorymodel.Category, parent *categorymodel.Category) (*categorymodel.Category, error) {
+	catRepo := categoryrepository.NewCategoryRepository()
+	lastID, err := catRepo.Create(ctx, newCat)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastID == 0 {
+		return nil, ErrCategoryNotCreated
+	}
+
+	nbrRows, err := catRepo.UpdatePath(ctx, lastID, categorymodel.NewPath(parent, lastID))
+	if err != nil {
+		return nil, err
+	}
+
+	if nbrRows == 0 {
+		return nil, ErrCategoryNotCreated
+	}
+
+	return catRepo.GetByID(ctx, lastID)
+}
+
+// Move - Re-parents category beneath parent along with its descendants, parent is nil to move category to the root
+func (catSvc *categoryService) Move(ctx context.Context, cat *categorymodel.Category, parent *categorymodel.Category) (*categorymodel.Category, error) {
+	oldPath := cat.GetPath()
+
+	cat.ParentID = 0
+	if parent != nil {
+		cat.ParentID = parent.GetID()
+	}
+	cat.Path = categorymodel.NewPath(parent, cat.GetID())
+
+	catRepo := categoryrepository.NewCategoryRepository()
+	nbrRows, err := catRepo.Move(ctx, cat, oldPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if nbrRows == 0 {
+		return nil, ErrCategoryNotMoved
+	}
+
+	return catRepo.GetByID(ctx, cat.GetID())
+}
@@ -0,0 +1,215 @@
+package cloneservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfielddefinitionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfieldgrouprepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/customfieldoptionrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/categoryservice"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/productservice"
+)
+
+var (
+	// ErrCatalogueNotCreated - Catalogue was not created
+	ErrCatalogueNotCreated = errors.New("Catalogue was not created.")
+
+	// ErrFieldGroupNotCreated - Custom field group was not created
+	ErrFieldGroupNotCreated = errors.New("Custom Field Group was not created.")
+
+	// ErrFieldDefinitionNotCreated - Custom field definition was not created
+	ErrFieldDefinitionNotCreated = errors.New("Custom Field Definition was not created.")
+
+	// ErrFieldOptionNotCreated - Custom field option was not created
+	ErrFieldOptionNotCreated = errors.New("Custom Field Option was not created.")
+)
+
+// ICloneService type
+type ICloneService interface {
+	Clone(context.Context, *cataloguemodel.Catalogue, *cataloguemodel.Catalogue, bool, string) (*cataloguemodel.Catalogue, error)
+}
+
+type cloneService struct {
+}
+
+// NewCloneService - Create clone service
+func NewCloneService() ICloneService {
+	return &cloneService{}
+}
+
+// Clone - Creates new catalogue cloned from catalogue in a single transaction, along with its products when includeProducts
+func (cloneSvc *cloneService) Clone(ctx context.Context, clg *cataloguemodel.Catalogue, newClg *cataloguemodel.Catalogue, includeProducts bool, username string) (*cataloguemodel.Catalogue, error) {
+	var result *cataloguemodel.Catalogue
+	err := database.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = cloneSvc.clone(ctx, clg, newClg, includeProducts, username)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cloneSvc *cloneService) clone(ctx context.Context, clg *cataloguemodel.Catalogue, newClg *cataloguemodel.Catalogue, includeProducts bool, username string) (*cataloguemodel.Catalogue, error) {
+	now := time.Now()
+	newClg.CreatedBy = username
+	newClg.CreatedAt = now
+	newClg.ModifiedBy = username
+	newClg.ModifiedAt = now
+	newClg.Vers = 1
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	nbrRows, err := clgRepo.Create(ctx, newClg)
+	if err != nil {
+		return nil, err
+	}
+
+	if nbrRows == 0 {
+		return nil, ErrCatalogueNotCreated
+	}
+
+	grpRepo := customfieldgrouprepository.NewCustomFieldGroupRepository()
+	for _, group := range newClg.GetAllFieldGroups() {
+		lastGroupID, err := grpRepo.Create(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastGroupID == 0 {
+			return nil, ErrFieldGroupNotCreated
+		}
+	}
+
+	// Categories are ordered by path, so parents are created before their children
+	categoryIDs := make(map[int64]int64)
+	categorySvc := categoryservice.NewCategoryService()
+	for _, cat := range newClg.GetAllCategories() {
+		oldID := cat.GetID()
+		cat.ParentID = categoryIDs[cat.GetParentID()]
+		cat.CreatedBy = username
+		cat.CreatedAt = now
+		cat.ModifiedBy = username
+		cat.ModifiedAt = now
+
+		parentCat, err := categorySvc.GetParent(ctx, cat)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := categorySvc.Create(ctx, cat, parentCat)
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			return nil, categoryservice.ErrCategoryNotCreated
+		}
+
+		categoryIDs[oldID] = result.GetID()
+	}
+
+	fieldIDs := make(map[int64]int64)
+	fieldDefRepo := customfielddefinitionrepository.NewCustomFieldDefinitionRepository()
+	optRepo := customfieldoptionrepository.NewCustomFieldOptionRepository()
+	for _, fieldDef := range newClg.GetAllCustomFieldDefinitions() {
+		oldID := fieldDef.GetID()
+		fieldDef.CategoryID = categoryIDs[fieldDef.GetCategoryID()]
+		fieldDef.CreatedBy = username
+		fieldDef.CreatedAt = now
+		fieldDef.ModifiedBy = username
+		fieldDef.ModifiedAt = now
+		fieldDef.Vers = 1
+
+		lastFieldDefID, err := fieldDefRepo.Create(ctx, fieldDef)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastFieldDefID == 0 {
+			return nil, ErrFieldDefinitionNotCreated
+		}
+
+		fieldIDs[oldID] = lastFieldDefID
+
+		for _, option := range fieldDef.GetAllOptions() {
+			option.FieldID = lastFieldDefID
+
+			lastOptionID, err := optRepo.Create(ctx, option)
+			if err != nil {
+				return nil, err
+			}
+
+			if lastOptionID == 0 {
+				return nil, ErrFieldOptionNotCreated
+			}
+		}
+	}
+
+	result, err := clgRepo.GetByID(ctx, newClg.GetCode())
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, ErrCatalogueNotCreated
+	}
+
+	if includeProducts {
+		if err := cloneSvc.copyProducts(ctx, clg, result, fieldIDs, categoryIDs, username); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// copyProducts - Copies products of catalogue as stored to new catalogue, parents are copied before their variants
+func (cloneSvc *cloneService) copyProducts(ctx context.Context, clg *cataloguemodel.Catalogue, newClg *cataloguemodel.Catalogue, fieldIDs map[int64]int64, categoryIDs map[int64]int64, username string) error {
+	prodIDs := make([]int64, 0)
+	prodRepo := productrepository.NewProductRepository()
+	err := prodRepo.ForEachFamilyByCatalogue(ctx, clg.GetCode(), func(product *productmodel.Product) error {
+		prodIDs = append(prodIDs, product.GetID())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	newProdIDs := make(map[int64]int64)
+	prodSvc := productservice.NewProductService()
+	for _, prodID := range prodIDs {
+		// Variant is read without the values it inherits, as they are copied along with its parent
+		product, err := prodRepo.GetByID(ctx, prodID)
+		if err != nil {
+			return err
+		}
+
+		if product == nil {
+			continue
+		}
+
+		newProd := product.NewCopy(clg, newClg, fieldIDs, categoryIDs).Product
+		newProd.ParentID = newProdIDs[product.GetParentID()]
+		newProd.CreatedBy = username
+		newProd.ModifiedBy = username
+		newProd.Vers = 1
+
+		result, err := prodSvc.Create(ctx, newProd)
+		if err != nil {
+			return err
+		}
+
+		newProdIDs[prodID] = result.GetID()
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package customfieldservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	customfielddefinitionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/customfielddefinition"
+	productcustomfieldmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/productcustomfield"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcustomfieldrepository"
+)
+
+// errStopIteration - Stops iterating values once enough blockers have been found
+var errStopIteration = errors.New("stop iteration")
+
+// TypeChangeBlocker type
+type TypeChangeBlocker struct {
+	Code    string `json:"code"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
+// ICustomFieldService type
+type ICustomFieldService interface {
+	CheckTypeChange(context.Context, *customfielddefinitionmodel.CustomFieldDefinition, *customfielddefinitionmodel.CustomFieldDefinition) ([]*TypeChangeBlocker, error)
+	MigrateType(context.Context, *customfielddefinitionmodel.CustomFieldDefinition, *customfielddefinitionmodel.CustomFieldDefinition) error
+}
+
+type customFieldService struct {
+}
+
+// NewCustomFieldService - Create custom field service
+func NewCustomFieldService() ICustomFieldService {
+	return &customFieldService{}
+}
+
+// CheckTypeChange - Returns products whose stored value can not be converted to the new definition
+func (fieldSvc *customFieldService) CheckTypeChange(ctx context.Context, oldFieldDef *customfielddefinitionmodel.CustomFieldDefinition, newFieldDef *customfielddefinitionmodel.CustomFieldDefinition) ([]*TypeChangeBlocker, error) {
+	result := make([]*TypeChangeBlocker, 0)
+
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+	err := fieldRepo.ForEachByField(ctx, oldFieldDef.GetID(), func(prodCode string, field *productcustomfieldmodel.ProductCustomField) error {
+		if _, message := convert(field, oldFieldDef, newFieldDef); message != "" {
+			result = append(result, &TypeChangeBlocker{
+				Code:    prodCode,
+				Value:   textValue(field, oldFieldDef),
+				Message: message,
+			})
+		}
+
+		if len(result) >= configs.TYPECHANGEMAXBLOCKERS {
+			return errStopIteration
+		}
+
+		return nil
+	})
+	if err != nil && err != errStopIteration {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MigrateType - Converts stored values of the old definition type to the new definition type
+func (fieldSvc *customFieldService) MigrateType(ctx context.Context, oldFieldDef *customfielddefinitionmodel.CustomFieldDefinition, newFieldDef *customfielddefinitionmodel.CustomFieldDefinition) error {
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+
+	return fieldRepo.ForEachByField(ctx, oldFieldDef.GetID(), func(prodCode string, field *productcustomfieldmodel.ProductCustomField) error {
+		converted, message := convert(field, oldFieldDef, newFieldDef)
+		if message != "" {
+			return errors.New(message)
+		}
+
+		_, err := fieldRepo.Update(ctx, converted)
+
+		return err
+	})
+}
+
+// convert - Converts value through its text representation, returning the failure message if any
+func convert(field *productcustomfieldmodel.ProductCustomField, oldFieldDef *customfielddefinitionmodel.CustomFieldDefinition, newFieldDef *customfielddefinitionmodel.CustomFieldDefinition) (*productcustomfieldmodel.ProductCustomField, string) {
+	converted := productcustomfieldmodel.NewProductCustomField()
+	converted.ID = field.GetID()
+	converted.ProdID = field.GetProdID()
+	converted.FieldID = field.GetFieldID()
+
+	value := textValue(field, oldFieldDef)
+	if value == "" {
+		value = newFieldDef.GetDefaultValue()
+	}
+
+	if err := converted.SetValueString(newFieldDef, value); err != nil {
+		return nil, err.Error()
+	}
+
+	if violations := converted.DoValidate(newFieldDef, nil); !violations.IsValid() {
+		return nil, violations.Error()
+	}
+
+	return converted, ""
+}
+
+// textValue - Returns text representation of value, empty when value is not specified
+func textValue(field *productcustomfieldmodel.ProductCustomField, fieldDef *customfielddefinitionmodel.CustomFieldDefinition) string {
+	if !field.IsSpecified(fieldDef) {
+		return ""
+	}
+
+	return field.GetValueString(fieldDef)
+}
@@ -0,0 +1,240 @@
+package exportservice
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/fileformat"
+	"github.com/bungysheep/catalogue-api/pkg/commons/xlsx"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	revisionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/revision"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcustomfieldrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/revisionproductrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/unitofmeasurerepository"
+)
+
+// IExportService type
+type IExportService interface {
+	Export(context.Context, *cataloguemodel.Catalogue, *revisionmodel.Revision, string, io.Writer) error
+}
+
+type exportService struct {
+}
+
+type recordWriter interface {
+	Write([]string) error
+	Flush() error
+}
+
+type csvRecordWriter struct {
+	*csv.Writer
+}
+
+// NewExportService - Create export service
+func NewExportService() IExportService {
+	return &exportService{}
+}
+
+// Export - Streams all products of catalogue in the given format, as published in rev or as drafted when rev is nil
+func (exportSvc *exportService) Export(ctx context.Context, clg *cataloguemodel.Catalogue, rev *revisionmodel.Revision, format string, w io.Writer) error {
+	forEachProduct := func(fn func(*productmodel.Product) error) error {
+		return exportSvc.forEachProduct(ctx, clg, fn)
+	}
+
+	// Columns follow the custom fields of catalogue as published
+	if rev != nil {
+		clg = rev.GetSnapshot().Catalogue
+		forEachProduct = func(fn func(*productmodel.Product) error) error {
+			return exportSvc.forEachPublishedProduct(ctx, rev, fn)
+		}
+	}
+
+	switch format {
+	case fileformat.CSV.String():
+		return exportSvc.exportRecords(clg, forEachProduct, &csvRecordWriter{csv.NewWriter(w)}, w)
+
+	case fileformat.XLSX.String():
+		xlsxWriter, err := xlsx.NewWriter(w, clg.GetCode())
+		if err != nil {
+			return err
+		}
+
+		if err := exportSvc.exportRecords(clg, forEachProduct, xlsxWriter, w); err != nil {
+			return err
+		}
+
+		return xlsxWriter.Close()
+
+	case fileformat.NDJSON.String():
+		return exportSvc.exportNDJSON(forEachProduct, w)
+
+	}
+
+	return fmt.Errorf("Unsupported export format '%s'", format)
+}
+
+// Header - Returns export columns, named the same as default import mapping
+func Header(clg *cataloguemodel.Catalogue) []string {
+	header := []string{"code", "parent_code", "description", "details", "status", "uom_code", "uom_description", "uom_ratio"}
+	for _, fieldDef := range clg.GetAllCustomFieldDefinitions() {
+		header = append(header, fieldDef.GetCaption())
+	}
+
+	return header
+}
+
+// exportRecords writes one record per unit of measure of each product, repeating values of product in each
+func (exportSvc *exportService) exportRecords(clg *cataloguemodel.Catalogue, forEachProduct func(func(*productmodel.Product) error) error, writer recordWriter, w io.Writer) error {
+	if err := writer.Write(Header(clg)); err != nil {
+		return err
+	}
+
+	var nbrRows int
+	var parent *productmodel.Product
+	err := forEachProduct(func(prod *productmodel.Product) error {
+		var parentCode string
+		if !prod.IsVariant() {
+			parent = prod
+		} else if parent != nil && parent.GetID() == prod.GetParentID() {
+			parentCode = parent.GetCode()
+		}
+
+		record := []string{prod.GetCode(), parentCode, prod.GetDescription(), prod.GetDetails(), prod.GetStatus(), "", "", ""}
+
+		for _, fieldDef := range clg.GetAllCustomFieldDefinitions() {
+			var value string
+			for _, field := range prod.GetAllCustomFields() {
+				if field.GetFieldID() == fieldDef.GetID() {
+					value = field.GetValueString(fieldDef)
+				}
+			}
+			record = append(record, value)
+		}
+
+		records := make([][]string, 0)
+		for _, uom := range prod.GetAllUoms() {
+			uomRecord := append([]string{}, record...)
+			uomRecord[5] = uom.GetCode()
+			uomRecord[6] = uom.GetDescription()
+			uomRecord[7] = strconv.FormatFloat(uom.GetRatio(), 'f', -1, 64)
+			records = append(records, uomRecord)
+		}
+
+		if len(records) == 0 {
+			records = append(records, record)
+		}
+
+		for _, record := range records {
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+
+			nbrRows++
+			if nbrRows%configs.EXPORTFLUSHINTERVAL == 0 {
+				if err := flush(writer, w); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+func (exportSvc *exportService) exportNDJSON(forEachProduct func(func(*productmodel.Product) error) error, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+
+	var nbrRows int
+	return forEachProduct(func(prod *productmodel.Product) error {
+		if err := encoder.Encode(prod); err != nil {
+			return err
+		}
+
+		nbrRows++
+		if nbrRows%configs.EXPORTFLUSHINTERVAL == 0 {
+			return flush(nil, w)
+		}
+
+		return nil
+	})
+}
+
+// forEachProduct streams products of catalogue, variants along with the values they inherit from their parent
+func (exportSvc *exportService) forEachProduct(ctx context.Context, clg *cataloguemodel.Catalogue, fn func(*productmodel.Product) error) error {
+	prodRepo := productrepository.NewProductRepository()
+	uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+
+	var parent *productmodel.Product
+	return prodRepo.ForEachFamilyByCatalogue(ctx, clg.GetCode(), func(prod *productmodel.Product) error {
+		uoms, err := uomRepo.GetByProduct(ctx, prod.GetID())
+		if err != nil {
+			return err
+		}
+		prod.UnitOfMeasures = uoms
+
+		fields, err := fieldRepo.GetByProduct(ctx, prod.GetID())
+		if err != nil {
+			return err
+		}
+		prod.CustomFields = fields
+
+		// Each parent is followed by its variants
+		if !prod.IsVariant() {
+			parent = prod
+		} else if parent != nil && parent.GetID() == prod.GetParentID() {
+			prod.Inherit(parent)
+		}
+
+		return fn(prod)
+	})
+}
+
+// forEachPublishedProduct streams products as published in revision, variants along with the values they inherit
+func (exportSvc *exportService) forEachPublishedProduct(ctx context.Context, rev *revisionmodel.Revision, fn func(*productmodel.Product) error) error {
+	revProdRepo := revisionproductrepository.NewRevisionProductRepository()
+
+	var parent *productmodel.Product
+	return revProdRepo.ForEachByRevision(ctx, rev.GetID(), func(prod *productmodel.Product) error {
+		// Each parent is followed by its variants
+		if !prod.IsVariant() {
+			parent = prod
+		} else if parent != nil && parent.GetID() == prod.GetParentID() {
+			prod.Inherit(parent)
+		}
+
+		return fn(prod)
+	})
+}
+
+func flush(writer recordWriter, w io.Writer) error {
+	if writer != nil {
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
+
+func (cw *csvRecordWriter) Flush() error {
+	cw.Writer.Flush()
+	return cw.Writer.Error()
+}
@@ -0,0 +1,582 @@
+package importservice
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/fileformat"
+	"github.com/bungysheep/catalogue-api/pkg/commons/jobstatus"
+	"github.com/bungysheep/catalogue-api/pkg/commons/lifecycle"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	importjobmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/importjob"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/productcustomfield"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/unitofmeasure"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/importjobrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/productservice"
+)
+
+// IImportService type
+type IImportService interface {
+	Run(context.Context, *importjobmodel.ImportJob, *importjobmodel.ImportOptions, []byte)
+}
+
+type importService struct {
+}
+
+type importRow struct {
+	RowNo      int64
+	Product    *productmodel.Product
+	ParentCode string
+	Message    string
+}
+
+// NewImportService - Create import service
+func NewImportService() IImportService {
+	return &importService{}
+}
+
+// Run - Processes import job rows, upserting products by code or submitting updates requiring approval
+func (importSvc *importService) Run(ctx context.Context, job *importjobmodel.ImportJob, opts *importjobmodel.ImportOptions, data []byte) {
+	log.Printf("Running Import Job '%v'.\n", job.GetID())
+
+	jobRepo := importjobrepository.NewImportJobRepository()
+
+	job.Status = jobstatus.Running.String()
+	if err := importSvc.saveProgress(ctx, job); err != nil {
+		log.Printf("Failed updating Import Job '%v', error: %v.\n", job.GetID(), err)
+		return
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(ctx, job.GetCatalogueCode())
+	if err != nil || clg == nil {
+		importSvc.fail(ctx, job, "Invalid catalogue code.")
+		return
+	}
+
+	var rows []*importRow
+	switch job.GetFormat() {
+	case fileformat.CSV.String():
+		rows, err = parseCSV(data, opts, clg)
+
+	case fileformat.NDJSON.String():
+		rows, err = parseNDJSON(data)
+
+	}
+	if err != nil {
+		importSvc.fail(ctx, job, err.Error())
+		return
+	}
+
+	job.TotalRows = int64(len(rows))
+
+	for _, row := range rows {
+		var created bool
+
+		message := row.Message
+		if message == "" {
+			created, message = importSvc.processRow(ctx, job, opts, clg, row)
+		}
+
+		job.ProcessedRows++
+		if message != "" {
+			job.FailedRows++
+
+			importError := importjobmodel.NewImportError()
+			importError.JobID = job.GetID()
+			importError.RowNo = row.RowNo
+			if row.Product != nil {
+				importError.Code = row.Product.GetCode()
+			}
+			importError.Message = message
+
+			if _, err := jobRepo.CreateError(ctx, importError); err != nil {
+				importSvc.fail(ctx, job, err.Error())
+				return
+			}
+		} else if created {
+			job.CreatedRows++
+		} else {
+			job.UpdatedRows++
+		}
+
+		if job.GetProcessedRows()%configs.IMPORTPROGRESSINTERVAL == 0 {
+			if err := importSvc.saveProgress(ctx, job); err != nil {
+				log.Printf("Failed updating Import Job '%v', error: %v.\n", job.GetID(), err)
+				return
+			}
+		}
+	}
+
+	job.Status = jobstatus.Completed.String()
+	if err := importSvc.saveProgress(ctx, job); err != nil {
+		log.Printf("Failed updating Import Job '%v', error: %v.\n", job.GetID(), err)
+	}
+}
+
+// processRow validates and upserts a product, returning whether it was created and the failure message
+func (importSvc *importService) processRow(ctx context.Context, job *importjobmodel.ImportJob, opts *importjobmodel.ImportOptions, clg *cataloguemodel.Catalogue, row *importRow) (bool, string) {
+	prod := row.Product
+	prod.CatalogueCode = clg.GetCode()
+	if prod.GetCode() == "" {
+		return false, "Code must be specified"
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	oldProd, err := prodRepo.GetByCode(ctx, clg.GetCode(), prod.GetCode())
+	if err != nil {
+		return false, err.Error()
+	}
+
+	prodSvc := productservice.NewProductService()
+
+	if oldProd == nil {
+		parent, message, err := getParent(ctx, clg, prod, row.ParentCode)
+		if err != nil {
+			return true, err.Error()
+		}
+
+		if message != "" {
+			return true, message
+		}
+
+		if prod.GetStatus() == "" {
+			prod.Status = lifecycle.Active.String()
+		}
+
+		if len(prod.GetAllUoms()) == 0 && opts.GetDefaultUom() != nil {
+			defaultUom := *opts.GetDefaultUom()
+			defaultUom.Ratio = 1
+			prod.UnitOfMeasures = []*unitofmeasure.UnitOfMeasure{&defaultUom}
+		}
+
+		for _, uom := range prod.GetAllUoms() {
+			uom.ChangeMode = changemode.Add
+		}
+
+		if parent != nil {
+			prod.Inherit(parent)
+		}
+
+		if err := prod.ApplyDefaults(clg); err != nil {
+			return true, err.Error()
+		}
+
+		violations := prod.DoValidate(nil, clg)
+		if parent != nil {
+			violations.Append("", prod.DoValidateVariant(parent, clg))
+		}
+
+		if !violations.IsValid() {
+			return true, violations.Error()
+		}
+
+		if parent != nil {
+			prod.DropInherited(parent)
+		}
+
+		unique, message, err := prodSvc.ValidateUnique(ctx, nil, prod, clg)
+		if err != nil {
+			return true, err.Error()
+		}
+
+		if !unique {
+			return true, message
+		}
+
+		if parent != nil {
+			valid, message, err := prodSvc.ValidateVariant(ctx, parent, prod, clg)
+			if err != nil {
+				return true, err.Error()
+			}
+
+			if !valid {
+				return true, message
+			}
+		}
+
+		valid, message, err := prodSvc.ValidateUoms(ctx, prod)
+		if err != nil {
+			return true, err.Error()
+		}
+
+		if !valid {
+			return true, message
+		}
+
+		if job.GetDryRun() {
+			return true, ""
+		}
+
+		prod.CreatedBy = job.GetCreatedBy()
+		prod.CreatedAt = time.Now()
+		prod.ModifiedBy = job.GetCreatedBy()
+		prod.ModifiedAt = time.Now()
+		prod.Vers = 1
+
+		if _, err := prodSvc.Create(ctx, prod); err != nil {
+			return true, err.Error()
+		}
+
+		return true, ""
+	}
+
+	mergeProduct(prod, oldProd)
+
+	// Change is submitted as imported, it is validated again once approved
+	var submitted []byte
+	if clg.GetApprovalRequired() {
+		submitted, err = json.Marshal(prod)
+		if err != nil {
+			return false, err.Error()
+		}
+	}
+
+	parent, message, err := getParent(ctx, clg, prod, "")
+	if err != nil {
+		return false, err.Error()
+	}
+
+	if message != "" {
+		return false, message
+	}
+
+	if parent != nil {
+		prod.Inherit(parent)
+	}
+
+	violations := prod.DoValidate(oldProd, clg)
+	if parent != nil {
+		violations.Append("", prod.DoValidateVariant(parent, clg))
+	}
+
+	if !violations.IsValid() {
+		return false, violations.Error()
+	}
+
+	if parent != nil {
+		prod.DropInherited(parent)
+	}
+
+	unique, message, err := prodSvc.ValidateUnique(ctx, oldProd, prod, clg)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	if !unique {
+		return false, message
+	}
+
+	if parent != nil {
+		valid, message, err := prodSvc.ValidateVariant(ctx, parent, prod, clg)
+		if err != nil {
+			return false, err.Error()
+		}
+
+		if !valid {
+			return false, message
+		}
+	}
+
+	valid, message, err := prodSvc.ValidateUoms(ctx, prod)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	if !valid {
+		return false, message
+	}
+
+	if job.GetDryRun() {
+		return false, ""
+	}
+
+	if submitted != nil {
+		submittedProd := productmodel.NewProduct()
+		if err := json.Unmarshal(submitted, submittedProd); err != nil {
+			return false, err.Error()
+		}
+
+		if _, err := prodSvc.SubmitChange(ctx, oldProd, submittedProd, job.GetCreatedBy()); err != nil {
+			return false, err.Error()
+		}
+
+		return false, ""
+	}
+
+	prod.ModifiedBy = job.GetCreatedBy()
+	prod.ModifiedAt = time.Now()
+
+	if _, err := prodSvc.Update(ctx, oldProd, prod); err != nil {
+		return false, err.Error()
+	}
+
+	return false, ""
+}
+
+func (importSvc *importService) fail(ctx context.Context, job *importjobmodel.ImportJob, message string) {
+	log.Printf("Import Job '%v' has failed, error: %v.\n", job.GetID(), message)
+
+	job.Status = jobstatus.Failed.String()
+	job.Message = message
+	if err := importSvc.saveProgress(ctx, job); err != nil {
+		log.Printf("Failed updating Import Job '%v', error: %v.\n", job.GetID(), err)
+	}
+}
+
+func (importSvc *importService) saveProgress(ctx context.Context, job *importjobmodel.ImportJob) error {
+	job.ModifiedAt = time.Now()
+
+	jobRepo := importjobrepository.NewImportJobRepository()
+	_, err := jobRepo.Update(ctx, job)
+
+	return err
+}
+
+// getParent returns parent of imported variant by parent code when supplied, by parent id otherwise
+func getParent(ctx context.Context, clg *cataloguemodel.Catalogue, prod *productmodel.Product, parentCode string) (*productmodel.Product, string, error) {
+	prodRepo := productrepository.NewProductRepository()
+
+	var parent *productmodel.Product
+	var err error
+	if parentCode != "" {
+		parent, err = prodRepo.GetByCode(ctx, clg.GetCode(), strings.ToUpper(parentCode))
+	} else if prod.IsVariant() {
+		parent, err = prodRepo.GetByID(ctx, prod.GetParentID())
+	} else {
+		return nil, "", nil
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if parent == nil || parent.GetCatalogueCode() != clg.GetCode() {
+		return nil, "Parent product does not exist.", nil
+	}
+
+	prod.ParentID = parent.GetID()
+
+	return parent, "", nil
+}
+
+// mergeProduct completes imported product with values of existing product which are not supplied by the import
+func mergeProduct(prod *productmodel.Product, oldProd *productmodel.Product) {
+	prod.ID = oldProd.GetID()
+	prod.ParentID = oldProd.GetParentID()
+	prod.Vers = oldProd.GetVers()
+
+	if prod.GetDescription() == "" {
+		prod.Description = oldProd.GetDescription()
+	}
+
+	if prod.GetDetails() == "" {
+		prod.Details = oldProd.GetDetails()
+	}
+
+	if prod.GetStatus() == "" {
+		prod.Status = oldProd.GetStatus()
+	}
+
+	if prod.GetEffectiveFrom() == nil {
+		prod.EffectiveFrom = oldProd.GetEffectiveFrom()
+	}
+
+	if prod.GetEffectiveTo() == nil {
+		prod.EffectiveTo = oldProd.GetEffectiveTo()
+	}
+
+	if len(prod.GetAllUoms()) == 0 {
+		for _, oldUom := range oldProd.GetAllUoms() {
+			uom := *oldUom
+			prod.UnitOfMeasures = append(prod.UnitOfMeasures, &uom)
+		}
+	} else {
+		// Existing uoms which are not supplied are kept, except default uom replaced by a default uom of another code
+		newDefaultUom := prod.GetDefaultUom()
+		for _, oldUom := range oldProd.GetAllUoms() {
+			var found bool
+			for _, uom := range prod.GetAllUoms() {
+				if uom.GetCode() == oldUom.GetCode() {
+					uom.ID = oldUom.GetID()
+					found = true
+				}
+			}
+
+			if !found && !(oldUom.IsDefault() && newDefaultUom != nil) {
+				uom := *oldUom
+				prod.UnitOfMeasures = append(prod.UnitOfMeasures, &uom)
+			}
+		}
+	}
+
+	for _, oldField := range oldProd.GetAllCustomFields() {
+		var found bool
+		for _, field := range prod.GetAllCustomFields() {
+			if field.GetFieldID() == oldField.GetFieldID() {
+				field.ID = oldField.GetID()
+				found = true
+			}
+		}
+
+		if !found {
+			field := *oldField
+			prod.CustomFields = append(prod.CustomFields, &field)
+		}
+	}
+
+	prod.MarkChanges(oldProd)
+}
+
+func parseCSV(data []byte, opts *importjobmodel.ImportOptions, clg *cataloguemodel.Catalogue) ([]*importRow, error) {
+	result := make([]*importRow, 0)
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid csv header, error: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, column := range header {
+		columns[strings.TrimSpace(column)] = i
+	}
+
+	getValue := func(record []string, column string) (string, bool) {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return "", ok
+		}
+		return strings.TrimSpace(record[i]), true
+	}
+
+	// Rows of the same code are one product, each carrying one of its units of measure
+	rowByCode := make(map[string]*importRow)
+
+	var rowNo int64 = 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowNo++
+
+		if err != nil {
+			result = append(result, &importRow{RowNo: rowNo, Message: fmt.Sprintf("Invalid csv row, error: %v", err)})
+			continue
+		}
+
+		var uom *unitofmeasure.UnitOfMeasure
+		var message string
+		if uomCode, ok := getValue(record, opts.GetColumn("uom_code")); ok && uomCode != "" {
+			uom = unitofmeasure.NewUnitOfMeasure()
+			uom.Code = uomCode
+			uom.Description, _ = getValue(record, opts.GetColumn("uom_description"))
+			uom.Ratio = 1
+
+			if ratio, _ := getValue(record, opts.GetColumn("uom_ratio")); ratio != "" {
+				if uom.Ratio, err = strconv.ParseFloat(ratio, 64); err != nil {
+					message = fmt.Sprintf("Invalid unit of measure ratio '%s'", ratio)
+				}
+			}
+		}
+
+		code, _ := getValue(record, opts.GetColumn("code"))
+		if row, ok := rowByCode[strings.ToUpper(code)]; ok && code != "" {
+			if uom != nil {
+				row.Product.UnitOfMeasures = append(row.Product.UnitOfMeasures, uom)
+			}
+
+			if row.Message == "" {
+				row.Message = message
+			}
+			continue
+		}
+
+		row := &importRow{RowNo: rowNo, Message: message}
+		result = append(result, row)
+		rowByCode[strings.ToUpper(code)] = row
+
+		prod := productmodel.NewProduct()
+		prod.Code = code
+		prod.Description, _ = getValue(record, opts.GetColumn("description"))
+		prod.Details, _ = getValue(record, opts.GetColumn("details"))
+		prod.Status, _ = getValue(record, opts.GetColumn("status"))
+		row.Product = prod
+		row.ParentCode, _ = getValue(record, opts.GetColumn("parent_code"))
+
+		if uom != nil {
+			prod.UnitOfMeasures = append(prod.UnitOfMeasures, uom)
+		}
+
+		for _, fieldDef := range clg.GetAllCustomFieldDefinitions() {
+			// Empty values are not supplied, existing ones are kept as they are
+			value, _ := getValue(record, opts.GetCustomFieldColumn(fieldDef.GetCaption()))
+			if value == "" {
+				continue
+			}
+
+			field := productcustomfield.NewProductCustomField()
+			field.FieldID = fieldDef.GetID()
+			if err := field.SetValueString(fieldDef, value); err != nil {
+				row.Message = err.Error()
+				break
+			}
+			prod.CustomFields = append(prod.CustomFields, field)
+		}
+	}
+
+	return result, nil
+}
+
+func parseNDJSON(data []byte) ([]*importRow, error) {
+	result := make([]*importRow, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), configs.IMPORTMAXSIZE)
+
+	var rowNo int64
+	for scanner.Scan() {
+		rowNo++
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		row := &importRow{RowNo: rowNo}
+		result = append(result, row)
+
+		prod := productmodel.NewProduct()
+		if err := json.Unmarshal(line, prod); err != nil {
+			row.Message = fmt.Sprintf("Invalid json row, error: %v", err)
+			continue
+		}
+		row.Product = prod
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Invalid ndjson, error: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package lifecycleservice
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/lifecycle"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+)
+
+// ILifecycleService type
+type ILifecycleService interface {
+	ApplyDue(context.Context, time.Time) (int64, error)
+	Schedule(context.Context, time.Duration)
+}
+
+type lifecycleService struct {
+}
+
+// NewLifecycleService - Create lifecycle service
+func NewLifecycleService() ILifecycleService {
+	return &lifecycleService{}
+}
+
+// ApplyDue - Applies lifecycle transitions due at date, returns number of products changed
+func (lcSvc *lifecycleService) ApplyDue(ctx context.Context, at time.Time) (int64, error) {
+	prodRepo := productrepository.NewProductRepository()
+	nbrActivated, err := prodRepo.ActivateEffective(ctx, []string{lifecycle.Draft.String()}, lifecycle.Active.String(), at, configs.SCHEDULERUSER)
+	if err != nil {
+		return 0, err
+	}
+
+	nbrRetired, err := prodRepo.RetireExpired(ctx, []string{lifecycle.Active.String(), lifecycle.Discontinued.String()}, lifecycle.EndOfLife.String(), at, configs.SCHEDULERUSER)
+	if err != nil {
+		return nbrActivated, err
+	}
+
+	return nbrActivated + nbrRetired, nil
+}
+
+// Schedule - Applies due transitions every interval until context is done
+func (lcSvc *lifecycleService) Schedule(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case at := <-ticker.C:
+			nbrRows, err := lcSvc.ApplyDue(ctx, at)
+			if err != nil {
+				log.Printf("Failed applying product lifecycle transitions, error: %v.\n", err)
+			} else if nbrRows > 0 {
+				log.Printf("Applied lifecycle transitions to %v Products.\n", nbrRows)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package priceservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/decimal"
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	pricelistmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/pricelist"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	productpricemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/productprice"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productpricerepository"
+)
+
+var (
+	// ErrPriceNotCreated - Product price was not created
+	ErrPriceNotCreated = errors.New("Product Price was not created.")
+)
+
+// IPriceService type
+type IPriceService interface {
+	ValidatePrices(*productmodel.Product, []*productpricemodel.ProductPrice) validation.Violations
+	SetPrices(context.Context, *pricelistmodel.PriceList, *productmodel.Product, []*productpricemodel.ProductPrice) ([]*productpricemodel.ProductPrice, error)
+	Resolve(context.Context, *pricelistmodel.PriceList, *productmodel.Product, string, decimal.Decimal, time.Time) (*productpricemodel.EffectivePrice, string, error)
+}
+
+type priceService struct {
+}
+
+// NewPriceService - Create price service
+func NewPriceService() IPriceService {
+	return &priceService{}
+}
+
+// ValidatePrices - Validate prices of product, each tier refers to a product uom
+func (priceSvc *priceService) ValidatePrices(prod *productmodel.Product, prices []*productpricemodel.ProductPrice) validation.Violations {
+	violations := make(validation.Violations, 0)
+
+	tiers := make(map[string]bool)
+	for i, price := range prices {
+		priceViolations := price.DoValidate()
+		if !priceViolations.IsValid() {
+			violations.Append(validation.Pointer(i), priceViolations)
+			continue
+		}
+
+		if prod.GetUomByCode(price.GetUomCode()) == nil {
+			violations.Add(validation.Pointer(i, "uom_code"), fmt.Sprintf("Unit of Measure '%s' is not a unit of product.", price.GetUomCode()))
+			continue
+		}
+
+		tier := price.GetUomCode() + "/" + price.GetMinQty().Normalize().String()
+		if tiers[tier] {
+			violations.Add(validation.Pointer(i, "min_qty"), fmt.Sprintf("Price of '%s' from quantity '%s' is duplicated.", price.GetUomCode(), price.GetMinQty()))
+			continue
+		}
+		tiers[tier] = true
+	}
+
+	return violations
+}
+
+// SetPrices - Replaces all prices of product in price list
+func (priceSvc *priceService) SetPrices(ctx context.Context, list *pricelistmodel.PriceList, prod *productmodel.Product, prices []*productpricemodel.ProductPrice) ([]*productpricemodel.ProductPrice, error) {
+	priceRepo := productpricerepository.NewProductPriceRepository()
+	if err := priceRepo.DeleteByListProduct(ctx, list.GetCode(), prod.GetID()); err != nil {
+		return nil, err
+	}
+
+	for _, price := range prices {
+		price.ListCode = list.GetCode()
+		price.ProdID = prod.GetID()
+
+		lastID, err := priceRepo.Create(ctx, price)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastID == 0 {
+			return nil, ErrPriceNotCreated
+		}
+	}
+
+	return priceRepo.GetByListProduct(ctx, list.GetCode(), prod.GetID())
+}
+
+// Resolve - Resolves effective price of quantity of product uom in price list at date
+func (priceSvc *priceService) Resolve(ctx context.Context, list *pricelistmodel.PriceList, prod *productmodel.Product, uomCode string, qty decimal.Decimal, date time.Time) (*productpricemodel.EffectivePrice, string, error) {
+	if !list.IsValidAt(date) {
+		return nil, fmt.Sprintf("Price list '%s' is not valid at %s.", list.GetCode(), date.Format(configs.SHORTDATEFORMAT)), nil
+	}
+
+	uom := prod.GetDefaultUom()
+	if uomCode != "" {
+		uom = prod.GetUomByCode(uomCode)
+	}
+
+	if uom == nil {
+		return nil, fmt.Sprintf("Unit of Measure '%s' is not a unit of product.", uomCode), nil
+	}
+
+	priceRepo := productpricerepository.NewProductPriceRepository()
+	prices, err := priceRepo.GetByListProduct(ctx, list.GetCode(), prod.GetID())
+	if err != nil {
+		return nil, "", err
+	}
+
+	var tier *productpricemodel.ProductPrice
+	for _, price := range prices {
+		if price.GetUomCode() != uom.GetCode() || price.GetMinQty().Cmp(qty) > 0 {
+			continue
+		}
+
+		if tier == nil || price.GetMinQty().Cmp(tier.GetMinQty()) > 0 {
+			tier = price
+		}
+	}
+
+	if tier == nil {
+		return nil, fmt.Sprintf("Product has no price in price list '%s' for quantity %s of '%s'.", list.GetCode(), qty, uom.GetCode()), nil
+	}
+
+	return &productpricemodel.EffectivePrice{
+		ProdID:    prod.GetID(),
+		ListCode:  list.GetCode(),
+		Currency:  list.GetCurrency(),
+		UomCode:   uom.GetCode(),
+		Qty:       qty,
+		MinQty:    tier.GetMinQty(),
+		UnitPrice: tier.GetPrice(),
+		Amount:    tier.GetPrice().Mul(qty).Rescale(tier.GetPrice().Scale()),
+	}, "", nil
+}
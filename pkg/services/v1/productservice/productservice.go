@@ -0,0 +1,701 @@
+package productservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/changemode"
+	"github.com/bungysheep/catalogue-api/pkg/commons/reviewstatus"
+	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	changerequestmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/changerequest"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	unitofmeasuremodel "github.com/bungysheep/catalogue-api/pkg/models/v1/unitofmeasure"
+	uommastermodel "github.com/bungysheep/catalogue-api/pkg/models/v1/uommaster"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/changerequestrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcategoryrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productcustomfieldrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productpricerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrelationrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/unitofmeasurerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/uommasterrepository"
+)
+
+var (
+	// ErrProductNotCreated - Product was not created
+	ErrProductNotCreated = errors.New("Product was not created.")
+
+	// ErrProductNotUpdated - Product was not updated, either missing or modified by another user
+	ErrProductNotUpdated = errors.New("Product was not updated.")
+
+	// ErrProductNotDeleted - Product was not deleted, either missing or modified by another user
+	ErrProductNotDeleted = errors.New("Product was not deleted.")
+
+	// ErrProductHasVariants - Product has variants, they must be deleted first
+	ErrProductHasVariants = errors.New("Product has variants, its variants must be deleted first.")
+
+	// ErrUomNotCreated - Unit of measure was not created
+	ErrUomNotCreated = errors.New("Unit of Measure was not created.")
+
+	// ErrUomNotDeleted - Unit of measure was not deleted
+	ErrUomNotDeleted = errors.New("Unit of Measure was not deleted.")
+
+	// ErrCustomFieldNotCreated - Custom field was not created
+	ErrCustomFieldNotCreated = errors.New("Custom Field was not created.")
+
+	// ErrCustomFieldNotDeleted - Custom field was not deleted
+	ErrCustomFieldNotDeleted = errors.New("Custom Field was not deleted.")
+
+	// ErrCategoryNotAssigned - Product was not assigned to category
+	ErrCategoryNotAssigned = errors.New("Product was not assigned to Category.")
+
+	// ErrProductCodeExists - Another product of catalogue has the code
+	ErrProductCodeExists = errors.New("Product already exists in catalogue.")
+
+	// ErrChangeAlreadyReviewed - Change request was reviewed by another reviewer meanwhile
+	ErrChangeAlreadyReviewed = errors.New("Product Change Request has already been reviewed.")
+
+	// ErrChangePending - Product already has a change request pending approval
+	ErrChangePending = errors.New("Product has a pending change request.")
+)
+
+// IProductService type
+type IProductService interface {
+	GetByID(context.Context, int64) (*productmodel.Product, error)
+	GetByCode(context.Context, string, string) (*productmodel.Product, error)
+	Create(context.Context, *productmodel.Product) (*productmodel.Product, error)
+	Update(context.Context, *productmodel.Product, *productmodel.Product) (*productmodel.Product, error)
+	Delete(context.Context, int64, int64) error
+	SubmitChange(context.Context, *productmodel.Product, *productmodel.Product, string) (*changerequestmodel.ChangeRequest, error)
+	ApplyChange(context.Context, *productmodel.Product, *productmodel.Product, *changerequestmodel.ChangeRequest) (*productmodel.Product, error)
+	Move(context.Context, *productmodel.Product, []*productmodel.Product) (*productmodel.Product, error)
+	ValidateUnique(context.Context, *productmodel.Product, *productmodel.Product, *cataloguemodel.Catalogue) (bool, string, error)
+	ValidateUoms(context.Context, *productmodel.Product) (bool, string, error)
+	ValidateVariant(context.Context, *productmodel.Product, *productmodel.Product, *cataloguemodel.Catalogue) (bool, string, error)
+	Convert(context.Context, *productmodel.Product, float64, string, string) (*unitofmeasuremodel.Conversion, string, error)
+}
+
+type productService struct {
+}
+
+// NewProductService - Create product service
+func NewProductService() IProductService {
+	return &productService{}
+}
+
+// GetByID - Returns product, variant along with the values it inherits from its parent
+func (prodSvc *productService) GetByID(ctx context.Context, id int64) (*productmodel.Product, error) {
+	prodRepo := productrepository.NewProductRepository()
+	prod, err := prodRepo.GetByID(ctx, id)
+	if err != nil || prod == nil || !prod.IsVariant() {
+		return prod, err
+	}
+
+	return inheritParent(ctx, prod)
+}
+
+// GetByCode - Returns product of code in catalogue, variant along with the values it inherits from its parent
+func (prodSvc *productService) GetByCode(ctx context.Context, clgCode string, code string) (*productmodel.Product, error) {
+	prodRepo := productrepository.NewProductRepository()
+	prod, err := prodRepo.GetByCode(ctx, clgCode, strings.ToUpper(code))
+	if err != nil || prod == nil || !prod.IsVariant() {
+		return prod, err
+	}
+
+	return inheritParent(ctx, prod)
+}
+
+// Create - Creates product along with its unit of measures and custom fields in a single transaction
+func (prodSvc *productService) Create(ctx context.Context, newProd *productmodel.Product) (*productmodel.Product, error) {
+	var result *productmodel.Product
+	err := database.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = prodSvc.create(ctx, newProd)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (prodSvc *productService) create(ctx context.Context, newProd *productmodel.Product) (*productmodel.Product, error) {
+	if err := reserveCode(ctx, newProd); err != nil {
+		return nil, err
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	lastID, err := prodRepo.Create(ctx, newProd)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastID == 0 {
+		return nil, ErrProductNotCreated
+	}
+
+	uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
+	for _, newUom := range newProd.GetAllUoms() {
+		if newUom.GetChangeMode() == changemode.Add {
+			newUom.ProdID = lastID
+			newUom.Vers = 1
+
+			lastUomID, err := uomRepo.Create(ctx, newUom)
+			if err != nil {
+				return nil, err
+			}
+
+			if lastUomID == 0 {
+				return nil, ErrUomNotCreated
+			}
+		}
+	}
+
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+	for _, newField := range newProd.GetAllCustomFields() {
+		newField.ProdID = lastID
+
+		lastFieldID, err := fieldRepo.Create(ctx, newField)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastFieldID == 0 {
+			return nil, ErrCustomFieldNotCreated
+		}
+	}
+
+	if err := assignCategories(ctx, lastID, newProd.GetCategoryIDs()); err != nil {
+		return nil, err
+	}
+
+	return prodSvc.GetByID(ctx, lastID)
+}
+
+// Update - Updates product and applies changes of its unit of measures and custom fields in a single transaction
+func (prodSvc *productService) Update(ctx context.Context, oldProd *productmodel.Product, updProd *productmodel.Product) (*productmodel.Product, error) {
+	var result *productmodel.Product
+	err := database.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = prodSvc.update(ctx, oldProd, updProd)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (prodSvc *productService) update(ctx context.Context, oldProd *productmodel.Product, updProd *productmodel.Product) (*productmodel.Product, error) {
+	oldProd.Description = updProd.GetDescription()
+	oldProd.Details = updProd.GetDetails()
+	oldProd.Status = updProd.GetStatus()
+	oldProd.EffectiveFrom = updProd.GetEffectiveFrom()
+	oldProd.EffectiveTo = updProd.GetEffectiveTo()
+	oldProd.ModifiedBy = updProd.GetModifiedBy()
+
+	prodRepo := productrepository.NewProductRepository()
+	nbrRows, err := prodRepo.Update(ctx, oldProd)
+	if err != nil {
+		return nil, err
+	}
+
+	if nbrRows == 0 {
+		return nil, ErrProductNotUpdated
+	}
+
+	uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
+	for _, updUom := range updProd.GetAllUoms() {
+		oldUom := oldProd.GetUom(updUom.GetID())
+
+		if updUom.GetChangeMode() == changemode.Add {
+			if oldUom == nil {
+				updUom.ProdID = oldProd.GetID()
+				updUom.Vers = 1
+
+				lastUomID, err := uomRepo.Create(ctx, updUom)
+				if err != nil {
+					return nil, err
+				}
+
+				if lastUomID == 0 {
+					return nil, ErrUomNotCreated
+				}
+			}
+
+		} else if updUom.GetChangeMode() == changemode.Update {
+			if !updUom.IsEqual(oldUom) {
+				oldUom.Code = updUom.GetCode()
+				oldUom.Description = updUom.GetDescription()
+				oldUom.Ratio = updUom.GetRatio()
+
+				_, err := uomRepo.Update(ctx, oldUom)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+		} else if updUom.GetChangeMode() == changemode.Delete {
+			nbrRow, err := uomRepo.Delete(ctx, oldUom.GetID())
+			if err != nil {
+				return nil, err
+			}
+
+			if nbrRow == 0 {
+				return nil, ErrUomNotDeleted
+			}
+
+		}
+	}
+
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+	for _, updField := range updProd.GetAllCustomFields() {
+		updField.ProdID = oldProd.GetID()
+
+		if updField.GetChangeMode() == changemode.Add {
+			lastFieldID, err := fieldRepo.Create(ctx, updField)
+			if err != nil {
+				return nil, err
+			}
+
+			if lastFieldID == 0 {
+				return nil, ErrCustomFieldNotCreated
+			}
+
+		} else if updField.GetChangeMode() == changemode.Delete {
+			nbrRow, err := fieldRepo.Delete(ctx, updField.GetID())
+			if err != nil {
+				return nil, err
+			}
+
+			if nbrRow == 0 {
+				return nil, ErrCustomFieldNotDeleted
+			}
+
+		} else {
+			_, err := fieldRepo.Update(ctx, updField)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Categories left out of request are kept as assigned
+	if updProd.GetCategoryIDs() != nil {
+		pcRepo := productcategoryrepository.NewProductCategoryRepository()
+		if err := pcRepo.DeleteByProduct(ctx, oldProd.GetID()); err != nil {
+			return nil, err
+		}
+
+		if err := assignCategories(ctx, oldProd.GetID(), updProd.GetCategoryIDs()); err != nil {
+			return nil, err
+		}
+	}
+
+	return prodSvc.GetByID(ctx, oldProd.GetID())
+}
+
+// SubmitChange - Records update of product as a change request pending approval
+func (prodSvc *productService) SubmitChange(ctx context.Context, oldProd *productmodel.Product, updProd *productmodel.Product, submittedBy string) (*changerequestmodel.ChangeRequest, error) {
+	changeRepo := changerequestrepository.NewChangeRequestRepository()
+	pending, err := changeRepo.GetPendingByProduct(ctx, oldProd.GetID())
+	if err != nil {
+		return nil, err
+	}
+
+	if pending != nil {
+		return pending, ErrChangePending
+	}
+
+	newChange := changerequestmodel.NewChangeRequest()
+	newChange.ProdID = oldProd.GetID()
+	newChange.CatalogueCode = oldProd.GetCatalogueCode()
+	newChange.BaseVers = oldProd.GetVers()
+	newChange.Product = updProd
+	newChange.Status = reviewstatus.Pending.String()
+	newChange.SubmittedBy = submittedBy
+	newChange.SubmittedAt = time.Now()
+
+	newChange.ID, err = changeRepo.Create(ctx, newChange)
+	if err != nil {
+		return nil, err
+	}
+
+	return newChange, nil
+}
+
+// ApplyChange - Updates product as approved by change request and records its review in a single transaction
+func (prodSvc *productService) ApplyChange(ctx context.Context, oldProd *productmodel.Product, updProd *productmodel.Product, change *changerequestmodel.ChangeRequest) (*productmodel.Product, error) {
+	var result *productmodel.Product
+	err := database.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = prodSvc.Update(ctx, oldProd, updProd)
+		if err != nil {
+			return err
+		}
+
+		changeRepo := changerequestrepository.NewChangeRequestRepository()
+		nbrReviewed, err := changeRepo.Review(ctx, change)
+		if err != nil {
+			return err
+		}
+
+		if nbrReviewed == 0 {
+			return ErrChangeAlreadyReviewed
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Move - Moves product along with its variants to their catalogue in a single transaction, see MoveTo
+func (prodSvc *productService) Move(ctx context.Context, prod *productmodel.Product, variants []*productmodel.Product) (*productmodel.Product, error) {
+	err := database.WithTransaction(ctx, func(ctx context.Context) error {
+		prodRepo := productrepository.NewProductRepository()
+		fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+		pcRepo := productcategoryrepository.NewProductCategoryRepository()
+
+		for _, movedProd := range append([]*productmodel.Product{prod}, variants...) {
+			if err := reserveCode(ctx, movedProd); err != nil {
+				return err
+			}
+
+			nbrRows, err := prodRepo.Move(ctx, movedProd)
+			if err != nil {
+				return err
+			}
+
+			if nbrRows == 0 {
+				return ErrProductNotUpdated
+			}
+
+			if err := fieldRepo.DeleteByProduct(ctx, movedProd.GetID()); err != nil {
+				return err
+			}
+
+			for _, field := range movedProd.GetAllCustomFields() {
+				if field.IsInherited() || field.GetChangeMode() == changemode.Delete {
+					continue
+				}
+
+				field.ProdID = movedProd.GetID()
+
+				lastFieldID, err := fieldRepo.Create(ctx, field)
+				if err != nil {
+					return err
+				}
+
+				if lastFieldID == 0 {
+					return ErrCustomFieldNotCreated
+				}
+			}
+
+			if err := pcRepo.DeleteByProduct(ctx, movedProd.GetID()); err != nil {
+				return err
+			}
+
+			if err := assignCategories(ctx, movedProd.GetID(), movedProd.GetCategoryIDs()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prodSvc.GetByID(ctx, prod.GetID())
+}
+
+// Delete - Deletes product along with its related records in a single transaction, vers is zero for any version
+func (prodSvc *productService) Delete(ctx context.Context, id int64, vers int64) error {
+	return database.WithTransaction(ctx, func(ctx context.Context) error {
+		prodRepo := productrepository.NewProductRepository()
+		nbrVariants, err := prodRepo.CountByParent(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if nbrVariants > 0 {
+			return ErrProductHasVariants
+		}
+
+		nbrRows, err := prodRepo.Delete(ctx, id, vers)
+		if err != nil {
+			return err
+		}
+
+		if nbrRows == 0 {
+			return ErrProductNotDeleted
+		}
+
+		// Also delete all related unit of measures
+		uomRepo := unitofmeasurerepository.NewUnitOfMeasureRepository()
+		if err := uomRepo.DeleteByProduct(ctx, id); err != nil {
+			return err
+		}
+
+		// Also delete all related custom fields
+		fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+		if err := fieldRepo.DeleteByProduct(ctx, id); err != nil {
+			return err
+		}
+
+		// Also delete all related prices
+		priceRepo := productpricerepository.NewProductPriceRepository()
+		if err := priceRepo.DeleteByProduct(ctx, id); err != nil {
+			return err
+		}
+
+		// Also delete all related category assignments
+		pcRepo := productcategoryrepository.NewProductCategoryRepository()
+		if err := pcRepo.DeleteByProduct(ctx, id); err != nil {
+			return err
+		}
+
+		// Also delete all relations of and to product
+		relRepo := productrelationrepository.NewProductRelationRepository()
+		if err := relRepo.DeleteByProductOrRelated(ctx, id); err != nil {
+			return err
+		}
+
+		// Also delete all change requests
+		changeRepo := changerequestrepository.NewChangeRequestRepository()
+		return changeRepo.DeleteByProduct(ctx, id)
+	})
+}
+
+// ValidateUnique - Validate code and values of unique custom fields are not used by other products of catalogue, oldProd is nil on create
+func (prodSvc *productService) ValidateUnique(ctx context.Context, oldProd *productmodel.Product, prod *productmodel.Product, clg *cataloguemodel.Catalogue) (bool, string, error) {
+	var excludeProdID int64
+	if oldProd != nil {
+		excludeProdID = oldProd.GetID()
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	other, err := prodRepo.GetByCode(ctx, clg.GetCode(), prod.GetCode())
+	if err != nil {
+		return false, "", err
+	}
+
+	if other != nil && other.GetID() != excludeProdID {
+		return false, fmt.Sprintf("Product '%s' already exists in catalogue '%s'.", prod.GetCode(), clg.GetCode()), nil
+	}
+
+	fieldRepo := productcustomfieldrepository.NewProductCustomFieldRepository()
+	for _, field := range prod.GetAllCustomFields() {
+		fieldDef := clg.GetCustomFieldDefinition(field.GetFieldID())
+		if fieldDef == nil || !fieldDef.GetUnique() || field.GetChangeMode() == changemode.Delete || !field.IsSpecified(fieldDef) {
+			continue
+		}
+
+		code, err := fieldRepo.GetProductCodeByValue(ctx, fieldDef, field, excludeProdID)
+		if err != nil {
+			return false, "", err
+		}
+
+		if code != "" {
+			return false, fmt.Sprintf("Custom Field '%s' value '%s' is already used by product '%s'.", fieldDef.GetCaption(), field.GetValueString(fieldDef), code), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// ValidateVariant - Validate values of variant axes are not used by other variants of parent
+func (prodSvc *productService) ValidateVariant(ctx context.Context, parent *productmodel.Product, prod *productmodel.Product, clg *cataloguemodel.Catalogue) (bool, string, error) {
+	prodRepo := productrepository.NewProductRepository()
+	variants, err := prodRepo.GetByParent(ctx, parent.GetID())
+	if err != nil {
+		return false, "", err
+	}
+
+	axes := clg.GetVariantAxes()
+	key := prod.GetVariantKey(axes)
+	for _, variant := range variants {
+		if variant.GetID() != prod.GetID() && variant.GetVariantKey(axes) == key {
+			return false, fmt.Sprintf("Product '%s' already has variant '%s' of the same variant axis values.", parent.GetCode(), variant.GetCode()), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// ValidateUoms - Validate uoms of product refer to units of measure master
+func (prodSvc *productService) ValidateUoms(ctx context.Context, prod *productmodel.Product) (bool, string, error) {
+	uoms := make([]*unitofmeasuremodel.UnitOfMeasure, 0)
+	for _, uom := range prod.GetAllUoms() {
+		if uom.GetChangeMode() != changemode.Delete {
+			uoms = append(uoms, uom)
+		}
+	}
+
+	masters, err := getUomMasters(ctx, uoms)
+	if err != nil {
+		return false, "", err
+	}
+
+	for i, uom := range uoms {
+		master := masters[uom.GetCode()]
+		if master == nil {
+			return false, fmt.Sprintf("Unit of Measure '%s' is unknown.", uom.GetCode()), nil
+		}
+
+		for _, otherUom := range uoms[:i] {
+			otherMaster := masters[otherUom.GetCode()]
+			if !master.IsConvertible(otherMaster) {
+				continue
+			}
+
+			if !isEqualRatio(uom.GetRatio()/otherUom.GetRatio(), master.GetFactor()/otherMaster.GetFactor()) {
+				return false, fmt.Sprintf("Unit of Measure '%s' ratio does not match its standard conversion to '%s'.", uom.GetCode(), otherUom.GetCode()), nil
+			}
+		}
+	}
+
+	return true, "", nil
+}
+
+// Convert - Converts quantity of product between units of measure
+func (prodSvc *productService) Convert(ctx context.Context, prod *productmodel.Product, qty float64, from string, to string) (*unitofmeasuremodel.Conversion, string, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+
+	masterRepo := uommasterrepository.NewUomMasterRepository()
+	masters, err := masterRepo.GetAll(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	masterByCode := make(map[string]*uommastermodel.UomMaster)
+	for _, master := range masters {
+		masterByCode[master.GetCode()] = master
+	}
+
+	for _, code := range []string{from, to} {
+		if masterByCode[code] == nil && prod.GetUomByCode(code) == nil {
+			return nil, fmt.Sprintf("Unit of Measure '%s' is unknown.", code), nil
+		}
+	}
+
+	fromRatio, fromOk := getProductRatio(prod, masterByCode, from)
+	toRatio, toOk := getProductRatio(prod, masterByCode, to)
+
+	var factor float64
+	if fromOk && toOk {
+		factor = fromRatio / toRatio
+
+	} else if fromMaster, toMaster := masterByCode[from], masterByCode[to]; fromMaster != nil && toMaster != nil && fromMaster.IsConvertible(toMaster) {
+		factor = fromMaster.GetFactor() / toMaster.GetFactor()
+
+	} else {
+		return nil, fmt.Sprintf("Unit of Measure '%s' can not be converted to '%s'.", from, to), nil
+	}
+
+	return &unitofmeasuremodel.Conversion{
+		ProdID: prod.GetID(),
+		Qty:    qty,
+		From:   from,
+		To:     to,
+		Result: math.Round(qty*factor*1e9) / 1e9,
+	}, "", nil
+}
+
+// getProductRatio - Returns ratio of unit to default uom of product
+func getProductRatio(prod *productmodel.Product, masters map[string]*uommastermodel.UomMaster, code string) (float64, bool) {
+	if uom := prod.GetUomByCode(code); uom != nil {
+		return uom.GetRatio(), true
+	}
+
+	master := masters[code]
+	if master == nil {
+		return 0, false
+	}
+
+	for _, uom := range prod.GetAllUoms() {
+		uomMaster := masters[uom.GetCode()]
+		if uomMaster != nil && master.IsConvertible(uomMaster) {
+			return master.GetFactor() / uomMaster.GetFactor() * uom.GetRatio(), true
+		}
+	}
+
+	return 0, false
+}
+
+// reserveCode - Locks catalogue of product until the transaction ends and validates no other product has its code
+func reserveCode(ctx context.Context, prod *productmodel.Product) error {
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	if err := clgRepo.Lock(ctx, prod.GetCatalogueCode()); err != nil {
+		return err
+	}
+
+	prodRepo := productrepository.NewProductRepository()
+	other, err := prodRepo.GetByCode(ctx, prod.GetCatalogueCode(), prod.GetCode())
+	if err != nil {
+		return err
+	}
+
+	if other != nil && other.GetID() != prod.GetID() {
+		return ErrProductCodeExists
+	}
+
+	return nil
+}
+
+// inheritParent - Returns variant along with the values it inherits from its parent
+func inheritParent(ctx context.Context, prod *productmodel.Product) (*productmodel.Product, error) {
+	prodRepo := productrepository.NewProductRepository()
+	parent, err := prodRepo.GetByID(ctx, prod.GetParentID())
+	if err != nil {
+		return nil, err
+	}
+
+	if parent != nil {
+		prod.Inherit(parent)
+	}
+
+	return prod, nil
+}
+
+func assignCategories(ctx context.Context, prodID int64, categoryIDs []int64) error {
+	pcRepo := productcategoryrepository.NewProductCategoryRepository()
+	for _, categoryID := range categoryIDs {
+		nbrRows, err := pcRepo.Create(ctx, prodID, categoryID)
+		if err != nil {
+			return err
+		}
+
+		if nbrRows == 0 {
+			return ErrCategoryNotAssigned
+		}
+	}
+
+	return nil
+}
+
+func getUomMasters(ctx context.Context, uoms []*unitofmeasuremodel.UnitOfMeasure) (map[string]*uommastermodel.UomMaster, error) {
+	codes := make([]string, 0, len(uoms))
+	for _, uom := range uoms {
+		codes = append(codes, uom.GetCode())
+	}
+
+	masterRepo := uommasterrepository.NewUomMasterRepository()
+	return masterRepo.GetByCodes(ctx, codes)
+}
+
+func isEqualRatio(ratio float64, otherRatio float64) bool {
+	return math.Abs(ratio-otherRatio) <= 1e-9*math.Max(math.Abs(ratio), math.Abs(otherRatio))
+}
@@ -0,0 +1,148 @@
+package relationservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/commons/validation"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	productrelationmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/productrelation"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrelationrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+)
+
+var (
+	// ErrRelationNotCreated - Product relation was not created
+	ErrRelationNotCreated = errors.New("Product Relation was not created.")
+)
+
+// IRelationService type
+type IRelationService interface {
+	ValidateRelations(context.Context, *productmodel.Product, []*productrelationmodel.ProductRelation) (validation.Violations, error)
+	SetRelations(context.Context, *productmodel.Product, []*productrelationmodel.ProductRelation) ([]*productrelationmodel.ProductRelation, error)
+}
+
+type relationService struct {
+}
+
+// NewRelationService - Create relation service
+func NewRelationService() IRelationService {
+	return &relationService{}
+}
+
+// ValidateRelations - Validate relations of product, bundle components can not contain the bundle itself
+func (relSvc *relationService) ValidateRelations(ctx context.Context, prod *productmodel.Product, relations []*productrelationmodel.ProductRelation) (validation.Violations, error) {
+	violations := make(validation.Violations, 0)
+
+	prodRepo := productrepository.NewProductRepository()
+	keys := make(map[string]bool)
+	visited := make(map[int64]bool)
+	for i, rel := range relations {
+		relViolations := rel.DoValidate()
+		if !relViolations.IsValid() {
+			violations.Append(validation.Pointer(i), relViolations)
+			continue
+		}
+
+		path := validation.Pointer(i, "related_id")
+		if rel.GetRelatedID() == prod.GetID() {
+			violations.Add(path, "Product can not be related to itself.")
+			continue
+		}
+
+		key := rel.GetType() + "/" + strconv.FormatInt(rel.GetRelatedID(), 10)
+		if keys[key] {
+			violations.Add(path, fmt.Sprintf("Relation to product '%d' is duplicated.", rel.GetRelatedID()))
+			continue
+		}
+		keys[key] = true
+
+		related, err := prodRepo.GetByID(ctx, rel.GetRelatedID())
+		if err != nil {
+			return violations, err
+		}
+
+		if related == nil {
+			violations.Add(path, fmt.Sprintf("Product '%d' does not exist.", rel.GetRelatedID()))
+			continue
+		}
+
+		if !rel.IsBundle() {
+			continue
+		}
+
+		if related.GetUomByCode(rel.GetUomCode()) == nil {
+			violations.Add(validation.Pointer(i, "uom_code"), fmt.Sprintf("Unit of Measure '%s' is not a unit of product '%s'.", rel.GetUomCode(), related.GetCode()))
+			continue
+		}
+
+		contains, err := relSvc.containsProduct(ctx, related.GetID(), prod.GetID(), visited)
+		if err != nil {
+			return violations, err
+		}
+
+		if contains {
+			violations.Add(path, fmt.Sprintf("Product '%s' can not be a component, it contains product '%s'.", related.GetCode(), prod.GetCode()))
+		}
+	}
+
+	return violations, nil
+}
+
+// SetRelations - Replaces all relations from product
+func (relSvc *relationService) SetRelations(ctx context.Context, prod *productmodel.Product, relations []*productrelationmodel.ProductRelation) ([]*productrelationmodel.ProductRelation, error) {
+	relRepo := productrelationrepository.NewProductRelationRepository()
+	if err := relRepo.DeleteByProduct(ctx, prod.GetID()); err != nil {
+		return nil, err
+	}
+
+	for _, rel := range relations {
+		rel.ProdID = prod.GetID()
+		rel.CreatedAt = time.Now()
+
+		lastID, err := relRepo.Create(ctx, rel)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastID == 0 {
+			return nil, ErrRelationNotCreated
+		}
+	}
+
+	return relRepo.GetByProduct(ctx, prod.GetID())
+}
+
+// containsProduct - Whether bundle contains product through its stored components at any depth
+func (relSvc *relationService) containsProduct(ctx context.Context, bundleID int64, prodID int64, visited map[int64]bool) (bool, error) {
+	if bundleID == prodID {
+		return true, nil
+	}
+
+	if visited[bundleID] {
+		return false, nil
+	}
+	visited[bundleID] = true
+
+	relRepo := productrelationrepository.NewProductRelationRepository()
+	relations, err := relRepo.GetByProduct(ctx, bundleID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, rel := range relations {
+		if !rel.IsBundle() {
+			continue
+		}
+
+		contains, err := relSvc.containsProduct(ctx, rel.GetRelatedID(), prodID, visited)
+		if err != nil || contains {
+			return contains, err
+		}
+	}
+
+	return false, nil
+}
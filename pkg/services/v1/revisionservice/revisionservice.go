@@ -0,0 +1,235 @@
+package revisionservice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cataloguemodel "github.com/bungysheep/catalogue-api/pkg/models/v1/catalogue"
+	productmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/product"
+	revisionmodel "github.com/bungysheep/catalogue-api/pkg/models/v1/revision"
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/cataloguerepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/productrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/revisionproductrepository"
+	"github.com/bungysheep/catalogue-api/pkg/repositories/v1/revisionrepository"
+)
+
+var (
+	// ErrRevisionNotCreated - Catalogue revision was not created
+	ErrRevisionNotCreated = errors.New("Catalogue Revision was not created.")
+
+	// ErrRevisionLabelExists - Catalogue already has a revision of the label
+	ErrRevisionLabelExists = errors.New("Catalogue Revision label already exists.")
+
+	// ErrInvalidRevision - Revision is neither a number nor draft
+	ErrInvalidRevision = errors.New("Invalid revision.")
+
+	// ErrRevisionNotFound - Catalogue has no revision of the number
+	ErrRevisionNotFound = errors.New("Catalogue Revision does not exist.")
+)
+
+// IRevisionService type
+type IRevisionService interface {
+	Publish(context.Context, *cataloguemodel.Catalogue, string, string) (*revisionmodel.Revision, error)
+	GetForReading(context.Context, string, string) (*revisionmodel.Revision, error)
+	GetProduct(context.Context, *revisionmodel.Revision, int64) (*productmodel.Product, error)
+	GetProductByCode(context.Context, *revisionmodel.Revision, string) (*productmodel.Product, error)
+	LoadProducts(context.Context, *revisionmodel.Revision) error
+}
+
+type revisionService struct {
+}
+
+// NewRevisionService - Create revision service
+func NewRevisionService() IRevisionService {
+	return &revisionService{}
+}
+
+// Publish - Freezes catalogue and its products as next revision labelled label
+func (revSvc *revisionService) Publish(ctx context.Context, clg *cataloguemodel.Catalogue, label string, username string) (*revisionmodel.Revision, error) {
+	var result *revisionmodel.Revision
+	err := database.WithTransactionOptions(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead}, func(ctx context.Context) error {
+		var err error
+		result, err = revSvc.publish(ctx, clg.GetCode(), label, username)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (revSvc *revisionService) publish(ctx context.Context, clgCode string, label string, username string) (*revisionmodel.Revision, error) {
+	revRepo := revisionrepository.NewRevisionRepository()
+	if err := revRepo.Lock(ctx); err != nil {
+		return nil, err
+	}
+
+	clgRepo := cataloguerepository.NewCatalogueRepository()
+	clg, err := clgRepo.GetByID(ctx, clgCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if clg == nil {
+		return nil, ErrRevisionNotCreated
+	}
+
+	if label != "" {
+		existing, err := revRepo.GetByLabel(ctx, clg.GetCode(), label)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing != nil {
+			return nil, ErrRevisionLabelExists
+		}
+	}
+
+	lastRevision, err := revRepo.GetLastRevision(ctx, clg.GetCode())
+	if err != nil {
+		return nil, err
+	}
+
+	prodIDs := make([]int64, 0)
+	prodRepo := productrepository.NewProductRepository()
+	err = prodRepo.ForEachFamilyByCatalogue(ctx, clg.GetCode(), func(product *productmodel.Product) error {
+		prodIDs = append(prodIDs, product.GetID())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newRev := revisionmodel.NewRevision()
+	newRev.CatalogueCode = clg.GetCode()
+	newRev.Revision = lastRevision + 1
+	newRev.Label = label
+	if newRev.Label == "" {
+		newRev.Label = fmt.Sprintf("v%d", newRev.GetRevision())
+	}
+	newRev.NbrProducts = int64(len(prodIDs))
+	newRev.CreatedBy = username
+	newRev.CreatedAt = time.Now()
+	newRev.Snapshot = &revisionmodel.Snapshot{Catalogue: clg}
+
+	lastID, err := revRepo.Create(ctx, newRev)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastID == 0 {
+		return nil, ErrRevisionNotCreated
+	}
+
+	newRev.ID = lastID
+
+	// Listing holds products without their units of measure, custom fields and categories
+	revProdRepo := revisionproductrepository.NewRevisionProductRepository()
+	for i, prodID := range prodIDs {
+		product, err := prodRepo.GetByID(ctx, prodID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Snapshot of the transaction still holds every listed product
+		if product == nil {
+			return nil, ErrRevisionNotCreated
+		}
+
+		if err := revProdRepo.Create(ctx, newRev.GetID(), int64(i+1), product); err != nil {
+			return nil, err
+		}
+	}
+
+	return newRev, nil
+}
+
+// GetForReading - Returns revision products of catalogue are read from, nil for the draft
+func (revSvc *revisionService) GetForReading(ctx context.Context, clgCode string, revision string) (*revisionmodel.Revision, error) {
+	if revision == "draft" {
+		return nil, nil
+	}
+
+	revRepo := revisionrepository.NewRevisionRepository()
+	if revision == "" {
+		return revRepo.GetLatest(ctx, strings.ToUpper(clgCode))
+	}
+
+	number, err := strconv.ParseInt(revision, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidRevision
+	}
+
+	result, err := revRepo.GetByRevision(ctx, strings.ToUpper(clgCode), number)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	return result, nil
+}
+
+// GetProduct - Returns product as published in revision, variant along with the values it inherits from its parent
+func (revSvc *revisionService) GetProduct(ctx context.Context, rev *revisionmodel.Revision, id int64) (*productmodel.Product, error) {
+	revProdRepo := revisionproductrepository.NewRevisionProductRepository()
+	prod, err := revProdRepo.GetByID(ctx, rev.GetID(), id)
+	if err != nil || prod == nil || !prod.IsVariant() {
+		return prod, err
+	}
+
+	return inheritParent(ctx, rev, prod)
+}
+
+// GetProductByCode - Returns product of code as published in revision
+func (revSvc *revisionService) GetProductByCode(ctx context.Context, rev *revisionmodel.Revision, code string) (*productmodel.Product, error) {
+	revProdRepo := revisionproductrepository.NewRevisionProductRepository()
+	prod, err := revProdRepo.GetByCode(ctx, rev.GetID(), strings.ToUpper(code))
+	if err != nil || prod == nil || !prod.IsVariant() {
+		return prod, err
+	}
+
+	return inheritParent(ctx, rev, prod)
+}
+
+// LoadProducts - Loads all products published in revision into its snapshot
+func (revSvc *revisionService) LoadProducts(ctx context.Context, rev *revisionmodel.Revision) error {
+	products := make([]*productmodel.Product, 0, rev.GetNbrProducts())
+
+	revProdRepo := revisionproductrepository.NewRevisionProductRepository()
+	err := revProdRepo.ForEachByRevision(ctx, rev.GetID(), func(product *productmodel.Product) error {
+		products = append(products, product)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rev.GetSnapshot().Products = products
+
+	return nil
+}
+
+// inheritParent - Returns variant along with the values it inherits from its parent as published in revision
+func inheritParent(ctx context.Context, rev *revisionmodel.Revision, prod *productmodel.Product) (*productmodel.Product, error) {
+	revProdRepo := revisionproductrepository.NewRevisionProductRepository()
+	parent, err := revProdRepo.GetByID(ctx, rev.GetID(), prod.GetParentID())
+	if err != nil {
+		return nil, err
+	}
+
+	if parent != nil {
+		prod.Inherit(parent)
+	}
+
+	return prod, nil
+}
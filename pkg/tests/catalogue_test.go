@@ -17,18 +17,24 @@ func TestCatalogue(t *testing.T) {
 
 	t.Run("Get catalogue", getCatalogue)
 
+	t.Run("Get schema of unknown catalogue", getUnknownCatalogueSchema)
+
 	t.Run("Create catalogue", createCatalogue)
 
 	t.Run("Create catalogue without custom field definitions", createCatalogueWithoutFieldDef)
 
 	t.Run("Update catalogue with invalid version", updateCatalogueWithInvalidVersion)
 
+	t.Run("Update catalogue with stale If-Match", updateCatalogueWithStaleIfMatch)
+
 	t.Run("Update catalogue", updateCatalogue)
 
 	t.Run("Update catalogue with adding custom field definition", updateCatalogueWithAddingFieldDef)
 
 	t.Run("Update catalogue with deleting custom field definition", updateCatalogueWithDeletingFieldDef)
 
+	t.Run("Patch catalogue with merge patch", patchCatalogueWithMergePatch)
+
 	t.Run("Delete catalogue", deleteCatalogue)
 }
 
@@ -77,6 +83,7 @@ func getCatalogue(t *testing.T) {
 	resp, err := client.Do(req)
 	assert.NilError(t, err, "Failed to retrieve catalogue.")
 	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("ETag"), "\"1\"")
 
 	defer resp.Body.Close()
 
@@ -109,6 +116,37 @@ func getCatalogue(t *testing.T) {
 	assert.Equal(t, dataFieldDefOutput["change_mode"], float64(0))
 }
 
+func getUnknownCatalogueSchema(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://localhost:50051/v1/catalogues/CLG_UNKNOWN/schema", bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create get request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve catalogue schema.")
+	assert.Equal(t, resp.StatusCode, http.StatusNotFound)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "application/problem+json")
+	assert.Assert(t, resp.Header.Get("X-Correlation-ID") != "")
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(body, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], false)
+	assert.Equal(t, respData["type"], "/problems/not_found")
+	assert.Equal(t, respData["title"], "Not Found")
+	assert.Equal(t, respData["status"], float64(http.StatusNotFound))
+	assert.Equal(t, respData["code"], "not_found")
+	assert.Equal(t, respData["detail"], "Catalogue does not exist.")
+	assert.Equal(t, respData["correlation_id"], resp.Header.Get("X-Correlation-ID"))
+}
+
 func createCatalogue(t *testing.T) {
 	dataInput := map[string]interface{}{
 		"code":        "CLG_TEST",
@@ -267,7 +305,48 @@ func updateCatalogueWithInvalidVersion(t *testing.T) {
 
 	resp, err := client.Do(req)
 	assert.NilError(t, err, "Failed to update catalogue.")
-	assert.Equal(t, resp.StatusCode, http.StatusBadRequest)
+	assert.Equal(t, resp.StatusCode, http.StatusConflict)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], false)
+	assert.Equal(t, resp.Header.Get("ETag"), "\"1\"")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["vers"], float64(1))
+}
+
+func updateCatalogueWithStaleIfMatch(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST",
+		"description": "Catalogue Test - Updated",
+		"details":     "Catalogue Test - Updated",
+		"status":      "I",
+		"modified_at": time.Now(),
+		"vers":        1,
+	}
+
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	req, err := http.NewRequest("PUT", "http://localhost:50051/v1/catalogues/CLG_TEST", bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create update request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("If-Match", "\"2\"")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to update catalogue.")
+	assert.Equal(t, resp.StatusCode, http.StatusPreconditionFailed)
+	assert.Equal(t, resp.Header.Get("ETag"), "\"1\"")
 
 	defer resp.Body.Close()
 
@@ -489,6 +568,42 @@ func updateCatalogueWithDeletingFieldDef(t *testing.T) {
 	assert.Equal(t, len(dataFieldDefs), 3)
 }
 
+func patchCatalogueWithMergePatch(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"details": "Catalogue Test - Patched",
+	}
+
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	req, err := http.NewRequest("PATCH", "http://localhost:50051/v1/catalogues/CLG_TEST", bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create patch request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Content-Type", "application/merge-patch+json")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to patch catalogue.")
+	assert.Equal(t, resp.StatusCode, http.StatusAccepted)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], true)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["code"], "CLG_TEST")
+	assert.Equal(t, dataOutput["description"], "Catalogue Test - Updated")
+	assert.Equal(t, dataOutput["details"], "Catalogue Test - Patched")
+}
+
 func deleteCatalogue(t *testing.T) {
 	req, err := http.NewRequest("DELETE", "http://localhost:50051/v1/catalogues/CLG_TEST", bytes.NewBuffer([]byte("")))
 	assert.NilError(t, err, "Failed to create delete request.")
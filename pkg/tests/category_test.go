@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestCategory(t *testing.T) {
+	t.Run("Create categories", createCategories)
+
+	t.Run("Create duplicate category", createDuplicateCategory)
+
+	t.Run("Get category tree", getCategoryTree)
+
+	t.Run("Get category breadcrumb", getCategoryBreadcrumb)
+
+	t.Run("Assign product to categories", assignProductToCategories)
+
+	t.Run("Assign product to unknown category", assignProductToUnknownCategory)
+
+	t.Run("Get products of category", getProductsOfCategory)
+
+	t.Run("Move category beneath its descendant", moveCategoryBeneathDescendant)
+
+	t.Run("Move category with stale If-Match", moveCategoryWithStaleIfMatch)
+
+	t.Run("Move category", moveCategory)
+
+	t.Run("Delete category in use", deleteCategoryInUse)
+
+	t.Run("Delete category", deleteCategory)
+}
+
+func createCategories(t *testing.T) {
+	categories := []map[string]interface{}{
+		{"code": "office", "description": "Office"},
+		{"code": "WRITING", "description": "Writing", "parent_id": 1},
+		{"code": "PAPER", "description": "Paper", "parent_id": 1},
+		{"code": "PENCILS", "description": "Pencils", "parent_id": 2},
+	}
+
+	for _, dataInput := range categories {
+		respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues/CLG_TEST_1/categories", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+		assert.Equal(t, respData["message"], "Category has been created.")
+	}
+
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/categories/4", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["clg_code"], "CLG_TEST_1")
+	assert.Equal(t, dataOutput["code"], "PENCILS")
+	assert.Equal(t, dataOutput["parent_id"], float64(2))
+	assert.Equal(t, dataOutput["path"], "/1/2/4/")
+	assert.Equal(t, dataOutput["created_by"], "TESTUSER")
+	assert.Equal(t, dataOutput["vers"], float64(1))
+}
+
+func createDuplicateCategory(t *testing.T) {
+	dataInput := map[string]interface{}{"code": "Paper", "description": "Paper", "parent_id": 2}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues/CLG_TEST_1/categories", "application/json", mustMarshal(t, dataInput), http.StatusConflict)
+	assert.Equal(t, respData["code"], "duplicate")
+	assert.Equal(t, respData["message"], "Category 'PAPER' already exists.")
+}
+
+func getCategoryTree(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/catalogues/CLG_TEST_1/categories?tree=true", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 1)
+
+	dataRoot := dataOutput[0].(map[string]interface{})
+	assert.Equal(t, dataRoot["code"], "OFFICE")
+
+	dataChildren := dataRoot["children"].([]interface{})
+	assert.Equal(t, len(dataChildren), 2)
+
+	dataWriting := dataChildren[0].(map[string]interface{})
+	assert.Equal(t, dataWriting["code"], "WRITING")
+	assert.Equal(t, len(dataWriting["children"].([]interface{})), 1)
+}
+
+func getCategoryBreadcrumb(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/categories/4/breadcrumb", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 3)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["code"], "OFFICE")
+	assert.Equal(t, dataOutput[1].(map[string]interface{})["code"], "WRITING")
+	assert.Equal(t, dataOutput[2].(map[string]interface{})["code"], "PENCILS")
+}
+
+func assignProductToCategories(t *testing.T) {
+	dataInput := map[string]interface{}{"category_ids": []int{4, 3}}
+
+	respData := sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/products/3", "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.DeepEqual(t, dataOutput["category_ids"], []interface{}{float64(3), float64(4)})
+}
+
+func assignProductToUnknownCategory(t *testing.T) {
+	dataInput := map[string]interface{}{"category_ids": []int{3, 99, 3}}
+
+	respData := sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/products/3", "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 2)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/category_ids/1")
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Category '99' is not in catalogue 'CLG_TEST_1'.")
+	assert.Equal(t, dataViolations[1].(map[string]interface{})["path"], "/category_ids/2")
+}
+
+func getProductsOfCategory(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/categories/1/products", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 1)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["code"], "P-0003")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/categories/2/products", "application/json", nil, http.StatusOK)
+
+	dataOutput = respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 1)
+}
+
+func moveCategoryBeneathDescendant(t *testing.T) {
+	dataInput := map[string]interface{}{"parent_id": 4}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/categories/2/move", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "Category 'WRITING' can not be moved beneath itself or its descendant 'PENCILS'.")
+}
+
+func moveCategoryWithStaleIfMatch(t *testing.T) {
+	dataInput := map[string]interface{}{"parent_id": 3}
+
+	resp := sendIfMatchRequest(t, "POST", "http://localhost:50051/v1/categories/2/move", mustMarshal(t, dataInput), "\"2\"")
+	assert.Equal(t, resp.StatusCode, http.StatusPreconditionFailed)
+	assert.Equal(t, resp.Header.Get("ETag"), "\"1\"")
+}
+
+func moveCategory(t *testing.T) {
+	dataInput := map[string]interface{}{"parent_id": 3}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/categories/2/move", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Category has been moved.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["parent_id"], float64(3))
+	assert.Equal(t, dataOutput["path"], "/1/3/2/")
+	assert.Equal(t, dataOutput["vers"], float64(2))
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/categories/4/breadcrumb", "application/json", nil, http.StatusOK)
+
+	dataBreadcrumb := respData["data"].([]interface{})
+	assert.Equal(t, len(dataBreadcrumb), 4)
+	assert.Equal(t, dataBreadcrumb[1].(map[string]interface{})["code"], "PAPER")
+	assert.Equal(t, dataBreadcrumb[3].(map[string]interface{})["path"], "/1/3/2/4/")
+}
+
+func deleteCategoryInUse(t *testing.T) {
+	respData := sendTypedRequest(t, "DELETE", "http://localhost:50051/v1/categories/2", "application/json", nil, http.StatusConflict)
+	assert.Equal(t, respData["message"], "Category has child categories, they must be moved or deleted first.")
+
+	respData = sendTypedRequest(t, "DELETE", "http://localhost:50051/v1/categories/4", "application/json", nil, http.StatusConflict)
+	assert.Equal(t, respData["message"], "Category has products assigned to it.")
+}
+
+func deleteCategory(t *testing.T) {
+	dataInput := map[string]interface{}{"category_ids": []int{3}}
+
+	respData := sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/products/3", "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.DeepEqual(t, dataOutput["category_ids"], []interface{}{float64(3)})
+
+	respData = sendTypedRequest(t, "DELETE", "http://localhost:50051/v1/categories/4", "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["message"], "Category has been deleted.")
+
+	sendTypedRequest(t, "GET", "http://localhost:50051/v1/categories/4", "application/json", nil, http.StatusNotFound)
+}
+
+func sendIfMatchRequest(t *testing.T, method string, url string, bodyReq []byte, ifMatch string) *http.Response {
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("If-Match", ifMatch)
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to submit request.")
+	resp.Body.Close()
+
+	return resp
+}
@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestPriceList(t *testing.T) {
+	t.Run("Create price list", createPriceList)
+
+	t.Run("Create invalid price list", createInvalidPriceList)
+
+	t.Run("Set product prices", setProductPrices)
+
+	t.Run("Set invalid product prices", setInvalidProductPrices)
+
+	t.Run("Get prices of price list", getPricesOfPriceList)
+
+	t.Run("Get product price", getProductPrice)
+
+	t.Run("Get product price by quantity break", getProductPriceByQuantityBreak)
+
+	t.Run("Get product price outside validity", getProductPriceOutsideValidity)
+
+	t.Run("Delete price list", deletePriceList)
+}
+
+func createPriceList(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "RETAIL_EUR",
+		"description": "Retail Euro",
+		"currency":    "EUR",
+		"segment":     "RETAIL",
+		"valid_from":  "2020-01-01T00:00:00Z",
+		"valid_to":    "2020-12-31T00:00:00Z",
+		"status":      "A",
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/pricelists", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Price List has been created.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["code"], "RETAIL_EUR")
+	assert.Equal(t, dataOutput["currency"], "EUR")
+	assert.Equal(t, dataOutput["segment"], "RETAIL")
+	assert.Equal(t, dataOutput["vers"], float64(1))
+}
+
+func createInvalidPriceList(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "RETAIL_BAD",
+		"description": "Retail Bad",
+		"currency":    "eur",
+		"valid_from":  "2020-01-01T00:00:00Z",
+		"valid_to":    "2019-12-31T00:00:00Z",
+		"status":      "A",
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/pricelists", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 2)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/currency")
+	assert.Equal(t, dataViolations[1].(map[string]interface{})["path"], "/valid_to")
+}
+
+func setProductPrices(t *testing.T) {
+	dataInput := []interface{}{
+		map[string]interface{}{"uom_code": "EACH", "min_qty": 0, "price": "1.50"},
+		map[string]interface{}{"uom_code": "EACH", "min_qty": 10, "price": "1.20"},
+		map[string]interface{}{"uom_code": "BOX", "min_qty": 0, "price": "2.80"},
+	}
+
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/pricelists/RETAIL_EUR/products/2/prices", "application/json", bodyReq, http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Product Prices have been set.")
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 3)
+
+	dataPriceOutput := dataOutput[0].(map[string]interface{})
+	assert.Equal(t, dataPriceOutput["uom_code"], "BOX")
+	assert.Equal(t, dataPriceOutput["price"], "2.80")
+}
+
+func setInvalidProductPrices(t *testing.T) {
+	dataInput := []interface{}{
+		map[string]interface{}{"uom_code": "EACH", "min_qty": 0, "price": "1.50"},
+		map[string]interface{}{"uom_code": "EACH", "min_qty": "0.0", "price": "1.40"},
+		map[string]interface{}{"uom_code": "PACK", "min_qty": 0, "price": "5.00"},
+		map[string]interface{}{"uom_code": "BOX", "min_qty": 0, "price": "-1"},
+	}
+
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/pricelists/RETAIL_EUR/products/2/prices", "application/json", bodyReq, http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 3)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/1/min_qty")
+	assert.Equal(t, dataViolations[1].(map[string]interface{})["path"], "/2/uom_code")
+	assert.Equal(t, dataViolations[1].(map[string]interface{})["message"], "Unit of Measure 'PACK' is not a unit of product.")
+	assert.Equal(t, dataViolations[2].(map[string]interface{})["path"], "/3/price")
+}
+
+func getPricesOfPriceList(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/pricelists/RETAIL_EUR/prices", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 3)
+}
+
+func getProductPrice(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/2/price?list=RETAIL_EUR&uom=BOX&qty=2.5&date=2020-06-01", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["currency"], "EUR")
+	assert.Equal(t, dataOutput["uom_code"], "BOX")
+	assert.Equal(t, dataOutput["unit_price"], "2.80")
+	assert.Equal(t, dataOutput["amount"], "7.00")
+}
+
+func getProductPriceByQuantityBreak(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/2/price?list=RETAIL_EUR&qty=9&date=2020-06-01", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["uom_code"], "EACH")
+	assert.Equal(t, dataOutput["unit_price"], "1.50")
+	assert.Equal(t, dataOutput["amount"], "13.50")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/2/price?list=RETAIL_EUR&qty=12&date=2020-06-01", "application/json", nil, http.StatusOK)
+
+	dataOutput = respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["min_qty"], "10")
+	assert.Equal(t, dataOutput["unit_price"], "1.20")
+	assert.Equal(t, dataOutput["amount"], "14.40")
+}
+
+func getProductPriceOutsideValidity(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/2/price?list=RETAIL_EUR&date=2021-01-01", "application/json", nil, http.StatusNotFound)
+	assert.Equal(t, respData["code"], "price_not_found")
+	assert.Equal(t, respData["message"], "Price list 'RETAIL_EUR' is not valid at 2021-01-01.")
+}
+
+func deletePriceList(t *testing.T) {
+	respData := sendTypedRequest(t, "DELETE", "http://localhost:50051/v1/pricelists/RETAIL_EUR", "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["message"], "Price List has been deleted.")
+
+	sendTypedRequest(t, "GET", "http://localhost:50051/v1/pricelists/RETAIL_EUR", "application/json", nil, http.StatusNotFound)
+}
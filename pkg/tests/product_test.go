@@ -15,6 +15,8 @@ import (
 func TestProduct(t *testing.T) {
 	t.Run("Get product by catalogue", getProductByCatalogue)
 
+	t.Run("Get product by catalogue as ndjson", getProductByCatalogueAsNDJSON)
+
 	t.Run("Get product", getProduct)
 
 	t.Run("Create product", createProduct)
@@ -27,10 +29,14 @@ func TestProduct(t *testing.T) {
 
 	t.Run("Create product without mandatory custom field", createProductWithoutMandatoryCustomField)
 
+	t.Run("Create product with multiple violations", createProductWithMultipleViolations)
+
 	t.Run("Create product with invalid custom field definition", createProductWithInvalidFieldDefinition)
 
 	t.Run("Update product with invalid version", updateProductWithInvalidVersion)
 
+	t.Run("Update product with stale If-Match", updateProductWithStaleIfMatch)
+
 	t.Run("Update product", updateProduct)
 
 	t.Run("Update product with adding uom", updateProductWithAddingUom)
@@ -41,6 +47,12 @@ func TestProduct(t *testing.T) {
 
 	t.Run("Update product with deleting and updating uom", updateProductWithDeletingAndUpdatingUom)
 
+	t.Run("Patch product with merge patch", patchProductWithMergePatch)
+
+	t.Run("Patch product with json patch", patchProductWithJSONPatch)
+
+	t.Run("Patch product with unsupported content type", patchProductWithUnsupportedContentType)
+
 	t.Run("Delete product", deleteProduct)
 }
 
@@ -78,6 +90,35 @@ func getProductByCatalogue(t *testing.T) {
 	assert.Equal(t, dataOutput["modified_by"], "TESTUSER")
 }
 
+func getProductByCatalogueAsNDJSON(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_1", bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create get all request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Accept", "application/x-ndjson")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve all products.")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "application/x-ndjson")
+
+	defer resp.Body.Close()
+
+	var data []map[string]interface{}
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var dataOutput map[string]interface{}
+		err = decoder.Decode(&dataOutput)
+		assert.NilError(t, err, "Failed to decode body response.")
+
+		data = append(data, dataOutput)
+	}
+	assert.Equal(t, len(data), 3)
+	assert.Equal(t, data[0]["code"], "P-0001")
+}
+
 func getProduct(t *testing.T) {
 	req, err := http.NewRequest("GET", "http://localhost:50051/v1/products/2", bytes.NewBuffer([]byte("")))
 	assert.NilError(t, err, "Failed to create get all request.")
@@ -89,6 +130,7 @@ func getProduct(t *testing.T) {
 	resp, err := client.Do(req)
 	assert.NilError(t, err, "Failed to retrieve product.")
 	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("ETag"), "\"1\"")
 
 	defer resp.Body.Close()
 
@@ -488,6 +530,81 @@ func createProductWithoutMandatoryCustomField(t *testing.T) {
 	assert.Equal(t, respData["success"], false)
 }
 
+func createProductWithMultipleViolations(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"clg_code":    "CLG_TEST_2",
+		"code":        "Q-0001",
+		"description": "",
+		"details":     "Hardisk",
+		"status":      "A",
+		"created_at":  time.Now(),
+		"modified_at": time.Now(),
+		"vers":        1,
+		"uoms": []interface{}{
+			map[string]interface{}{
+				"code":        "EACH",
+				"description": "Each",
+				"ratio":       1,
+				"vers":        1,
+				"change_mode": 1,
+			},
+			map[string]interface{}{
+				"code":        "BOX",
+				"description": "",
+				"ratio":       2,
+				"vers":        1,
+				"change_mode": 1,
+			},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{
+				"field_id":    4,
+				"alpha_value": "",
+			},
+		},
+	}
+
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	req, err := http.NewRequest("POST", "http://localhost:50051/v1/products", bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("X-Correlation-ID", "test-multiple-violations")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to create product.")
+	assert.Equal(t, resp.StatusCode, http.StatusBadRequest)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "application/problem+json")
+	assert.Equal(t, resp.Header.Get("X-Correlation-ID"), "test-multiple-violations")
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], false)
+	assert.Equal(t, respData["message"], "Description must be specified")
+	assert.Equal(t, respData["status"], float64(http.StatusBadRequest))
+	assert.Equal(t, respData["code"], "validation_failed")
+	assert.Equal(t, respData["detail"], "Description must be specified")
+	assert.Equal(t, respData["correlation_id"], "test-multiple-violations")
+	assert.Equal(t, len(respData["errors"].([]interface{})), 3)
+
+	dataViolations := respData["data"].([]interface{})
+	assert.Equal(t, len(dataViolations), 3)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/description")
+	assert.Equal(t, dataViolations[1].(map[string]interface{})["path"], "/uoms/1/description")
+	assert.Equal(t, dataViolations[2].(map[string]interface{})["path"], "/custom_fields/0/alpha_value")
+	assert.Equal(t, dataViolations[2].(map[string]interface{})["message"], "Custom Field 'Field-1' must be specified.")
+}
+
 func createProductWithInvalidFieldDefinition(t *testing.T) {
 	dataInput := map[string]interface{}{
 		"clg_code":    "CLG_TEST_2",
@@ -613,7 +730,48 @@ func updateProductWithInvalidVersion(t *testing.T) {
 
 	resp, err := client.Do(req)
 	assert.NilError(t, err, "Failed to update product.")
-	assert.Equal(t, resp.StatusCode, http.StatusBadRequest)
+	assert.Equal(t, resp.StatusCode, http.StatusConflict)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], false)
+	assert.Equal(t, resp.Header.Get("ETag"), "\"1\"")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["vers"], float64(1))
+}
+
+func updateProductWithStaleIfMatch(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "Q-0001",
+		"description": "Hardisk - Updated",
+		"details":     "Hardisk - Updated",
+		"status":      "A",
+		"modified_at": time.Now(),
+		"vers":        1,
+	}
+
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	req, err := http.NewRequest("PUT", "http://localhost:50051/v1/products/4", bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create update request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("If-Match", "\"2\"")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to update product.")
+	assert.Equal(t, resp.StatusCode, http.StatusPreconditionFailed)
+	assert.Equal(t, resp.Header.Get("ETag"), "\"1\"")
 
 	defer resp.Body.Close()
 
@@ -1140,6 +1298,129 @@ func updateProductWithDeletingAndUpdatingUom(t *testing.T) {
 	assert.Equal(t, dateValue.Format(configs.SHORTDATEFORMAT), "2000-01-01")
 }
 
+func patchProductWithMergePatch(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"description": "Hardisk - Patched",
+	}
+
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	req, err := http.NewRequest("PATCH", "http://localhost:50051/v1/products/4", bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create patch request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Content-Type", "application/merge-patch+json")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to patch product.")
+	assert.Equal(t, resp.StatusCode, http.StatusAccepted)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], true)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["code"], "Q-0001")
+	assert.Equal(t, dataOutput["description"], "Hardisk - Patched")
+	assert.Equal(t, dataOutput["details"], "Hardisk - Updated")
+	assert.Equal(t, dataOutput["vers"], float64(7))
+
+	dataUoms := dataOutput["uoms"].([]interface{})
+	assert.Equal(t, len(dataUoms), 3)
+
+	dataFields := dataOutput["custom_fields"].([]interface{})
+	assert.Equal(t, len(dataFields), 3)
+}
+
+func patchProductWithJSONPatch(t *testing.T) {
+	dataInput := []interface{}{
+		map[string]interface{}{
+			"op":    "test",
+			"path":  "/description",
+			"value": "Hardisk - Patched",
+		},
+		map[string]interface{}{
+			"op":   "remove",
+			"path": "/uoms/2",
+		},
+		map[string]interface{}{
+			"op":    "replace",
+			"path":  "/custom_fields/0/alpha_value",
+			"value": "Custom Field - Patched",
+		},
+	}
+
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	req, err := http.NewRequest("PATCH", "http://localhost:50051/v1/products/4", bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create patch request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Content-Type", "application/json-patch+json")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to patch product.")
+	assert.Equal(t, resp.StatusCode, http.StatusAccepted)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], true)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["vers"], float64(8))
+
+	dataUoms := dataOutput["uoms"].([]interface{})
+	assert.Equal(t, len(dataUoms), 2)
+
+	dataFields := dataOutput["custom_fields"].([]interface{})
+	assert.Equal(t, len(dataFields), 3)
+
+	dataFieldOutput := dataFields[0].(map[string]interface{})
+	assert.Equal(t, dataFieldOutput["alpha_value"], "Custom Field - Patched")
+}
+
+func patchProductWithUnsupportedContentType(t *testing.T) {
+	req, err := http.NewRequest("PATCH", "http://localhost:50051/v1/products/4", bytes.NewBuffer([]byte("{}")))
+	assert.NilError(t, err, "Failed to create patch request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Content-Type", "application/json")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to patch product.")
+	assert.Equal(t, resp.StatusCode, http.StatusUnsupportedMediaType)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], false)
+}
+
 func deleteProduct(t *testing.T) {
 	req, err := http.NewRequest("DELETE", "http://localhost:50051/v1/products/4", bytes.NewBuffer([]byte("")))
 	assert.NilError(t, err, "Failed to create delete request.")
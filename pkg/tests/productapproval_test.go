@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/configs"
+	"github.com/bungysheep/catalogue-api/pkg/models/v1/signinclaimresource"
+	"github.com/dgrijalva/jwt-go"
+	"gotest.tools/assert"
+)
+
+var (
+	approvalProdID float64
+	approvalRoute  string
+)
+
+func TestProductApproval(t *testing.T) {
+	t.Run("Create approval catalogue", createApprovalCatalogue)
+
+	t.Run("Submit product change", submitProductChange)
+
+	t.Run("Submit product change while pending", submitProductChangeWhilePending)
+
+	t.Run("Review product change by submitter", reviewProductChangeBySubmitter)
+
+	t.Run("Approve product change", approveProductChange)
+
+	t.Run("Reject product change", rejectProductChange)
+
+	t.Run("Get product change history", getProductChangeHistory)
+}
+
+func createApprovalCatalogue(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":              "CLG_TEST_APR",
+		"description":       "Catalogue Test Approval",
+		"status":            "A",
+		"approval_required": true,
+		"vers":              1,
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "Catalogue requiring approval must have reviewers.")
+
+	dataInput["reviewers"] = []interface{}{"TESTUSER", "REVIEWER"}
+	sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataInput = map[string]interface{}{
+		"clg_code":    "CLG_TEST_APR",
+		"code":        "APR-0001",
+		"description": "Syringe",
+		"status":      "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+	}
+
+	respData = sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	approvalProdID = respData["data"].(map[string]interface{})["id"].(float64)
+	approvalRoute = fmt.Sprintf("http://localhost:50051/v1/products/%v", approvalProdID)
+}
+
+func submitProductChange(t *testing.T) {
+	dataInput := map[string]interface{}{"description": "Sterile Syringe"}
+
+	respData := sendTypedRequest(t, "PATCH", approvalRoute, "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Product change has been submitted for approval.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["status"], "P")
+	assert.Equal(t, dataOutput["submitted_by"], "TESTUSER")
+	assert.Equal(t, dataOutput["product"].(map[string]interface{})["description"], "Sterile Syringe")
+
+	respData = sendTypedRequest(t, "GET", approvalRoute, "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["description"], "Syringe")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/catalogues/CLG_TEST_APR/changes", "application/json", nil, http.StatusOK)
+	assert.Equal(t, len(respData["data"].([]interface{})), 1)
+}
+
+func submitProductChangeWhilePending(t *testing.T) {
+	dataInput := map[string]interface{}{"description": "Plastic Syringe"}
+
+	respData := sendTypedRequest(t, "PATCH", approvalRoute, "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusConflict)
+	assert.Equal(t, respData["message"], "Product has a pending change request.")
+}
+
+func reviewProductChangeBySubmitter(t *testing.T) {
+	respData := sendTypedRequest(t, "POST", approvalRoute+"/changes/1/approve", "application/json", nil, http.StatusForbidden)
+	assert.Equal(t, respData["code"], "forbidden")
+	assert.Equal(t, respData["message"], "Product Change Request can not be reviewed by its submitter.")
+}
+
+func approveProductChange(t *testing.T) {
+	defer useAccessToken(t, "REVIEWER")()
+
+	dataInput := map[string]interface{}{"comment": "Checked against supplier sheet."}
+
+	respData := sendTypedRequest(t, "POST", approvalRoute+"/changes/1/approve", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Product change has been approved.")
+
+	respData = sendTypedRequest(t, "GET", approvalRoute, "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["description"], "Sterile Syringe")
+	assert.Equal(t, dataOutput["modified_by"], "TESTUSER")
+
+	sendTypedRequest(t, "POST", approvalRoute+"/changes/1/reject", "application/json", mustMarshal(t, dataInput), http.StatusConflict)
+}
+
+func rejectProductChange(t *testing.T) {
+	dataInput := map[string]interface{}{"description": "Plastic Syringe"}
+	sendTypedRequest(t, "PATCH", approvalRoute, "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	defer useAccessToken(t, "REVIEWER")()
+
+	respData := sendTypedRequest(t, "POST", approvalRoute+"/changes/2/reject", "application/json", nil, http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "Comment must be specified when rejecting a change.")
+
+	dataInput = map[string]interface{}{"comment": "Material is not approved."}
+	respData = sendTypedRequest(t, "POST", approvalRoute+"/changes/2/reject", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Product change has been rejected.")
+
+	respData = sendTypedRequest(t, "GET", approvalRoute, "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["description"], "Sterile Syringe")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/catalogues/CLG_TEST_APR/changes", "application/json", nil, http.StatusOK)
+	assert.Equal(t, len(respData["data"].([]interface{})), 0)
+}
+
+func getProductChangeHistory(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", approvalRoute+"/changes", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 2)
+
+	rejected := dataOutput[0].(map[string]interface{})
+	assert.Equal(t, rejected["status"], "R")
+	assert.Equal(t, rejected["reviewed_by"], "REVIEWER")
+	assert.Equal(t, rejected["comment"], "Material is not approved.")
+
+	approved := dataOutput[1].(map[string]interface{})
+	assert.Equal(t, approved["status"], "A")
+	assert.Equal(t, approved["reviewed_by"], "REVIEWER")
+	assert.Equal(t, approved["submitted_by"], "TESTUSER")
+}
+
+// useAccessToken - Sends requests as username until returned function restores test user
+func useAccessToken(t *testing.T, username string) func() {
+	signInToken := &signinclaimresource.SignInClaimResource{
+		Username: username,
+		Status:   "A",
+		StandardClaims: &jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(15 * time.Minute).Unix(),
+		},
+	}
+
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, signInToken).SignedString([]byte(configs.TOKENSIGNKEY))
+	assert.NilError(t, err, "Failed to sign token.")
+
+	testToken := accessTokenTest
+	accessTokenTest = "Bearer " + signedToken
+
+	return func() {
+		accessTokenTest = testToken
+	}
+}
@@ -0,0 +1,194 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var (
+	categoryIDs      = make(map[string]float64)
+	brandFieldID     float64
+	voltageFieldID   float64
+	categoryClgVers  float64
+	categoryClgRoute = "http://localhost:50051/v1/catalogues/CLG_TEST_CAT"
+)
+
+func TestProductCategoryField(t *testing.T) {
+	t.Run("Create catalogue with categories", createCatalogueWithCategories)
+
+	t.Run("Attach custom field definition to unknown category", attachFieldDefToUnknownCategory)
+
+	t.Run("Attach custom field definition to category", attachFieldDefToCategory)
+
+	t.Run("Create product of sub-category without inherited custom field", createProductWithoutInheritedField)
+
+	t.Run("Create product of sub-category with inherited custom field", createProductWithInheritedField)
+
+	t.Run("Create product with custom field of other category", createProductWithInapplicableField)
+
+	t.Run("Create product of other category", createProductOfOtherCategory)
+
+	t.Run("Delete category used by custom field definition", deleteCategoryUsedByFieldDef)
+}
+
+func createCatalogueWithCategories(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_CAT",
+		"description": "Catalogue Test Categories",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "Brand", "type": "A", "change_mode": 1},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	brandFieldID = dataOutput["field_definitions"].([]interface{})[0].(map[string]interface{})["id"].(float64)
+	categoryClgVers = dataOutput["vers"].(float64)
+
+	categories := []map[string]interface{}{
+		{"code": "ELECTRICAL", "description": "Electrical"},
+		{"code": "LAMPS", "description": "Lamps", "parent": "ELECTRICAL"},
+		{"code": "CLOTHING", "description": "Clothing"},
+		{"code": "BULBS", "description": "Bulbs", "parent": "ELECTRICAL"},
+	}
+
+	for _, category := range categories {
+		if parent, ok := category["parent"]; ok {
+			category["parent_id"] = categoryIDs[parent.(string)]
+		}
+
+		respData := sendTypedRequest(t, "POST", categoryClgRoute+"/categories", "application/json", mustMarshal(t, category), http.StatusAccepted)
+		categoryIDs[category["code"].(string)] = respData["data"].(map[string]interface{})["id"].(float64)
+	}
+}
+
+func attachFieldDefToUnknownCategory(t *testing.T) {
+	dataInput := newCategorisedCatalogue(map[string]interface{}{
+		"caption": "Voltage", "type": "A", "mandatory": true, "category_id": 9999, "change_mode": 1,
+	})
+
+	respData := sendTypedRequest(t, "PUT", categoryClgRoute, "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 1)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/field_definitions/1/category_id")
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Custom Field 'Voltage' refers to unknown category '9999'.")
+}
+
+func attachFieldDefToCategory(t *testing.T) {
+	dataInput := newCategorisedCatalogue(map[string]interface{}{
+		"caption": "Voltage", "type": "A", "mandatory": true, "category_id": categoryIDs["ELECTRICAL"], "change_mode": 1,
+	})
+
+	respData := sendTypedRequest(t, "PUT", categoryClgRoute, "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	dataFieldDefs := dataOutput["field_definitions"].([]interface{})
+	assert.Equal(t, len(dataFieldDefs), 2)
+
+	dataVoltage := dataFieldDefs[1].(map[string]interface{})
+	assert.Equal(t, dataVoltage["caption"], "Voltage")
+	assert.Equal(t, dataVoltage["category_id"], categoryIDs["ELECTRICAL"])
+
+	voltageFieldID = dataVoltage["id"].(float64)
+	categoryClgVers = dataOutput["vers"].(float64)
+}
+
+func createProductWithoutInheritedField(t *testing.T) {
+	dataInput := newCategorisedProduct("L-0001", "LAMPS", []interface{}{
+		map[string]interface{}{"field_id": brandFieldID, "alpha_value": "Lumo"},
+	})
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 1)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/custom_fields")
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Custom Field 'Voltage' must be specified.")
+}
+
+func createProductWithInheritedField(t *testing.T) {
+	dataInput := newCategorisedProduct("L-0001", "LAMPS", []interface{}{
+		map[string]interface{}{"field_id": brandFieldID, "alpha_value": "Lumo"},
+		map[string]interface{}{"field_id": voltageFieldID, "alpha_value": "230V"},
+	})
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, len(dataOutput["custom_fields"].([]interface{})), 2)
+	assert.DeepEqual(t, dataOutput["category_ids"], []interface{}{categoryIDs["LAMPS"]})
+}
+
+func createProductWithInapplicableField(t *testing.T) {
+	dataInput := newCategorisedProduct("C-0001", "CLOTHING", []interface{}{
+		map[string]interface{}{"field_id": brandFieldID, "alpha_value": "Cotto"},
+		map[string]interface{}{"field_id": voltageFieldID, "alpha_value": "230V"},
+	})
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 1)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/custom_fields/1/field_id")
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Custom Field 'Voltage' does not apply to categories of product.")
+}
+
+func createProductOfOtherCategory(t *testing.T) {
+	dataInput := newCategorisedProduct("C-0001", "CLOTHING", []interface{}{
+		map[string]interface{}{"field_id": brandFieldID, "alpha_value": "Cotto"},
+	})
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, len(dataOutput["custom_fields"].([]interface{})), 1)
+}
+
+func deleteCategoryUsedByFieldDef(t *testing.T) {
+	// Bulbs has neither children nor products, only its custom field definition prevents deletion
+	dataInput := newCategorisedCatalogue(map[string]interface{}{
+		"id": voltageFieldID, "caption": "Voltage", "type": "A", "mandatory": true, "category_id": categoryIDs["BULBS"], "change_mode": 2,
+	})
+
+	sendTypedRequest(t, "PUT", categoryClgRoute, "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	url := fmt.Sprintf("http://localhost:50051/v1/categories/%v", categoryIDs["BULBS"])
+	respData := sendTypedRequest(t, "DELETE", url, "application/json", nil, http.StatusConflict)
+	assert.Equal(t, respData["message"], "Category is used by Custom Field 'Voltage'.")
+}
+
+func newCategorisedCatalogue(fieldDef map[string]interface{}) map[string]interface{} {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_CAT",
+		"description": "Catalogue Test Categories",
+		"status":      "A",
+		"vers":        categoryClgVers,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"id": brandFieldID, "caption": "Brand", "type": "A", "change_mode": 0},
+			fieldDef,
+		},
+	}
+
+	return dataInput
+}
+
+func newCategorisedProduct(code string, category string, fields []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"clg_code":     "CLG_TEST_CAT",
+		"code":         code,
+		"description":  code,
+		"status":       "A",
+		"category_ids": []interface{}{categoryIDs[category]},
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+		"custom_fields": fields,
+	}
+}
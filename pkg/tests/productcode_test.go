@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var codeProdID float64
+
+func TestProductCode(t *testing.T) {
+	t.Run("Create code catalogue", createCodeCatalogue)
+
+	t.Run("Create product with existing code", createProductWithExistingCode)
+
+	t.Run("Get product by code", getProductByCode)
+
+	t.Run("Upsert new product", upsertNewProduct)
+
+	t.Run("Upsert existing product", upsertExistingProduct)
+
+	t.Run("Upsert product with stale If-Match", upsertProductWithStaleIfMatch)
+}
+
+func createCodeCatalogue(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_CODE",
+		"description": "Catalogue Test Code",
+		"status":      "A",
+		"vers":        1,
+	}
+
+	sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, newCodeProduct("Spanner")), http.StatusAccepted)
+	codeProdID = respData["data"].(map[string]interface{})["id"].(float64)
+}
+
+func createProductWithExistingCode(t *testing.T) {
+	dataInput := newCodeProduct("Wrench")
+	dataInput["code"] = "cd-0001"
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusConflict)
+	assert.Equal(t, respData["code"], "duplicate")
+	assert.Equal(t, respData["message"], "Product 'CD-0001' already exists in catalogue 'CLG_TEST_CODE'.")
+}
+
+func getProductByCode(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/catalogues/CLG_TEST_CODE/products/cd-0001", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["id"], codeProdID)
+	assert.Equal(t, dataOutput["description"], "Spanner")
+
+	sendTypedRequest(t, "GET", "http://localhost:50051/v1/catalogues/CLG_TEST_CODE/products/CD-0009", "application/json", nil, http.StatusNotFound)
+}
+
+func upsertNewProduct(t *testing.T) {
+	dataInput := newCodeProduct("Pliers")
+	delete(dataInput, "code")
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/catalogues/CLG_TEST_CODE/products/CD-0002", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Product has been created.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["code"], "CD-0002")
+	assert.Equal(t, dataOutput["vers"], float64(1))
+}
+
+func upsertExistingProduct(t *testing.T) {
+	dataInput := newCodeProduct("Pliers")
+	delete(dataInput, "code")
+
+	for i := 0; i < 2; i++ {
+		respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/catalogues/CLG_TEST_CODE/products/CD-0002", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+		assert.Equal(t, respData["message"], "Product has been updated.")
+
+		dataOutput := respData["data"].(map[string]interface{})
+		assert.Equal(t, dataOutput["description"], "Pliers")
+		assert.Equal(t, len(dataOutput["uoms"].([]interface{})), 1)
+	}
+
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_CODE", "application/json", nil, http.StatusOK)
+	assert.Equal(t, len(respData["data"].([]interface{})), 2)
+}
+
+func upsertProductWithStaleIfMatch(t *testing.T) {
+	bodyReq := mustMarshal(t, newCodeProduct("Long Nose Pliers"))
+
+	resp := sendIfMatchRequest(t, "PUT", "http://localhost:50051/v1/catalogues/CLG_TEST_CODE/products/CD-0002", bodyReq, "\"1\"")
+	assert.Equal(t, resp.StatusCode, http.StatusPreconditionFailed)
+	assert.Equal(t, resp.Header.Get("ETag"), "\"3\"")
+
+	resp = sendIfMatchRequest(t, "PUT", "http://localhost:50051/v1/catalogues/CLG_TEST_CODE/products/CD-0003", bodyReq, "\"1\"")
+	assert.Equal(t, resp.StatusCode, http.StatusPreconditionFailed)
+}
+
+func newCodeProduct(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"clg_code":    "CLG_TEST_CODE",
+		"code":        "CD-0001",
+		"description": description,
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+	}
+}
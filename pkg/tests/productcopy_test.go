@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var (
+	copyProdID     float64
+	copyCategoryID float64
+)
+
+func TestProductCopy(t *testing.T) {
+	t.Run("Create copy catalogue", createCopyCatalogue)
+
+	t.Run("Clone catalogue", cloneCatalogue)
+
+	t.Run("Clone catalogue with existing code", cloneCatalogueWithExistingCode)
+
+	t.Run("Copy product to catalogue", copyProductToCatalogue)
+
+	t.Run("Copy product to catalogue with existing code", copyProductToCatalogueWithExistingCode)
+
+	t.Run("Copy product to unknown catalogue", copyProductToUnknownCatalogue)
+}
+
+func createCopyCatalogue(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_CPY",
+		"description": "Catalogue Test Copy",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "Colour", "type": "A", "change_mode": 1},
+			map[string]interface{}{"caption": "Weight", "type": "N", "change_mode": 1},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	fieldDefs := respData["data"].(map[string]interface{})["field_definitions"].([]interface{})
+
+	dataInput = map[string]interface{}{"code": "TOOLS", "description": "Tools"}
+	respData = sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues/CLG_TEST_CPY/categories", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	copyCategoryID = respData["data"].(map[string]interface{})["id"].(float64)
+
+	dataInput = map[string]interface{}{
+		"clg_code":     "CLG_TEST_CPY",
+		"code":         "CPY-0001",
+		"description":  "Hammer",
+		"status":       "A",
+		"category_ids": []interface{}{copyCategoryID},
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": fieldDefs[0].(map[string]interface{})["id"], "alpha_value": "Red", "change_mode": 1},
+			map[string]interface{}{"field_id": fieldDefs[1].(map[string]interface{})["id"], "numeric_value": 1.5, "change_mode": 1},
+		},
+	}
+
+	respData = sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	copyProdID = respData["data"].(map[string]interface{})["id"].(float64)
+}
+
+func cloneCatalogue(t *testing.T) {
+	dataInput := map[string]interface{}{"code": "CLG_TEST_CPY_2", "include_products": true}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues/CLG_TEST_CPY/clone", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Catalogue has been cloned.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["code"], "CLG_TEST_CPY_2")
+	assert.Equal(t, dataOutput["description"], "Catalogue Test Copy")
+	assert.Equal(t, len(dataOutput["categories"].([]interface{})), 1)
+
+	fieldDefs := dataOutput["field_definitions"].([]interface{})
+	assert.Equal(t, len(fieldDefs), 2)
+	assert.Equal(t, fieldDefs[0].(map[string]interface{})["caption"], "Colour")
+	assert.Equal(t, fieldDefs[0].(map[string]interface{})["clg_code"], "CLG_TEST_CPY_2")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_CPY_2", "application/json", nil, http.StatusOK)
+
+	dataProducts := respData["data"].([]interface{})
+	assert.Equal(t, len(dataProducts), 1)
+
+	clonedID := dataProducts[0].(map[string]interface{})["id"].(float64)
+	assert.Assert(t, clonedID != copyProdID)
+
+	respData = sendTypedRequest(t, "GET", fmt.Sprintf("http://localhost:50051/v1/products/%v", clonedID), "application/json", nil, http.StatusOK)
+
+	dataProduct := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataProduct["code"], "CPY-0001")
+	assert.Equal(t, len(dataProduct["uoms"].([]interface{})), 1)
+	assert.Equal(t, len(dataProduct["category_ids"].([]interface{})), 1)
+	assert.Assert(t, dataProduct["category_ids"].([]interface{})[0] != copyCategoryID)
+
+	dataFields := dataProduct["custom_fields"].([]interface{})
+	assert.Equal(t, len(dataFields), 2)
+	assert.Equal(t, dataFields[0].(map[string]interface{})["field_id"], fieldDefs[0].(map[string]interface{})["id"])
+	assert.Equal(t, dataFields[0].(map[string]interface{})["alpha_value"], "Red")
+}
+
+func cloneCatalogueWithExistingCode(t *testing.T) {
+	dataInput := map[string]interface{}{"code": "CLG_TEST_CPY_2"}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues/CLG_TEST_CPY/clone", "application/json", mustMarshal(t, dataInput), http.StatusConflict)
+	assert.Equal(t, respData["code"], "duplicate")
+	assert.Equal(t, respData["message"], "Catalogue 'CLG_TEST_CPY_2' already exists.")
+}
+
+func copyProductToCatalogue(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_CPY_3",
+		"description": "Catalogue Test Copy 3",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "COLOUR", "type": "A", "change_mode": 1},
+			map[string]interface{}{"caption": "Weight", "type": "I", "change_mode": 1},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	fieldDefs := respData["data"].(map[string]interface{})["field_definitions"].([]interface{})
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v/copy?to=CLG_TEST_CPY_3", copyProdID)
+	respData = sendTypedRequest(t, "POST", url, "application/json", nil, http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Product has been copied.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.DeepEqual(t, dataOutput["unmapped_fields"], []interface{}{"Weight"})
+	assert.DeepEqual(t, dataOutput["unmapped_categories"], []interface{}{"TOOLS"})
+
+	dataProduct := dataOutput["product"].(map[string]interface{})
+	assert.Equal(t, dataProduct["clg_code"], "CLG_TEST_CPY_3")
+	assert.Equal(t, dataProduct["code"], "CPY-0001")
+
+	dataFields := dataProduct["custom_fields"].([]interface{})
+	assert.Equal(t, len(dataFields), 1)
+	assert.Equal(t, dataFields[0].(map[string]interface{})["field_id"], fieldDefs[0].(map[string]interface{})["id"])
+	assert.Equal(t, dataFields[0].(map[string]interface{})["alpha_value"], "Red")
+}
+
+func copyProductToCatalogueWithExistingCode(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v/copy?to=CLG_TEST_CPY_3", copyProdID)
+
+	respData := sendTypedRequest(t, "POST", url, "application/json", nil, http.StatusConflict)
+	assert.Equal(t, respData["code"], "duplicate")
+	assert.Equal(t, respData["message"], "Product 'CPY-0001' already exists in catalogue 'CLG_TEST_CPY_3'.")
+}
+
+func copyProductToUnknownCatalogue(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v/copy?to=CLG_TEST_CPY_9", copyProdID)
+	sendTypedRequest(t, "POST", url, "application/json", nil, http.StatusNotFound)
+}
@@ -0,0 +1,664 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+var typedFieldIDs = make(map[string]float64)
+
+var typedProductID float64
+
+var groupedFieldIDs = make(map[string]float64)
+
+func TestProductCustomField(t *testing.T) {
+	t.Run("Create catalogue with typed custom fields", createCatalogueWithTypedCustomFields)
+
+	t.Run("Get product schema of catalogue", getProductSchema)
+
+	t.Run("Create catalogue with invalid decimal precision", createCatalogueWithInvalidDecimalPrecision)
+
+	t.Run("Create product with typed custom fields", createProductWithTypedCustomFields)
+
+	t.Run("Create product with too many decimal places", createProductWithTooManyDecimalPlaces)
+
+	t.Run("Create product with invalid email", createProductWithInvalidEmail)
+
+	t.Run("Create product with invalid url", createProductWithInvalidURL)
+
+	t.Run("Create product with invalid option", createProductWithInvalidOption)
+
+	t.Run("Create catalogue with invalid value range", createCatalogueWithInvalidValueRange)
+
+	t.Run("Create product violating custom field rules", createProductViolatingCustomFieldRules)
+
+	t.Run("Create product with duplicate unique custom field", createProductWithDuplicateUniqueCustomField)
+
+	t.Run("Delete option used by products", deleteOptionUsedByProducts)
+
+	t.Run("Deactivate option used by products", deactivateOptionUsedByProducts)
+
+	t.Run("Create product with inactive option", createProductWithInactiveOption)
+
+	t.Run("Update product keeping inactive option", updateProductKeepingInactiveOption)
+
+	t.Run("Add mandatory custom field without default value", addMandatoryCustomFieldWithoutDefault)
+
+	t.Run("Add mandatory custom field with default value", addMandatoryCustomFieldWithDefault)
+
+	t.Run("Create product with default custom field value", createProductWithDefaultCustomFieldValue)
+
+	t.Run("Change custom field type blocked by products", changeCustomFieldTypeBlockedByProducts)
+
+	t.Run("Change custom field type converting values", changeCustomFieldTypeConvertingValues)
+
+	t.Run("Create catalogue with custom field groups", createCatalogueWithFieldGroups)
+
+	t.Run("Create catalogue with unknown custom field group", createCatalogueWithUnknownFieldGroup)
+
+	t.Run("Reorder custom field definitions", reorderCustomFieldDefinitions)
+
+	t.Run("Reorder custom field definitions partially", reorderCustomFieldDefinitionsPartially)
+}
+
+func createCatalogueWithTypedCustomFields(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_TYPES",
+		"description": "Catalogue Test Types",
+		"details":     "Catalogue Test Types",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "Discontinued", "type": "B", "change_mode": 1},
+			map[string]interface{}{"caption": "Pack Size", "type": "I", "mandatory": true, "change_mode": 1},
+			map[string]interface{}{"caption": "Weight", "type": "F", "precision": 2, "change_mode": 1},
+			map[string]interface{}{"caption": "Website", "type": "U", "change_mode": 1},
+			map[string]interface{}{"caption": "Support Email", "type": "E", "change_mode": 1},
+			map[string]interface{}{
+				"caption":     "Colour",
+				"type":        "P",
+				"change_mode": 1,
+				"options": []interface{}{
+					map[string]interface{}{"code": "RED", "label": "Red", "sort_order": 1},
+					map[string]interface{}{"code": "BLUE", "label": "Blue", "sort_order": 2},
+				},
+			},
+			map[string]interface{}{
+				"caption":     "Material",
+				"type":        "M",
+				"change_mode": 1,
+				"options": []interface{}{
+					map[string]interface{}{"code": "COTTON", "label": "Cotton", "sort_order": 1},
+					map[string]interface{}{"code": "WOOL", "label": "Wool", "sort_order": 2},
+				},
+			},
+			map[string]interface{}{
+				"caption":     "SKU",
+				"type":        "A",
+				"min_length":  3,
+				"max_length":  10,
+				"pattern":     "^[A-Z0-9-]+$",
+				"unique":      true,
+				"change_mode": 1,
+			},
+			map[string]interface{}{
+				"caption":     "Length",
+				"type":        "F",
+				"precision":   2,
+				"min_value":   "0.5",
+				"max_value":   "100",
+				"step":        "0.25",
+				"change_mode": 1,
+			},
+			map[string]interface{}{
+				"caption":     "Launch Date",
+				"type":        "D",
+				"min_date":    "2020-01-01T00:00:00Z",
+				"change_mode": 1,
+			},
+		},
+	}
+
+	respData := postTypedRequest(t, "http://localhost:50051/v1/catalogues", dataInput, http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	dataFieldDefs := dataOutput["field_definitions"].([]interface{})
+	assert.Equal(t, len(dataFieldDefs), 10)
+
+	dataOptions := dataFieldDefs[5].(map[string]interface{})["options"].([]interface{})
+	assert.Equal(t, len(dataOptions), 2)
+	assert.Equal(t, dataOptions[0].(map[string]interface{})["code"], "RED")
+	assert.Equal(t, dataOptions[0].(map[string]interface{})["active"], true)
+
+	for _, item := range dataFieldDefs {
+		dataFieldDefOutput := item.(map[string]interface{})
+		typedFieldIDs[dataFieldDefOutput["caption"].(string)] = dataFieldDefOutput["id"].(float64)
+
+		if dataFieldDefOutput["caption"] == "Weight" {
+			assert.Equal(t, dataFieldDefOutput["precision"], float64(2))
+		} else if dataFieldDefOutput["caption"] != "Length" {
+			assert.Equal(t, dataFieldDefOutput["precision"], float64(0))
+		}
+
+		if dataFieldDefOutput["caption"] == "SKU" {
+			assert.Equal(t, dataFieldDefOutput["min_length"], float64(3))
+			assert.Equal(t, dataFieldDefOutput["pattern"], "^[A-Z0-9-]+$")
+			assert.Equal(t, dataFieldDefOutput["unique"], true)
+		}
+
+		if dataFieldDefOutput["caption"] == "Length" {
+			assert.Equal(t, dataFieldDefOutput["min_value"], "0.5")
+			assert.Equal(t, dataFieldDefOutput["step"], "0.25")
+		}
+	}
+}
+
+func getProductSchema(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://localhost:50051/v1/catalogues/CLG_TEST_TYPES/schema", bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve schema.")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "application/schema+json")
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var schema map[string]interface{}
+	err = json.Unmarshal(bodyResp, &schema)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, schema["$schema"], "https://json-schema.org/draft/2020-12/schema")
+	assert.DeepEqual(t, schema["required"], []interface{}{"clg_code", "description", "status", "uoms"})
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Equal(t, properties["clg_code"].(map[string]interface{})["const"], "CLG_TEST_TYPES")
+	assert.Equal(t, properties["description"].(map[string]interface{})["maxLength"], float64(32))
+
+	items := properties["custom_fields"].(map[string]interface{})["items"].(map[string]interface{})
+	fieldIDs := items["properties"].(map[string]interface{})["field_id"].(map[string]interface{})["enum"].([]interface{})
+	assert.Equal(t, len(fieldIDs), 10)
+
+	rules := items["allOf"].([]interface{})
+	assert.Equal(t, len(rules), 10)
+
+	packSize := rules[1].(map[string]interface{})["then"].(map[string]interface{})
+	assert.DeepEqual(t, packSize["required"], []interface{}{"integer_value"})
+
+	sku := rules[7].(map[string]interface{})["then"].(map[string]interface{})["properties"].(map[string]interface{})["alpha_value"].(map[string]interface{})
+	assert.Equal(t, sku["title"], "SKU")
+
+	skuRules := sku["anyOf"].([]interface{})[1].(map[string]interface{})
+	assert.Equal(t, skuRules["minLength"], float64(3))
+	assert.Equal(t, skuRules["maxLength"], float64(10))
+	assert.Equal(t, skuRules["pattern"], "^[A-Z0-9-]+$")
+
+	colour := rules[5].(map[string]interface{})["then"].(map[string]interface{})["properties"].(map[string]interface{})["alpha_value"].(map[string]interface{})
+	assert.DeepEqual(t, colour["anyOf"].([]interface{})[1].(map[string]interface{})["enum"], []interface{}{"RED", "BLUE"})
+}
+
+func createCatalogueWithInvalidDecimalPrecision(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_PREC",
+		"description": "Catalogue Test Precision",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "Weight", "type": "F", "precision": 11, "change_mode": 1},
+		},
+	}
+
+	postTypedRequest(t, "http://localhost:50051/v1/catalogues", dataInput, http.StatusBadRequest)
+}
+
+func createProductWithTypedCustomFields(t *testing.T) {
+	dataInput := newTypedProduct("T-0001", map[string]interface{}{
+		"Discontinued":  map[string]interface{}{"boolean_value": true},
+		"Pack Size":     map[string]interface{}{"integer_value": 12},
+		"Weight":        map[string]interface{}{"decimal_value": "1.5"},
+		"Website":       map[string]interface{}{"alpha_value": "https://example.com/t-0001"},
+		"Support Email": map[string]interface{}{"alpha_value": "support@example.com"},
+		"Colour":        map[string]interface{}{"alpha_value": "RED"},
+		"Material":      map[string]interface{}{"multi_value": []string{"COTTON", "WOOL"}},
+		"SKU":           map[string]interface{}{"alpha_value": "SKU-001"},
+		"Length":        map[string]interface{}{"decimal_value": "1.25"},
+		"Launch Date":   map[string]interface{}{"date_value": "2021-06-01T00:00:00Z"},
+	})
+
+	respData := postTypedRequest(t, "http://localhost:50051/v1/products", dataInput, http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	typedProductID = dataOutput["id"].(float64)
+
+	dataFields := dataOutput["custom_fields"].([]interface{})
+	assert.Equal(t, len(dataFields), 10)
+
+	for _, item := range dataFields {
+		dataFieldOutput := item.(map[string]interface{})
+
+		switch dataFieldOutput["field_id"] {
+		case typedFieldIDs["Discontinued"]:
+			assert.Equal(t, dataFieldOutput["boolean_value"], true)
+
+		case typedFieldIDs["Pack Size"]:
+			assert.Equal(t, dataFieldOutput["integer_value"], float64(12))
+
+		case typedFieldIDs["Weight"]:
+			assert.Equal(t, dataFieldOutput["decimal_value"], "1.50")
+
+		case typedFieldIDs["Website"]:
+			assert.Equal(t, dataFieldOutput["alpha_value"], "https://example.com/t-0001")
+
+		case typedFieldIDs["Support Email"]:
+			assert.Equal(t, dataFieldOutput["alpha_value"], "support@example.com")
+
+		case typedFieldIDs["Colour"]:
+			assert.Equal(t, dataFieldOutput["alpha_value"], "RED")
+
+		case typedFieldIDs["Material"]:
+			assert.DeepEqual(t, dataFieldOutput["multi_value"], []interface{}{"COTTON", "WOOL"})
+
+		}
+	}
+}
+
+func createProductWithTooManyDecimalPlaces(t *testing.T) {
+	dataInput := newTypedProduct("T-0002", map[string]interface{}{
+		"Pack Size": map[string]interface{}{"integer_value": 12},
+		"Weight":    map[string]interface{}{"decimal_value": "1.505"},
+	})
+
+	postTypedRequest(t, "http://localhost:50051/v1/products", dataInput, http.StatusBadRequest)
+}
+
+func createProductWithInvalidEmail(t *testing.T) {
+	dataInput := newTypedProduct("T-0003", map[string]interface{}{
+		"Pack Size":     map[string]interface{}{"integer_value": 12},
+		"Support Email": map[string]interface{}{"alpha_value": "support at example.com"},
+	})
+
+	postTypedRequest(t, "http://localhost:50051/v1/products", dataInput, http.StatusBadRequest)
+}
+
+func createProductWithInvalidURL(t *testing.T) {
+	dataInput := newTypedProduct("T-0004", map[string]interface{}{
+		"Pack Size": map[string]interface{}{"integer_value": 12},
+		"Website":   map[string]interface{}{"alpha_value": "example.com"},
+	})
+
+	postTypedRequest(t, "http://localhost:50051/v1/products", dataInput, http.StatusBadRequest)
+}
+
+func createProductWithInvalidOption(t *testing.T) {
+	dataInput := newTypedProduct("T-0005", map[string]interface{}{
+		"Pack Size": map[string]interface{}{"integer_value": 12},
+		"Material":  map[string]interface{}{"multi_value": []string{"COTTON", "SILK"}},
+	})
+
+	postTypedRequest(t, "http://localhost:50051/v1/products", dataInput, http.StatusBadRequest)
+}
+
+func createCatalogueWithInvalidValueRange(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_RANGE",
+		"description": "Catalogue Test Range",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "Length", "type": "N", "min_value": "10", "max_value": "1", "change_mode": 1},
+		},
+	}
+
+	respData := postTypedRequest(t, "http://localhost:50051/v1/catalogues", dataInput, http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "Custom Field 'Length' minimum value can not be more than maximum value.")
+}
+
+func createProductViolatingCustomFieldRules(t *testing.T) {
+	violations := []struct {
+		caption string
+		value   map[string]interface{}
+		message string
+	}{
+		{"SKU", map[string]interface{}{"alpha_value": "AB"}, "Custom Field 'SKU' can not be less than 3 chars."},
+		{"SKU", map[string]interface{}{"alpha_value": "sku-002"}, "Custom Field 'SKU' does not match pattern '^[A-Z0-9-]+$'."},
+		{"Length", map[string]interface{}{"decimal_value": "1.3"}, "Custom Field 'Length' must be in steps of 0.25."},
+		{"Length", map[string]interface{}{"decimal_value": "200"}, "Custom Field 'Length' can not be more than 100."},
+		{"Launch Date", map[string]interface{}{"date_value": "2019-12-31T00:00:00Z"}, "Custom Field 'Launch Date' can not be before 2020-01-01."},
+	}
+
+	for _, violation := range violations {
+		dataInput := newTypedProduct("T-0007", map[string]interface{}{
+			"Pack Size":       map[string]interface{}{"integer_value": 12},
+			violation.caption: violation.value,
+		})
+
+		respData := postTypedRequest(t, "http://localhost:50051/v1/products", dataInput, http.StatusBadRequest)
+		assert.Equal(t, respData["message"], violation.message)
+	}
+}
+
+func createProductWithDuplicateUniqueCustomField(t *testing.T) {
+	dataInput := newTypedProduct("T-0008", map[string]interface{}{
+		"Pack Size": map[string]interface{}{"integer_value": 12},
+		"SKU":       map[string]interface{}{"alpha_value": "SKU-001"},
+	})
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusConflict)
+	assert.Equal(t, respData["message"], "Custom Field 'SKU' value 'SKU-001' is already used by product 'T-0001'.")
+}
+
+func deleteOptionUsedByProducts(t *testing.T) {
+	patch := `[
+		{"op": "test", "path": "/field_definitions/5/options/0/code", "value": "RED"},
+		{"op": "remove", "path": "/field_definitions/5/options/0"}
+	]`
+
+	sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/catalogues/CLG_TEST_TYPES", "application/json-patch+json", []byte(patch), http.StatusConflict)
+}
+
+func deactivateOptionUsedByProducts(t *testing.T) {
+	patch := `[
+		{"op": "test", "path": "/field_definitions/5/options/0/code", "value": "RED"},
+		{"op": "replace", "path": "/field_definitions/5/options/0/active", "value": false}
+	]`
+
+	respData := sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/catalogues/CLG_TEST_TYPES", "application/json-patch+json", []byte(patch), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	dataFieldDefs := dataOutput["field_definitions"].([]interface{})
+	dataOptions := dataFieldDefs[5].(map[string]interface{})["options"].([]interface{})
+	assert.Equal(t, len(dataOptions), 2)
+	assert.Equal(t, dataOptions[0].(map[string]interface{})["active"], false)
+}
+
+func createProductWithInactiveOption(t *testing.T) {
+	dataInput := newTypedProduct("T-0006", map[string]interface{}{
+		"Pack Size": map[string]interface{}{"integer_value": 12},
+		"Colour":    map[string]interface{}{"alpha_value": "RED"},
+	})
+
+	postTypedRequest(t, "http://localhost:50051/v1/products", dataInput, http.StatusBadRequest)
+}
+
+func updateProductKeepingInactiveOption(t *testing.T) {
+	patch := `{"description": "Typed Product - Updated"}`
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%d", int64(typedProductID))
+	respData := sendTypedRequest(t, "PATCH", url, "application/merge-patch+json", []byte(patch), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["description"], "Typed Product - Updated")
+}
+
+func addMandatoryCustomFieldWithoutDefault(t *testing.T) {
+	patch := `[
+		{"op": "add", "path": "/field_definitions/-", "value": {"caption": "Origin", "type": "A", "mandatory": true}}
+	]`
+
+	respData := sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/catalogues/CLG_TEST_TYPES", "application/json-patch+json", []byte(patch), http.StatusConflict)
+	assert.Equal(t, respData["message"], "Custom Field 'Origin' must have default value to become mandatory, catalogue already has products.")
+}
+
+func addMandatoryCustomFieldWithDefault(t *testing.T) {
+	patch := `[
+		{"op": "add", "path": "/field_definitions/-", "value": {"caption": "Origin", "type": "A", "mandatory": true, "default_value": "AU"}}
+	]`
+
+	respData := sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/catalogues/CLG_TEST_TYPES", "application/json-patch+json", []byte(patch), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	dataFieldDefs := dataOutput["field_definitions"].([]interface{})
+	dataFieldDefOutput := dataFieldDefs[len(dataFieldDefs)-1].(map[string]interface{})
+	assert.Equal(t, dataFieldDefOutput["caption"], "Origin")
+	assert.Equal(t, dataFieldDefOutput["default_value"], "AU")
+	typedFieldIDs["Origin"] = dataFieldDefOutput["id"].(float64)
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/catalogues/CLG_TEST_TYPES/backfills", "application/json", nil, http.StatusOK)
+
+	dataJobs := respData["data"].([]interface{})
+	assert.Equal(t, len(dataJobs), 1)
+
+	dataJob := dataJobs[0].(map[string]interface{})
+	assert.Equal(t, dataJob["field_id"], typedFieldIDs["Origin"])
+
+	dataJob = waitJob(t, fmt.Sprintf("http://localhost:50051/v1/catalogues/CLG_TEST_TYPES/backfills/%d", int64(dataJob["id"].(float64))))
+	assert.Equal(t, dataJob["status"], "C")
+	assert.Equal(t, dataJob["updated_rows"], dataJob["total_rows"])
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%d", int64(typedProductID))
+	respData = sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+
+	originField := findTypedField(respData["data"].(map[string]interface{}), "Origin")
+	assert.Assert(t, originField != nil)
+	assert.Equal(t, originField["alpha_value"], "AU")
+}
+
+func createProductWithDefaultCustomFieldValue(t *testing.T) {
+	dataInput := newTypedProduct("T-0009", map[string]interface{}{
+		"Pack Size": map[string]interface{}{"integer_value": 6},
+	})
+
+	respData := postTypedRequest(t, "http://localhost:50051/v1/products", dataInput, http.StatusAccepted)
+
+	originField := findTypedField(respData["data"].(map[string]interface{}), "Origin")
+	assert.Assert(t, originField != nil)
+	assert.Equal(t, originField["alpha_value"], "AU")
+}
+
+func changeCustomFieldTypeBlockedByProducts(t *testing.T) {
+	patch := `[
+		{"op": "test", "path": "/field_definitions/3/caption", "value": "Website"},
+		{"op": "replace", "path": "/field_definitions/3/type", "value": "N"}
+	]`
+
+	respData := sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/catalogues/CLG_TEST_TYPES", "application/json-patch+json", []byte(patch), http.StatusConflict)
+	assert.Equal(t, respData["message"], "Custom Field 'Website' can not be changed to type 'N', values of products can not be converted.")
+
+	dataBlockers := respData["data"].([]interface{})
+	assert.Equal(t, len(dataBlockers), 1)
+	assert.Equal(t, dataBlockers[0].(map[string]interface{})["code"], "T-0001")
+	assert.Equal(t, dataBlockers[0].(map[string]interface{})["value"], "https://example.com/t-0001")
+}
+
+func changeCustomFieldTypeConvertingValues(t *testing.T) {
+	patch := `[
+		{"op": "test", "path": "/field_definitions/1/caption", "value": "Pack Size"},
+		{"op": "replace", "path": "/field_definitions/1/type", "value": "F"},
+		{"op": "replace", "path": "/field_definitions/1/precision", "value": 1}
+	]`
+
+	sendTypedRequest(t, "PATCH", "http://localhost:50051/v1/catalogues/CLG_TEST_TYPES", "application/json-patch+json", []byte(patch), http.StatusAccepted)
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%d", int64(typedProductID))
+	respData := sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+
+	packSizeField := findTypedField(respData["data"].(map[string]interface{}), "Pack Size")
+	assert.Assert(t, packSizeField != nil)
+	assert.Equal(t, packSizeField["decimal_value"], "12.0")
+	assert.Equal(t, packSizeField["integer_value"], float64(0))
+}
+
+func createCatalogueWithFieldGroups(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_GROUPS",
+		"description": "Catalogue Test Groups",
+		"status":      "A",
+		"vers":        1,
+		"field_groups": []interface{}{
+			map[string]interface{}{"code": "general", "name": "General", "sort_order": 1, "change_mode": 1},
+			map[string]interface{}{"code": "PHYSICAL", "name": "Physical", "sort_order": 2, "change_mode": 1},
+		},
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "Colour", "type": "A", "group_code": "general", "sort_order": 3, "help_text": "Main colour of product", "placeholder": "e.g. Red", "change_mode": 1},
+			map[string]interface{}{"caption": "Weight", "type": "N", "group_code": "PHYSICAL", "sort_order": 2, "unit_label": "kg", "change_mode": 1},
+			map[string]interface{}{"caption": "Notes", "type": "A", "sort_order": 1, "hidden": true, "read_only": true, "change_mode": 1},
+		},
+	}
+
+	respData := postTypedRequest(t, "http://localhost:50051/v1/catalogues", dataInput, http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	dataGroups := dataOutput["field_groups"].([]interface{})
+	assert.Equal(t, len(dataGroups), 2)
+	assert.Equal(t, dataGroups[0].(map[string]interface{})["code"], "GENERAL")
+	assert.Equal(t, dataGroups[1].(map[string]interface{})["code"], "PHYSICAL")
+
+	dataFieldDefs := dataOutput["field_definitions"].([]interface{})
+	assert.Equal(t, len(dataFieldDefs), 3)
+	for _, dataFieldDef := range dataFieldDefs {
+		dataFieldDefOutput := dataFieldDef.(map[string]interface{})
+		groupedFieldIDs[dataFieldDefOutput["caption"].(string)] = dataFieldDefOutput["id"].(float64)
+	}
+
+	dataNotes := dataFieldDefs[0].(map[string]interface{})
+	assert.Equal(t, dataNotes["caption"], "Notes")
+	assert.Equal(t, dataNotes["group_code"], "")
+	assert.Equal(t, dataNotes["hidden"], true)
+	assert.Equal(t, dataNotes["read_only"], true)
+
+	dataWeight := dataFieldDefs[1].(map[string]interface{})
+	assert.Equal(t, dataWeight["caption"], "Weight")
+	assert.Equal(t, dataWeight["group_code"], "PHYSICAL")
+	assert.Equal(t, dataWeight["unit_label"], "kg")
+
+	dataColour := dataFieldDefs[2].(map[string]interface{})
+	assert.Equal(t, dataColour["caption"], "Colour")
+	assert.Equal(t, dataColour["group_code"], "GENERAL")
+	assert.Equal(t, dataColour["help_text"], "Main colour of product")
+	assert.Equal(t, dataColour["placeholder"], "e.g. Red")
+}
+
+func createCatalogueWithUnknownFieldGroup(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_GROUPS_2",
+		"description": "Catalogue Test Groups 2",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "Colour", "type": "A", "group_code": "MISC", "change_mode": 1},
+		},
+	}
+
+	respData := postTypedRequest(t, "http://localhost:50051/v1/catalogues", dataInput, http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "Custom Field 'Colour' refers to unknown group 'MISC'.")
+}
+
+func reorderCustomFieldDefinitions(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"field_ids": []float64{groupedFieldIDs["Colour"], groupedFieldIDs["Notes"], groupedFieldIDs["Weight"]},
+	}
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/catalogues/CLG_TEST_GROUPS/field_definitions/order", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["vers"], float64(2))
+
+	dataFieldDefs := dataOutput["field_definitions"].([]interface{})
+	assert.Equal(t, len(dataFieldDefs), 3)
+	for i, caption := range []string{"Colour", "Notes", "Weight"} {
+		dataFieldDefOutput := dataFieldDefs[i].(map[string]interface{})
+		assert.Equal(t, dataFieldDefOutput["caption"], caption)
+		assert.Equal(t, dataFieldDefOutput["sort_order"], float64(i+1))
+	}
+}
+
+func reorderCustomFieldDefinitionsPartially(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"field_ids": []float64{groupedFieldIDs["Weight"]},
+	}
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/catalogues/CLG_TEST_GROUPS/field_definitions/order", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "All Custom Field Definitions of catalogue must be ordered.")
+}
+
+func findTypedField(dataProduct map[string]interface{}, caption string) map[string]interface{} {
+	for _, dataField := range dataProduct["custom_fields"].([]interface{}) {
+		field := dataField.(map[string]interface{})
+		if field["field_id"] == typedFieldIDs[caption] {
+			return field
+		}
+	}
+
+	return nil
+}
+
+func newTypedProduct(code string, values map[string]interface{}) map[string]interface{} {
+	customFields := make([]interface{}, 0)
+	for caption, value := range values {
+		field := value.(map[string]interface{})
+		field["field_id"] = typedFieldIDs[caption]
+		field["change_mode"] = 1
+		customFields = append(customFields, field)
+	}
+
+	return map[string]interface{}{
+		"clg_code":    "CLG_TEST_TYPES",
+		"code":        code,
+		"description": "Typed Product",
+		"details":     "Typed Product",
+		"status":      "A",
+		"created_at":  time.Now(),
+		"modified_at": time.Now(),
+		"vers":        1,
+		"uoms": []interface{}{
+			map[string]interface{}{
+				"code":        "EACH",
+				"description": "Each",
+				"ratio":       1,
+				"vers":        1,
+				"change_mode": 1,
+			},
+		},
+		"custom_fields": customFields,
+	}
+}
+
+func postTypedRequest(t *testing.T, url string, dataInput map[string]interface{}, statusCode int) map[string]interface{} {
+	return sendTypedRequest(t, "POST", url, "application/json", mustMarshal(t, dataInput), statusCode)
+}
+
+func mustMarshal(t *testing.T, dataInput interface{}) []byte {
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	return bodyReq
+}
+
+func sendTypedRequest(t *testing.T, method string, url string, contentType string, bodyReq []byte, statusCode int) map[string]interface{} {
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Content-Type", contentType)
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to submit request.")
+	assert.Equal(t, resp.StatusCode, statusCode)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], statusCode == http.StatusOK || statusCode == http.StatusAccepted)
+
+	return respData
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestProductExport(t *testing.T) {
+	t.Run("Export products to csv", exportProductsToCSV)
+
+	t.Run("Export products to ndjson", exportProductsToNDJSON)
+
+	t.Run("Export products to xlsx", exportProductsToXLSX)
+
+	t.Run("Export products with invalid format", exportProductsWithInvalidFormat)
+
+	t.Run("Export products of non existing catalogue", exportProductsOfNonExistingCatalogue)
+}
+
+func exportProductsToCSV(t *testing.T) {
+	resp := getExport(t, "http://localhost:50051/v1/catalogues/CLG_TEST_1/export?format=csv")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "text/csv")
+	assert.Equal(t, resp.Header.Get("Content-Disposition"), "attachment; filename=\"CLG_TEST_1.csv\"")
+
+	defer resp.Body.Close()
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Assert(t, len(records) > 1)
+	assert.DeepEqual(t, records[0], []string{"code", "parent_code", "description", "details", "status", "uom_code", "uom_description", "uom_ratio", "Field-1", "Field-2", "Field-3"})
+}
+
+func exportProductsToNDJSON(t *testing.T) {
+	resp := getExport(t, "http://localhost:50051/v1/catalogues/CLG_TEST_1/export?format=ndjson")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "application/x-ndjson")
+
+	defer resp.Body.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var prod map[string]interface{}
+		err := json.Unmarshal(scanner.Bytes(), &prod)
+		assert.NilError(t, err, "Failed to decode body response.")
+		assert.Equal(t, prod["clg_code"], "CLG_TEST_1")
+		lines++
+	}
+	assert.NilError(t, scanner.Err(), "Failed to read body response.")
+	assert.Assert(t, lines > 0)
+}
+
+func exportProductsToXLSX(t *testing.T) {
+	resp := getExport(t, "http://localhost:50051/v1/catalogues/CLG_TEST_1/export?format=xlsx")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Disposition"), "attachment; filename=\"CLG_TEST_1.xlsx\"")
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	zipReader, err := zip.NewReader(bytes.NewReader(bodyResp), int64(len(bodyResp)))
+	assert.NilError(t, err, "Failed to open workbook.")
+
+	hasSheet := false
+	for _, file := range zipReader.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			hasSheet = true
+		}
+	}
+	assert.Assert(t, hasSheet)
+}
+
+func exportProductsWithInvalidFormat(t *testing.T) {
+	resp := getExport(t, "http://localhost:50051/v1/catalogues/CLG_TEST_1/export?format=pdf")
+	assert.Equal(t, resp.StatusCode, http.StatusBadRequest)
+
+	defer resp.Body.Close()
+}
+
+func exportProductsOfNonExistingCatalogue(t *testing.T) {
+	resp := getExport(t, "http://localhost:50051/v1/catalogues/CLG_TEST_X/export")
+	assert.Equal(t, resp.StatusCode, http.StatusNotFound)
+
+	defer resp.Body.Close()
+}
+
+func getExport(t *testing.T, url string) *http.Response {
+	req, err := http.NewRequest("GET", url, bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create get request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve export.")
+
+	return resp
+}
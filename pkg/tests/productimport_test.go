@@ -0,0 +1,551 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestProductImport(t *testing.T) {
+	t.Run("Import products from csv", importProductsFromCSV)
+
+	t.Run("Get import error report", getImportErrorReport)
+
+	t.Run("Import products from ndjson with dry run", importProductsFromNDJSONWithDryRun)
+
+	t.Run("Import products without file", importProductsWithoutFile)
+
+	t.Run("Import product replacing default uom", importProductReplacingDefaultUom)
+
+	t.Run("Import exported products", importExportedProducts)
+
+	t.Run("Import product change requiring approval", importProductChangeRequiringApproval)
+}
+
+func importProductsFromCSV(t *testing.T) {
+	options := map[string]interface{}{
+		"format": "csv",
+		"default_uom": map[string]interface{}{
+			"code":        "EACH",
+			"description": "Each",
+		},
+	}
+
+	fileContent := "code,description,details,Field-1,Field-2,Field-3\n" +
+		"P-0001,Book - Imported,,Field-Prod-1 - Imported,11.5,2020-01-15\n" +
+		"P-0100,Ruler,Ruler,Field-Prod-100,5,2020-04-01\n" +
+		"P-0101,Eraser,Eraser,,1,\n"
+
+	req, err := newImportRequest("http://localhost:50051/v1/catalogues/CLG_TEST_1/imports", options, "products.csv", fileContent)
+	assert.NilError(t, err, "Failed to create import request.")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to submit import.")
+	assert.Equal(t, resp.StatusCode, http.StatusAccepted)
+	assert.Equal(t, resp.Header.Get("Location"), "/v1/catalogues/CLG_TEST_1/imports/1")
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, respData["success"], true)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["format"], "CSV")
+	assert.Equal(t, dataOutput["status"], "P")
+
+	dataOutput = waitJob(t, "http://localhost:50051/v1/catalogues/CLG_TEST_1/imports/1")
+	assert.Equal(t, dataOutput["status"], "C")
+	assert.Equal(t, dataOutput["total_rows"], float64(3))
+	assert.Equal(t, dataOutput["processed_rows"], float64(3))
+	assert.Equal(t, dataOutput["created_rows"], float64(1))
+	assert.Equal(t, dataOutput["updated_rows"], float64(1))
+	assert.Equal(t, dataOutput["failed_rows"], float64(1))
+}
+
+func getImportErrorReport(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://localhost:50051/v1/catalogues/CLG_TEST_1/imports/1/errors", bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create get request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve import error report.")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "text/csv")
+
+	defer resp.Body.Close()
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NilError(t, err, "Failed to decode body response.")
+	assert.Equal(t, len(records), 2)
+	assert.Equal(t, records[1][0], "4")
+	assert.Equal(t, records[1][1], "P-0101")
+}
+
+func importProductsFromNDJSONWithDryRun(t *testing.T) {
+	options := map[string]interface{}{
+		"dry_run": true,
+	}
+
+	fileContent := `{"code":"P-0200","description":"Stapler","uoms":[{"code":"EACH","description":"Each","ratio":1}],"custom_fields":[{"field_id":1,"alpha_value":"Field-Prod-200"}]}` + "\n" +
+		`{"code":"P-0201","description":"Stapler","uoms":[]}` + "\n"
+
+	req, err := newImportRequest("http://localhost:50051/v1/catalogues/CLG_TEST_1/imports", options, "products.ndjson", fileContent)
+	assert.NilError(t, err, "Failed to create import request.")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to submit import.")
+	assert.Equal(t, resp.StatusCode, http.StatusAccepted)
+
+	defer resp.Body.Close()
+
+	dataOutput := waitJob(t, "http://localhost:50051/v1/catalogues/CLG_TEST_1/imports/2")
+	assert.Equal(t, dataOutput["format"], "NDJSON")
+	assert.Equal(t, dataOutput["dry_run"], true)
+	assert.Equal(t, dataOutput["status"], "C")
+	assert.Equal(t, dataOutput["created_rows"], float64(1))
+	assert.Equal(t, dataOutput["failed_rows"], float64(1))
+
+	req, err = http.NewRequest("GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_1", bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create get request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	resp, err = client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve products.")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+
+	data := respData["data"].([]interface{})
+	assert.Equal(t, len(data), 4)
+}
+
+func importProductsWithoutFile(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("options", "{}")
+	writer.Close()
+
+	req, err := http.NewRequest("POST", "http://localhost:50051/v1/catalogues/CLG_TEST_1/imports", body)
+	assert.NilError(t, err, "Failed to create import request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Content-Type", writer.FormDataContentType())
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to submit import.")
+	assert.Equal(t, resp.StatusCode, http.StatusBadRequest)
+
+	defer resp.Body.Close()
+}
+
+func importProductReplacingDefaultUom(t *testing.T) {
+	fileContent := `{"code":"P-0100","uoms":[{"code":"ITEM","description":"Item","ratio":1}]}` + "\n"
+
+	req, err := newImportRequest("http://localhost:50051/v1/catalogues/CLG_TEST_1/imports", map[string]interface{}{}, "products.ndjson", fileContent)
+	assert.NilError(t, err, "Failed to create import request.")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to submit import.")
+	assert.Equal(t, resp.StatusCode, http.StatusAccepted)
+
+	defer resp.Body.Close()
+
+	dataOutput := waitJob(t, "http://localhost:50051"+resp.Header.Get("Location"))
+	assert.Equal(t, dataOutput["status"], "C")
+	assert.Equal(t, dataOutput["updated_rows"], float64(1))
+	assert.Equal(t, dataOutput["failed_rows"], float64(0))
+
+	dataOutput = getImportedProduct(t, "CLG_TEST_1", "P-0100")
+
+	dataUoms := dataOutput["uoms"].([]interface{})
+	assert.Equal(t, len(dataUoms), 1)
+	assert.Equal(t, dataUoms[0].(map[string]interface{})["code"], "ITEM")
+}
+
+func importExportedProducts(t *testing.T) {
+	for _, clgCode := range []string{"CLG_TEST_RT", "CLG_TEST_RT_2"} {
+		dataInput := map[string]interface{}{
+			"code":        clgCode,
+			"description": "Catalogue Test Round Trip",
+			"details":     "Catalogue Test Round Trip",
+			"status":      "A",
+			"created_at":  time.Now(),
+			"modified_at": time.Now(),
+			"vers":        1,
+			"field_definitions": []interface{}{
+				map[string]interface{}{
+					"caption":      "Size",
+					"type":         "A",
+					"mandatory":    false,
+					"variant_axis": true,
+					"change_mode":  1,
+				},
+				map[string]interface{}{
+					"caption":     "Weight",
+					"type":        "N",
+					"mandatory":   false,
+					"change_mode": 1,
+				},
+			},
+		}
+
+		createTestData(t, "http://localhost:50051/v1/catalogues", dataInput)
+	}
+
+	fieldIDs := getFieldIDs(t, "CLG_TEST_RT")
+
+	dataInput := map[string]interface{}{
+		"clg_code":    "CLG_TEST_RT",
+		"code":        "RT-0001",
+		"description": "Hammer",
+		"details":     "Claw Hammer",
+		"status":      "A",
+		"created_at":  time.Now(),
+		"modified_at": time.Now(),
+		"vers":        1,
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+			map[string]interface{}{"code": "DOZEN", "description": "Dozen", "ratio": 12, "change_mode": 1},
+			map[string]interface{}{"code": "BOX", "description": "Box of 20", "ratio": 20, "change_mode": 1},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": fieldIDs["Weight"], "numeric_value": 1.25, "change_mode": 1},
+		},
+	}
+
+	dataOutput := createTestData(t, "http://localhost:50051/v1/products", dataInput)
+
+	dataInput = map[string]interface{}{
+		"clg_code":    "CLG_TEST_RT",
+		"parent_id":   dataOutput["id"],
+		"code":        "RT-0001-L",
+		"description": "Hammer Large",
+		"status":      "A",
+		"created_at":  time.Now(),
+		"modified_at": time.Now(),
+		"vers":        1,
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": fieldIDs["Size"], "alpha_value": "L", "change_mode": 1},
+		},
+	}
+
+	createTestData(t, "http://localhost:50051/v1/products", dataInput)
+
+	resp := getExport(t, "http://localhost:50051/v1/catalogues/CLG_TEST_RT/export?format=csv")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	defer resp.Body.Close()
+
+	fileContent, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read export.")
+
+	req, err := newImportRequest("http://localhost:50051/v1/catalogues/CLG_TEST_RT_2/imports", map[string]interface{}{}, "products.csv", string(fileContent))
+	assert.NilError(t, err, "Failed to create import request.")
+
+	client := &http.Client{}
+
+	importResp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to submit import.")
+	assert.Equal(t, importResp.StatusCode, http.StatusAccepted)
+
+	defer importResp.Body.Close()
+
+	dataOutput = waitJob(t, "http://localhost:50051"+importResp.Header.Get("Location"))
+	assert.Equal(t, dataOutput["status"], "C")
+	assert.Equal(t, dataOutput["created_rows"], float64(2))
+	assert.Equal(t, dataOutput["failed_rows"], float64(0))
+
+	for _, code := range []string{"RT-0001", "RT-0001-L"} {
+		exported := getRoundTripProduct(t, "CLG_TEST_RT", code)
+		imported := getRoundTripProduct(t, "CLG_TEST_RT_2", code)
+		assert.DeepEqual(t, imported, exported)
+	}
+}
+
+func importProductChangeRequiringApproval(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":              "CLG_TEST_APR_IMP",
+		"description":       "Catalogue Test Approval Import",
+		"status":            "A",
+		"approval_required": true,
+		"reviewers":         []interface{}{"REVIEWER"},
+		"vers":              1,
+	}
+
+	createTestData(t, "http://localhost:50051/v1/catalogues", dataInput)
+
+	dataInput = map[string]interface{}{
+		"clg_code":    "CLG_TEST_APR_IMP",
+		"code":        "APR-0100",
+		"description": "Scalpel",
+		"status":      "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+	}
+
+	prodData := createTestData(t, "http://localhost:50051/v1/products", dataInput)
+	prodRoute := fmt.Sprintf("http://localhost:50051/v1/products/%v", prodData["id"])
+
+	fileContent := "code,description\n" +
+		"APR-0100,Sterile Scalpel\n"
+
+	req, err := newImportRequest("http://localhost:50051/v1/catalogues/CLG_TEST_APR_IMP/imports", map[string]interface{}{}, "products.csv", fileContent)
+	assert.NilError(t, err, "Failed to create import request.")
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to submit import.")
+	assert.Equal(t, resp.StatusCode, http.StatusAccepted)
+
+	defer resp.Body.Close()
+
+	dataOutput := waitJob(t, "http://localhost:50051"+resp.Header.Get("Location"))
+	assert.Equal(t, dataOutput["status"], "C")
+	assert.Equal(t, dataOutput["failed_rows"], float64(0))
+
+	respData := sendTypedRequest(t, "GET", prodRoute, "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["description"], "Scalpel")
+
+	respData = sendTypedRequest(t, "GET", prodRoute+"/changes", "application/json", nil, http.StatusOK)
+
+	dataChanges := respData["data"].([]interface{})
+	assert.Equal(t, len(dataChanges), 1)
+	assert.Equal(t, dataChanges[0].(map[string]interface{})["status"], "P")
+	assert.Equal(t, dataChanges[0].(map[string]interface{})["product"].(map[string]interface{})["description"], "Sterile Scalpel")
+}
+
+func newImportRequest(url string, options map[string]interface{}, filename string, fileContent string) (*http.Request, error) {
+	optionsReq, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("options", string(optionsReq))
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	part.Write([]byte(fileContent))
+	writer.Close()
+
+	req, err := http.NewRequest("POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Authorization", accessTokenTest)
+	req.Header.Add("Content-Type", writer.FormDataContentType())
+
+	return req, nil
+}
+
+func waitJob(t *testing.T, url string) map[string]interface{} {
+	client := &http.Client{}
+
+	for i := 0; i < 50; i++ {
+		req, err := http.NewRequest("GET", url, bytes.NewBuffer([]byte("")))
+		assert.NilError(t, err, "Failed to create get request.")
+
+		req.Header.Add("Authorization", accessTokenTest)
+
+		resp, err := client.Do(req)
+		assert.NilError(t, err, "Failed to retrieve job.")
+		assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+		bodyResp, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NilError(t, err, "Failed to read body response.")
+
+		var respData map[string]interface{}
+		err = json.Unmarshal(bodyResp, &respData)
+		assert.NilError(t, err, "Failed to decode body response.")
+
+		dataOutput := respData["data"].(map[string]interface{})
+		if dataOutput["status"] == "C" || dataOutput["status"] == "F" {
+			return dataOutput
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatal("Job did not complete.")
+	return nil
+}
+
+func getImportedProduct(t *testing.T, clgCode string, code string) map[string]interface{} {
+	client := &http.Client{}
+
+	req, err := http.NewRequest("GET", "http://localhost:50051/v1/products/bycatalogue/"+clgCode, bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create get request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve products.")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+
+	var id interface{}
+	for _, item := range respData["data"].([]interface{}) {
+		if item.(map[string]interface{})["code"] == code {
+			id = item.(map[string]interface{})["id"]
+		}
+	}
+	assert.Assert(t, id != nil, "Product does not exist.")
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("http://localhost:50051/v1/products/%v", id), bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create get request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	resp, err = client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve product.")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	bodyResp, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var prodData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &prodData)
+	assert.NilError(t, err, "Failed to decode body response.")
+
+	return prodData["data"].(map[string]interface{})
+}
+
+func createTestData(t *testing.T, url string, dataInput map[string]interface{}) map[string]interface{} {
+	bodyReq, err := json.Marshal(dataInput)
+	assert.NilError(t, err, "Failed to encode body request.")
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyReq))
+	assert.NilError(t, err, "Failed to create request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to create test data.")
+	assert.Equal(t, resp.StatusCode, http.StatusAccepted)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+
+	return respData["data"].(map[string]interface{})
+}
+
+func getFieldIDs(t *testing.T, clgCode string) map[string]interface{} {
+	req, err := http.NewRequest("GET", "http://localhost:50051/v1/catalogues/"+clgCode, bytes.NewBuffer([]byte("")))
+	assert.NilError(t, err, "Failed to create get request.")
+
+	req.Header.Add("Authorization", accessTokenTest)
+
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err, "Failed to retrieve catalogue.")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	defer resp.Body.Close()
+
+	bodyResp, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err, "Failed to read body response.")
+
+	var respData map[string]interface{}
+	err = json.Unmarshal(bodyResp, &respData)
+	assert.NilError(t, err, "Failed to decode body response.")
+
+	result := make(map[string]interface{})
+	for _, item := range respData["data"].(map[string]interface{})["field_definitions"].([]interface{}) {
+		fieldDef := item.(map[string]interface{})
+		result[fieldDef["caption"].(string)] = fieldDef["id"]
+	}
+
+	return result
+}
+
+func getRoundTripProduct(t *testing.T, clgCode string, code string) map[string]interface{} {
+	dataOutput := getImportedProduct(t, clgCode, code)
+
+	var parentCode interface{}
+	if dataOutput["parent_id"] != float64(0) {
+		respData := sendTypedRequest(t, "GET", fmt.Sprintf("http://localhost:50051/v1/products/%v", dataOutput["parent_id"]), "application/json", nil, http.StatusOK)
+		parentCode = respData["data"].(map[string]interface{})["code"]
+	}
+
+	uoms := make(map[string]interface{})
+	for _, item := range dataOutput["uoms"].([]interface{}) {
+		uom := item.(map[string]interface{})
+		uoms[uom["code"].(string)] = []interface{}{uom["description"], uom["ratio"]}
+	}
+
+	captions := make(map[interface{}]string)
+	for caption, id := range getFieldIDs(t, clgCode) {
+		captions[id] = caption
+	}
+
+	fields := make(map[string]interface{})
+	for _, item := range dataOutput["custom_fields"].([]interface{}) {
+		field := item.(map[string]interface{})
+		fields[captions[field["field_id"]]] = []interface{}{field["alpha_value"], field["numeric_value"], field["inherited"]}
+	}
+
+	return map[string]interface{}{
+		"parent_code":   parentCode,
+		"description":   dataOutput["description"],
+		"details":       dataOutput["details"],
+		"status":        dataOutput["status"],
+		"uoms":          uoms,
+		"custom_fields": fields,
+	}
+}
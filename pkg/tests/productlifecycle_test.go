@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bungysheep/catalogue-api/pkg/protocols/database"
+	"github.com/bungysheep/catalogue-api/pkg/services/v1/lifecycleservice"
+	"gotest.tools/assert"
+)
+
+var lifecycleProdIDs = make(map[string]float64)
+
+func TestProductLifecycle(t *testing.T) {
+	t.Run("Create lifecycle catalogue", createLifecycleCatalogue)
+
+	t.Run("Create product as discontinued", createProductAsDiscontinued)
+
+	t.Run("Create products with effective dates", createProductsWithEffectiveDates)
+
+	t.Run("Change product status not allowed", changeProductStatusNotAllowed)
+
+	t.Run("Apply due lifecycle transitions", applyDueLifecycleTransitions)
+
+	t.Run("Get products by lifecycle status", getProductsByLifecycleStatus)
+
+	t.Run("Change end of life product status", changeEndOfLifeProductStatus)
+
+	t.Run("Update legacy inactive product", updateLegacyInactiveProduct)
+}
+
+func createLifecycleCatalogue(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_LC",
+		"description": "Catalogue Test Lifecycle",
+		"status":      "A",
+		"vers":        1,
+	}
+
+	sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+}
+
+func createProductAsDiscontinued(t *testing.T) {
+	dataInput := newLifecycleProduct("LC-0001", "X")
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 1)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/status")
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Product can only be created as draft or active.")
+}
+
+func createProductsWithEffectiveDates(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -1).Format(time.RFC3339)
+	nextYear := time.Now().AddDate(1, 0, 0).Format(time.RFC3339)
+
+	dataInput := newLifecycleProduct("LC-0001", "D")
+	dataInput["effective_from"] = yesterday
+	dataInput["effective_to"] = nextYear
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["status"], "D")
+	lifecycleProdIDs["LC-0001"] = dataOutput["id"].(float64)
+
+	dataInput = newLifecycleProduct("LC-0002", "")
+	dataInput["effective_to"] = yesterday
+
+	respData = sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput = respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["status"], "A")
+	lifecycleProdIDs["LC-0002"] = dataOutput["id"].(float64)
+
+	dataInput = newLifecycleProduct("LC-0003", "A")
+	dataInput["effective_from"] = nextYear
+	dataInput["effective_to"] = yesterday
+
+	respData = sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 1)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/effective_to")
+}
+
+func changeProductStatusNotAllowed(t *testing.T) {
+	dataInput := map[string]interface{}{"status": "X"}
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", lifecycleProdIDs["LC-0001"])
+	respData := sendTypedRequest(t, "PATCH", url, "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 1)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Product status can not change from 'D' to 'X'.")
+}
+
+func applyDueLifecycleTransitions(t *testing.T) {
+	nbrRows, err := lifecycleservice.NewLifecycleService().ApplyDue(context.TODO(), time.Now())
+	assert.NilError(t, err)
+	assert.Equal(t, nbrRows, int64(2))
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", lifecycleProdIDs["LC-0001"])
+	respData := sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["status"], "A")
+	assert.Equal(t, dataOutput["modified_by"], "SCHEDULER")
+	assert.Equal(t, dataOutput["vers"], float64(2))
+
+	url = fmt.Sprintf("http://localhost:50051/v1/products/%v", lifecycleProdIDs["LC-0002"])
+	respData = sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+
+	dataOutput = respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["status"], "E")
+}
+
+func getProductsByLifecycleStatus(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_LC?status=e,X", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 1)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["code"], "LC-0002")
+}
+
+func changeEndOfLifeProductStatus(t *testing.T) {
+	dataInput := map[string]interface{}{"status": "A"}
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", lifecycleProdIDs["LC-0002"])
+	respData := sendTypedRequest(t, "PATCH", url, "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "Product status can not change from 'E' to 'A'.")
+}
+
+func updateLegacyInactiveProduct(t *testing.T) {
+	var prodID int64
+	err := database.DbConnection.QueryRow(`INSERT INTO products (clg_code, code, descr, details, status, created_by, created_at, modified_by, modified_at, vers) VALUES
+		('CLG_TEST_LC', 'LC-0099', 'Legacy', 'Legacy', 'I', 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1) RETURNING id`).Scan(&prodID)
+	assert.NilError(t, err, "Failed to insert legacy product.")
+
+	_, err = database.DbConnection.Exec(`INSERT INTO product_uoms (prod_id, code, descr, ratio, vers) VALUES ($1, 'EACH', 'Each', 1, 1)`, prodID)
+	assert.NilError(t, err, "Failed to insert legacy product uom.")
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", prodID)
+	respData := sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["status"], "X")
+
+	dataInput := map[string]interface{}{"description": "Legacy - Updated"}
+	respData = sendTypedRequest(t, "PATCH", url, "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["data"].(map[string]interface{})["status"], "X")
+
+	dataInput = map[string]interface{}{"status": "A"}
+	respData = sendTypedRequest(t, "PATCH", url, "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["data"].(map[string]interface{})["status"], "A")
+}
+
+func newLifecycleProduct(code string, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"clg_code":    "CLG_TEST_LC",
+		"code":        code,
+		"description": code,
+		"status":      status,
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+	}
+}
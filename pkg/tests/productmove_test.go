@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var moveRoute string
+
+func TestProductMove(t *testing.T) {
+	t.Run("Create move catalogues", createMoveCatalogues)
+
+	t.Run("Move product with unmapped values", moveProductWithUnmappedValues)
+
+	t.Run("Move product missing mandatory field", moveProductMissingMandatoryField)
+
+	t.Run("Move product dropping unmapped values", moveProductDroppingUnmappedValues)
+
+	t.Run("Move product along with variant of its own category", moveProductWithVariantCategory)
+}
+
+func createMoveCatalogues(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_MV",
+		"description": "Catalogue Test Move",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "Colour", "type": "A", "change_mode": 1},
+			map[string]interface{}{"caption": "Weight", "type": "N", "change_mode": 1},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	fieldDefs := respData["data"].(map[string]interface{})["field_definitions"].([]interface{})
+
+	dataInput = map[string]interface{}{
+		"code":        "CLG_TEST_MV_2",
+		"description": "Catalogue Test Move 2",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{"caption": "colour", "type": "A", "change_mode": 1},
+			map[string]interface{}{"caption": "Grade", "type": "A", "mandatory": true, "change_mode": 1},
+		},
+	}
+
+	sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataInput["code"] = "CLG_TEST_MV_3"
+	dataInput["field_definitions"] = []interface{}{
+		map[string]interface{}{"caption": "colour", "type": "A", "change_mode": 1},
+		map[string]interface{}{"caption": "Grade", "type": "A", "mandatory": true, "default_value": "STD", "change_mode": 1},
+	}
+
+	sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataInput = map[string]interface{}{
+		"clg_code":    "CLG_TEST_MV",
+		"code":        "MV-0001",
+		"description": "Chisel",
+		"status":      "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": fieldDefs[0].(map[string]interface{})["id"], "alpha_value": "Blue", "change_mode": 1},
+			map[string]interface{}{"field_id": fieldDefs[1].(map[string]interface{})["id"], "numeric_value": 0.4, "change_mode": 1},
+		},
+	}
+
+	respData = sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	moveRoute = fmt.Sprintf("http://localhost:50051/v1/products/%v/move", respData["data"].(map[string]interface{})["id"])
+}
+
+func moveProductWithUnmappedValues(t *testing.T) {
+	respData := sendTypedRequest(t, "POST", moveRoute+"?to=CLG_TEST_MV_3", "application/json", nil, http.StatusConflict)
+	assert.Equal(t, respData["message"], "Product has values that can not be mapped to catalogue 'CLG_TEST_MV_3'.")
+	assert.DeepEqual(t, respData["data"].(map[string]interface{})["unmapped_fields"], []interface{}{"Weight"})
+}
+
+func moveProductMissingMandatoryField(t *testing.T) {
+	respData := sendTypedRequest(t, "POST", moveRoute+"?to=CLG_TEST_MV_2&drop_unmapped=true", "application/json", nil, http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "Custom Field 'Grade' must be specified.")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_MV", "application/json", nil, http.StatusOK)
+	assert.Equal(t, len(respData["data"].([]interface{})), 1)
+}
+
+func moveProductDroppingUnmappedValues(t *testing.T) {
+	respData := sendTypedRequest(t, "POST", moveRoute+"?to=CLG_TEST_MV_3&drop_unmapped=true", "application/json", nil, http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Product has been moved.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.DeepEqual(t, dataOutput["unmapped_fields"], []interface{}{"Weight"})
+
+	dataProduct := dataOutput["product"].(map[string]interface{})
+	assert.Equal(t, dataProduct["clg_code"], "CLG_TEST_MV_3")
+	assert.Equal(t, dataProduct["vers"], float64(2))
+
+	dataFields := dataProduct["custom_fields"].([]interface{})
+	assert.Equal(t, len(dataFields), 2)
+	assert.Equal(t, dataFields[0].(map[string]interface{})["alpha_value"], "Blue")
+	assert.Equal(t, dataFields[1].(map[string]interface{})["alpha_value"], "STD")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_MV", "application/json", nil, http.StatusOK)
+	assert.Equal(t, len(respData["data"].([]interface{})), 0)
+}
+
+func moveProductWithVariantCategory(t *testing.T) {
+	categoryIDs := make(map[string]interface{})
+	for _, clgCode := range []string{"CLG_TEST_MV_4", "CLG_TEST_MV_5"} {
+		dataInput := map[string]interface{}{
+			"code":        clgCode,
+			"description": "Catalogue Test Move Variant",
+			"status":      "A",
+			"vers":        1,
+			"field_definitions": []interface{}{
+				map[string]interface{}{"caption": "Size", "type": "A", "variant_axis": true, "change_mode": 1},
+			},
+		}
+
+		sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+		dataInput = map[string]interface{}{"code": "SPANNERS", "description": "Spanners"}
+
+		respData := sendTypedRequest(t, "POST", fmt.Sprintf("http://localhost:50051/v1/catalogues/%s/categories", clgCode), "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+		categoryIDs[clgCode] = respData["data"].(map[string]interface{})["id"]
+	}
+
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/catalogues/CLG_TEST_MV_4", "application/json", nil, http.StatusOK)
+	sizeFieldID := respData["data"].(map[string]interface{})["field_definitions"].([]interface{})[0].(map[string]interface{})["id"]
+
+	dataInput := map[string]interface{}{
+		"clg_code":    "CLG_TEST_MV_4",
+		"code":        "MV-0002",
+		"description": "Spanner",
+		"status":      "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+	}
+
+	respData = sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	parentID := respData["data"].(map[string]interface{})["id"]
+
+	dataInput = map[string]interface{}{
+		"clg_code":     "CLG_TEST_MV_4",
+		"parent_id":    parentID,
+		"code":         "MV-0002-S",
+		"description":  "Spanner Small",
+		"status":       "A",
+		"category_ids": []interface{}{categoryIDs["CLG_TEST_MV_4"]},
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": sizeFieldID, "alpha_value": "S", "change_mode": 1},
+		},
+	}
+
+	respData = sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	variantID := respData["data"].(map[string]interface{})["id"]
+
+	sendTypedRequest(t, "POST", fmt.Sprintf("http://localhost:50051/v1/products/%v/move?to=CLG_TEST_MV_5", parentID), "application/json", nil, http.StatusAccepted)
+
+	respData = sendTypedRequest(t, "GET", fmt.Sprintf("http://localhost:50051/v1/products/%v", variantID), "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["clg_code"], "CLG_TEST_MV_5")
+	assert.DeepEqual(t, dataOutput["category_ids"], []interface{}{categoryIDs["CLG_TEST_MV_5"]})
+}
@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestProductRelation(t *testing.T) {
+	t.Run("Set bundle components", setBundleComponents)
+
+	t.Run("Set invalid relations", setInvalidRelations)
+
+	t.Run("Set bundle relations forming a cycle", setBundleRelationsFormingCycle)
+
+	t.Run("Set accessory and replacement relations", setAccessoryAndReplacementRelations)
+
+	t.Run("Get inbound relations", getInboundRelations)
+
+	t.Run("Delete related product", deleteRelatedProduct)
+}
+
+func setBundleComponents(t *testing.T) {
+	// Kit P-0001 contains 2 each of P-0002 and a pack of P-0003
+	dataInput := []interface{}{
+		map[string]interface{}{"type": "B", "related_id": 2, "qty": "2", "uom_code": "EACH"},
+		map[string]interface{}{"type": "b", "related_id": 3, "qty": "1", "uom_code": "pack"},
+	}
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/products/1/relations", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Product Relations have been set.")
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 2)
+
+	dataRelOutput := dataOutput[1].(map[string]interface{})
+	assert.Equal(t, dataRelOutput["prod_id"], float64(1))
+	assert.Equal(t, dataRelOutput["type"], "B")
+	assert.Equal(t, dataRelOutput["related_id"], float64(3))
+	assert.Equal(t, dataRelOutput["qty"], "1")
+	assert.Equal(t, dataRelOutput["uom_code"], "PACK")
+	assert.Equal(t, dataRelOutput["created_by"], "TESTUSER")
+}
+
+func setInvalidRelations(t *testing.T) {
+	dataInput := []interface{}{
+		map[string]interface{}{"type": "A", "related_id": 2},
+		map[string]interface{}{"type": "B", "related_id": 3, "qty": "0", "uom_code": "EACH"},
+		map[string]interface{}{"type": "A", "related_id": 2},
+		map[string]interface{}{"type": "S", "related_id": 1},
+		map[string]interface{}{"type": "R", "related_id": 999},
+		map[string]interface{}{"type": "B", "related_id": 3, "qty": "1", "uom_code": "BOX"},
+	}
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/products/1/relations", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 5)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/1/qty")
+	assert.Equal(t, dataViolations[1].(map[string]interface{})["path"], "/2/related_id")
+	assert.Equal(t, dataViolations[1].(map[string]interface{})["message"], "Relation to product '2' is duplicated.")
+	assert.Equal(t, dataViolations[2].(map[string]interface{})["message"], "Product can not be related to itself.")
+	assert.Equal(t, dataViolations[3].(map[string]interface{})["message"], "Product '999' does not exist.")
+	assert.Equal(t, dataViolations[4].(map[string]interface{})["path"], "/5/uom_code")
+	assert.Equal(t, dataViolations[4].(map[string]interface{})["message"], "Unit of Measure 'BOX' is not a unit of product 'P-0003'.")
+}
+
+func setBundleRelationsFormingCycle(t *testing.T) {
+	dataInput := []interface{}{
+		map[string]interface{}{"type": "B", "related_id": 1, "qty": "1", "uom_code": "EACH"},
+	}
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/products/3/relations", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 1)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/0/related_id")
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Product 'P-0001' can not be a component, it contains product 'P-0003'.")
+}
+
+func setAccessoryAndReplacementRelations(t *testing.T) {
+	dataInput := []interface{}{
+		map[string]interface{}{"type": "A", "related_id": 3, "qty": "5", "uom_code": "EACH"},
+		map[string]interface{}{"type": "R", "related_id": 1},
+	}
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/products/2/relations", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 2)
+
+	// Quantity and unit of measure only apply to bundle components
+	dataRelOutput := dataOutput[0].(map[string]interface{})
+	assert.Equal(t, dataRelOutput["type"], "A")
+	assert.Equal(t, dataRelOutput["qty"], "0")
+	assert.Equal(t, dataRelOutput["uom_code"], "")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/2/relations?type=R", "application/json", nil, http.StatusOK)
+
+	dataOutput = respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 1)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["related_id"], float64(1))
+}
+
+func getInboundRelations(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/3/relations?direction=inbound", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 2)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["prod_id"], float64(2))
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["type"], "A")
+	assert.Equal(t, dataOutput[1].(map[string]interface{})["prod_id"], float64(1))
+	assert.Equal(t, dataOutput[1].(map[string]interface{})["type"], "B")
+}
+
+func deleteRelatedProduct(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"clg_code":    "CLG_TEST_1",
+		"code":        "P-0099",
+		"description": "Pencil",
+		"status":      "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": 1, "alpha_value": "Pencil"},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	pencilID := respData["data"].(map[string]interface{})["id"].(float64)
+
+	relInput := []interface{}{
+		map[string]interface{}{"type": "A", "related_id": 3},
+		map[string]interface{}{"type": "S", "related_id": pencilID},
+	}
+
+	sendTypedRequest(t, "PUT", "http://localhost:50051/v1/products/2/relations", "application/json", mustMarshal(t, relInput), http.StatusAccepted)
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", pencilID)
+	sendTypedRequest(t, "DELETE", url, "application/json", nil, http.StatusOK)
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/2/relations", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 1)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["related_id"], float64(3))
+}
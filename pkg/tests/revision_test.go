@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var (
+	revisionProdID float64
+	revisionRoute  = "http://localhost:50051/v1/catalogues/CLG_TEST_REV"
+)
+
+func TestRevision(t *testing.T) {
+	t.Run("Create revision catalogue", createRevisionCatalogue)
+
+	t.Run("Publish catalogue", publishCatalogue)
+
+	t.Run("Edit draft after publishing", editDraftAfterPublishing)
+
+	t.Run("Publish catalogue with duplicate label", publishCatalogueWithDuplicateLabel)
+
+	t.Run("Publish catalogue again", publishCatalogueAgain)
+
+	t.Run("Get revisions", getRevisions)
+
+	t.Run("Get revision diff", getRevisionDiff)
+}
+
+func createRevisionCatalogue(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_REV",
+		"description": "Catalogue Test Revision",
+		"status":      "A",
+		"vers":        1,
+	}
+
+	sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, newRevisionProduct("R-0001", "Stapler")), http.StatusAccepted)
+	revisionProdID = respData["data"].(map[string]interface{})["id"].(float64)
+}
+
+func publishCatalogue(t *testing.T) {
+	dataInput := map[string]interface{}{"label": "2020.1"}
+
+	respData := sendTypedRequest(t, "POST", revisionRoute+"/publish", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Catalogue has been published.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["revision"], float64(1))
+	assert.Equal(t, dataOutput["label"], "2020.1")
+	assert.Equal(t, dataOutput["nbr_products"], float64(1))
+	assert.Equal(t, dataOutput["snapshot"], nil)
+
+	respData = sendTypedRequest(t, "GET", revisionRoute+"/revisions/latest", "application/json", nil, http.StatusOK)
+
+	dataSnapshot := respData["data"].(map[string]interface{})["snapshot"].(map[string]interface{})
+	assert.Equal(t, dataSnapshot["catalogue"].(map[string]interface{})["code"], "CLG_TEST_REV")
+	assert.Equal(t, len(dataSnapshot["products"].([]interface{})), 1)
+}
+
+func editDraftAfterPublishing(t *testing.T) {
+	dataInput := map[string]interface{}{"description": "Heavy Stapler"}
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", revisionProdID)
+	sendTypedRequest(t, "PATCH", url, "application/merge-patch+json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, newRevisionProduct("R-0002", "Punch")), http.StatusAccepted)
+
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_REV", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 1)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["description"], "Stapler")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_REV?revision=draft", "application/json", nil, http.StatusOK)
+
+	dataOutput = respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 2)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["description"], "Heavy Stapler")
+
+	respData = sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["description"], "Stapler")
+
+	respData = sendTypedRequest(t, "GET", url+"?revision=draft", "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["description"], "Heavy Stapler")
+
+	respData = sendTypedRequest(t, "GET", revisionRoute+"/products/r-0001", "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["description"], "Stapler")
+
+	sendTypedRequest(t, "GET", revisionRoute+"/products/R-0002", "application/json", nil, http.StatusNotFound)
+}
+
+func publishCatalogueWithDuplicateLabel(t *testing.T) {
+	dataInput := map[string]interface{}{"label": "2020.1"}
+
+	respData := sendTypedRequest(t, "POST", revisionRoute+"/publish", "application/json", mustMarshal(t, dataInput), http.StatusConflict)
+	assert.Equal(t, respData["code"], "duplicate")
+	assert.Equal(t, respData["message"], "Catalogue Revision '2020.1' already exists.")
+}
+
+func publishCatalogueAgain(t *testing.T) {
+	respData := sendTypedRequest(t, "POST", revisionRoute+"/publish", "application/json", nil, http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["revision"], float64(2))
+	assert.Equal(t, dataOutput["label"], "v2")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_REV", "application/json", nil, http.StatusOK)
+	assert.Equal(t, len(respData["data"].([]interface{})), 2)
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_REV?revision=1", "application/json", nil, http.StatusOK)
+	assert.Equal(t, len(respData["data"].([]interface{})), 1)
+}
+
+func getRevisions(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", revisionRoute+"/revisions", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 2)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["label"], "v2")
+	assert.Equal(t, dataOutput[1].(map[string]interface{})["label"], "2020.1")
+
+	sendTypedRequest(t, "GET", revisionRoute+"/revisions/3", "application/json", nil, http.StatusNotFound)
+}
+
+func getRevisionDiff(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", revisionRoute+"/revisions/2/diff", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["from"], float64(1))
+	assert.Equal(t, dataOutput["to"], float64(2))
+	assert.DeepEqual(t, dataOutput["catalogue_changes"], []interface{}{})
+	assert.DeepEqual(t, dataOutput["added_products"], []interface{}{"R-0002"})
+	assert.DeepEqual(t, dataOutput["removed_products"], []interface{}{})
+
+	dataChanged := dataOutput["changed_products"].([]interface{})
+	assert.Equal(t, len(dataChanged), 1)
+	assert.Equal(t, dataChanged[0].(map[string]interface{})["code"], "R-0001")
+	assert.DeepEqual(t, dataChanged[0].(map[string]interface{})["attributes"], []interface{}{"description"})
+
+	respData = sendTypedRequest(t, "GET", revisionRoute+"/revisions/2/diff?from=0", "application/json", nil, http.StatusOK)
+
+	dataOutput = respData["data"].(map[string]interface{})
+	assert.DeepEqual(t, dataOutput["added_products"], []interface{}{"R-0001", "R-0002"})
+}
+
+func newRevisionProduct(code string, description string) map[string]interface{} {
+	return map[string]interface{}{
+		"clg_code":    "CLG_TEST_REV",
+		"code":        code,
+		"description": description,
+		"status":      "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+	}
+}
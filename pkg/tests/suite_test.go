@@ -61,9 +61,23 @@ func setupDatabase(ctx context.Context) {
 	_, err = tx.Exec("TRUNCATE TABLE users")
 	_, err = tx.Exec("TRUNCATE TABLE catalogues")
 	_, err = tx.Exec("TRUNCATE TABLE custom_field_definitions")
+	_, err = tx.Exec("TRUNCATE TABLE custom_field_options")
+	_, err = tx.Exec("TRUNCATE TABLE custom_field_groups")
 	_, err = tx.Exec("TRUNCATE TABLE products")
 	_, err = tx.Exec("TRUNCATE TABLE product_uoms")
 	_, err = tx.Exec("TRUNCATE TABLE product_custom_fields")
+	_, err = tx.Exec("TRUNCATE TABLE import_jobs")
+	_, err = tx.Exec("TRUNCATE TABLE import_job_errors")
+	_, err = tx.Exec("TRUNCATE TABLE backfill_jobs")
+	_, err = tx.Exec("TRUNCATE TABLE uom_masters")
+	_, err = tx.Exec("TRUNCATE TABLE price_lists")
+	_, err = tx.Exec("TRUNCATE TABLE product_prices")
+	_, err = tx.Exec("TRUNCATE TABLE product_relations")
+	_, err = tx.Exec("TRUNCATE TABLE categories")
+	_, err = tx.Exec("TRUNCATE TABLE product_categories")
+	_, err = tx.Exec("TRUNCATE TABLE catalogue_revisions")
+	_, err = tx.Exec("TRUNCATE TABLE catalogue_revision_products")
+	_, err = tx.Exec("TRUNCATE TABLE product_change_requests")
 
 	// Seed users
 	_, err = tx.Exec(`INSERT INTO users (username, name, email, password, status, created_by, created_at, modified_by, modified_at, vers) VALUES 
@@ -87,6 +101,23 @@ func setupDatabase(ctx context.Context) {
 					('CLG_TEST_2', 'Field-2', 'N', false, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
 					('CLG_TEST_2', 'Field-3', 'D', false, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1)`)
 
+	// Restart custom field groups sequence
+	_, err = tx.Exec(`ALTER SEQUENCE custom_field_groups_id_seq RESTART WITH 1`)
+
+	// Seed units of measure master
+	_, err = tx.Exec(`INSERT INTO uom_masters (code, descr, dimension, factor, created_by, created_at, modified_by, modified_at, vers) VALUES
+					('EACH', 'Each', 'C', 1, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('DOZEN', 'Dozen', 'C', 12, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('BOX', 'Box', 'C', 0, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('PACK', 'Pack', 'C', 0, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('ITEM', 'Item', 'C', 0, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('KG', 'Kilogram', 'M', 1, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('G', 'Gram', 'M', 0.001, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('M', 'Metre', 'L', 1, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('CM', 'Centimetre', 'L', 0.01, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('L', 'Litre', 'V', 1, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1),
+					('ML', 'Millilitre', 'V', 0.001, 'TESTUSER', CURRENT_TIMESTAMP, 'TESTUSER', CURRENT_TIMESTAMP, 1)`)
+
 	// Restart products sequence
 	_, err = tx.Exec(`ALTER SEQUENCE products_id_seq RESTART WITH 1`)
 
@@ -123,6 +154,27 @@ func setupDatabase(ctx context.Context) {
 					(3, 2, DEFAULT, 30.5, DEFAULT),
 					(3, 3, DEFAULT, DEFAULT, '2020-03-01')`)
 
+	// Restart import jobs sequence
+	_, err = tx.Exec(`ALTER SEQUENCE import_jobs_id_seq RESTART WITH 1`)
+
+	// Restart product prices sequence
+	_, err = tx.Exec(`ALTER SEQUENCE product_prices_id_seq RESTART WITH 1`)
+
+	// Restart product relations sequence
+	_, err = tx.Exec(`ALTER SEQUENCE product_relations_id_seq RESTART WITH 1`)
+
+	// Restart categories sequence
+	_, err = tx.Exec(`ALTER SEQUENCE categories_id_seq RESTART WITH 1`)
+
+	// Restart catalogue revisions sequence
+	_, err = tx.Exec(`ALTER SEQUENCE catalogue_revisions_id_seq RESTART WITH 1`)
+
+	// Restart product change requests sequence
+	_, err = tx.Exec(`ALTER SEQUENCE product_change_requests_id_seq RESTART WITH 1`)
+
+	// Restart backfill jobs sequence
+	_, err = tx.Exec(`ALTER SEQUENCE backfill_jobs_id_seq RESTART WITH 1`)
+
 	if err != nil {
 		tx.Rollback()
 	}
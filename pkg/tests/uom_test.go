@@ -0,0 +1,206 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var riceProdID int64
+
+func TestUom(t *testing.T) {
+	t.Run("Get all units of measure", getAllUoms)
+
+	t.Run("Create unit of measure", createUom)
+
+	t.Run("Create duplicate unit of measure", createDuplicateUom)
+
+	t.Run("Update unit of measure", updateUom)
+
+	t.Run("Update unit of measure dimension", updateUomDimension)
+
+	t.Run("Delete unit of measure used by products", deleteUsedUom)
+
+	t.Run("Delete unit of measure", deleteUom)
+
+	t.Run("Create product with unknown unit of measure", createProductWithUnknownUom)
+
+	t.Run("Create product with inconsistent unit of measure ratio", createProductWithInconsistentUomRatio)
+
+	t.Run("Create product with standard unit of measure", createProductWithStandardUom)
+
+	t.Run("Convert product quantity by product ratios", convertByProductRatios)
+
+	t.Run("Convert product quantity by standard conversion", convertByStandardConversion)
+
+	t.Run("Convert product quantity between unrelated units", convertBetweenUnrelatedUoms)
+}
+
+func getAllUoms(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/uoms", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 11)
+
+	dataUomOutput := dataOutput[0].(map[string]interface{})
+	assert.Equal(t, dataUomOutput["code"], "BOX")
+	assert.Equal(t, dataUomOutput["dimension"], "C")
+	assert.Equal(t, dataUomOutput["factor"], float64(0))
+}
+
+func createUom(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "T",
+		"description": "Tonne",
+		"dimension":   "M",
+		"factor":      1000,
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/uoms", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Unit of Measure has been created.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["code"], "T")
+	assert.Equal(t, dataOutput["factor"], float64(1000))
+	assert.Equal(t, dataOutput["created_by"], "TESTUSER")
+	assert.Equal(t, dataOutput["vers"], float64(1))
+}
+
+func createDuplicateUom(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "kg",
+		"description": "Kilogram",
+		"dimension":   "M",
+		"factor":      1,
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/uoms", "application/json", mustMarshal(t, dataInput), http.StatusConflict)
+	assert.Equal(t, respData["code"], "duplicate")
+	assert.Equal(t, respData["message"], "Unit of Measure 'KG' already exists.")
+}
+
+func updateUom(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"description": "Metric Tonne",
+		"dimension":   "M",
+		"factor":      1000,
+		"vers":        1,
+	}
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/uoms/T", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Unit of Measure has been updated.")
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["description"], "Metric Tonne")
+	assert.Equal(t, dataOutput["vers"], float64(2))
+}
+
+func updateUomDimension(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"description": "Metric Tonne",
+		"dimension":   "V",
+		"factor":      1000,
+		"vers":        2,
+	}
+
+	respData := sendTypedRequest(t, "PUT", "http://localhost:50051/v1/uoms/T", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+	assert.Equal(t, respData["message"], "Dimension of Unit of Measure 'T' can not be changed.")
+}
+
+func deleteUsedUom(t *testing.T) {
+	respData := sendTypedRequest(t, "DELETE", "http://localhost:50051/v1/uoms/BOX", "application/json", nil, http.StatusConflict)
+	assert.Equal(t, respData["message"], "Unit of Measure 'BOX' is used by products.")
+}
+
+func deleteUom(t *testing.T) {
+	respData := sendTypedRequest(t, "DELETE", "http://localhost:50051/v1/uoms/T", "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["message"], "Unit of Measure has been deleted.")
+}
+
+func createProductWithUnknownUom(t *testing.T) {
+	dataInput := newRiceProduct([]interface{}{
+		map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		map[string]interface{}{"code": "SACK", "description": "Sack", "ratio": 10, "change_mode": 1},
+	})
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusUnprocessableEntity)
+	assert.Equal(t, respData["code"], "reference_violation")
+	assert.Equal(t, respData["message"], "Unit of Measure 'SACK' is unknown.")
+}
+
+func createProductWithInconsistentUomRatio(t *testing.T) {
+	dataInput := newRiceProduct([]interface{}{
+		map[string]interface{}{"code": "KG", "description": "Kilogram", "ratio": 1, "change_mode": 1},
+		map[string]interface{}{"code": "G", "description": "Gram", "ratio": 0.5, "change_mode": 1},
+	})
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusUnprocessableEntity)
+	assert.Equal(t, respData["message"], "Unit of Measure 'G' ratio does not match its standard conversion to 'KG'.")
+}
+
+func createProductWithStandardUom(t *testing.T) {
+	// A bag of rice weighs 2 kilograms
+	dataInput := newRiceProduct([]interface{}{
+		map[string]interface{}{"code": "EACH", "description": "Bag", "ratio": 1, "change_mode": 1},
+		map[string]interface{}{"code": "KG", "description": "Kilogram", "ratio": 0.5, "change_mode": 1},
+		map[string]interface{}{"code": "BOX", "description": "Box of 10 bags", "ratio": 10, "change_mode": 1},
+	})
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, len(dataOutput["uoms"].([]interface{})), 3)
+
+	riceProdID = int64(dataOutput["id"].(float64))
+}
+
+func convertByProductRatios(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%d/convert?qty=3&from=box&to=each", riceProdID)
+	respData := sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["from"], "BOX")
+	assert.Equal(t, dataOutput["to"], "EACH")
+	assert.Equal(t, dataOutput["qty"], float64(3))
+	assert.Equal(t, dataOutput["result"], float64(30))
+}
+
+func convertByStandardConversion(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%d/convert?qty=3&from=EACH&to=G", riceProdID)
+	respData := sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["result"], float64(6000))
+
+	url = fmt.Sprintf("http://localhost:50051/v1/products/%d/convert?qty=2500&from=G&to=KG", riceProdID)
+	respData = sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+
+	dataOutput = respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["result"], float64(2.5))
+}
+
+func convertBetweenUnrelatedUoms(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%d/convert?qty=1&from=EACH&to=L", riceProdID)
+	respData := sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusUnprocessableEntity)
+	assert.Equal(t, respData["code"], "uom_not_convertible")
+	assert.Equal(t, respData["message"], "Unit of Measure 'EACH' can not be converted to 'L'.")
+}
+
+func newRiceProduct(uoms []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"clg_code":    "CLG_TEST_2",
+		"code":        "R-0001",
+		"description": "Rice",
+		"details":     "Rice",
+		"status":      "A",
+		"uoms":        uoms,
+		"custom_fields": []interface{}{
+			map[string]interface{}{
+				"field_id":    4,
+				"alpha_value": "Field-Rice",
+			},
+		},
+	}
+}
@@ -0,0 +1,278 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var variantFieldIDs = make(map[string]float64)
+
+var variantParentID float64
+
+var variantIDs = make(map[string]float64)
+
+func TestVariant(t *testing.T) {
+	t.Run("Create catalogue with variant axes", createCatalogueWithVariantAxes)
+
+	t.Run("Create catalogue with multi-select variant axis", createCatalogueWithMultiSelectVariantAxis)
+
+	t.Run("Create parent product", createParentProduct)
+
+	t.Run("Create variant without variant axis value", createVariantWithoutAxisValue)
+
+	t.Run("Generate variants", generateVariants)
+
+	t.Run("Generate variants again", generateVariantsAgain)
+
+	t.Run("Create duplicate variant", createDuplicateVariant)
+
+	t.Run("Get variant inheriting from parent", getVariantInheritingFromParent)
+
+	t.Run("Update variant overriding parent", updateVariantOverridingParent)
+
+	t.Run("Get products grouped by parent", getProductsGroupedByParent)
+
+	t.Run("Delete parent product with variants", deleteParentWithVariants)
+}
+
+func createCatalogueWithVariantAxes(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_VARIANT",
+		"description": "Catalogue Test Variant",
+		"details":     "Catalogue Test Variant",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{
+				"caption":      "Size",
+				"type":         "P",
+				"variant_axis": true,
+				"change_mode":  1,
+				"options": []interface{}{
+					map[string]interface{}{"code": "S", "label": "Small", "sort_order": 1},
+					map[string]interface{}{"code": "M", "label": "Medium", "sort_order": 2},
+				},
+			},
+			map[string]interface{}{"caption": "Colour", "type": "A", "variant_axis": true, "change_mode": 1},
+			map[string]interface{}{"caption": "Material", "type": "A", "change_mode": 1},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataFieldDefs := respData["data"].(map[string]interface{})["field_definitions"].([]interface{})
+	assert.Equal(t, len(dataFieldDefs), 3)
+
+	for _, item := range dataFieldDefs {
+		dataFieldDefOutput := item.(map[string]interface{})
+		variantFieldIDs[dataFieldDefOutput["caption"].(string)] = dataFieldDefOutput["id"].(float64)
+
+		assert.Equal(t, dataFieldDefOutput["variant_axis"], dataFieldDefOutput["caption"] != "Material")
+	}
+}
+
+func createCatalogueWithMultiSelectVariantAxis(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"code":        "CLG_TEST_VARIANT_BAD",
+		"description": "Catalogue Test Variant Bad",
+		"status":      "A",
+		"vers":        1,
+		"field_definitions": []interface{}{
+			map[string]interface{}{
+				"caption":      "Features",
+				"type":         "M",
+				"variant_axis": true,
+				"change_mode":  1,
+				"options": []interface{}{
+					map[string]interface{}{"code": "POCKET", "label": "Pocket", "sort_order": 1},
+				},
+			},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/catalogues", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/field_definitions/0/variant_axis")
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Custom Field 'Features' holding multiple options can not be a variant axis.")
+}
+
+func createParentProduct(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"clg_code":    "CLG_TEST_VARIANT",
+		"code":        "TSHIRT",
+		"description": "T-Shirt",
+		"details":     "T-Shirt",
+		"status":      "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": variantFieldIDs["Material"], "alpha_value": "COTTON"},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["parent_id"], float64(0))
+
+	variantParentID = dataOutput["id"].(float64)
+}
+
+func createVariantWithoutAxisValue(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"clg_code":  "CLG_TEST_VARIANT",
+		"parent_id": variantParentID,
+		"code":      "TSHIRT-S",
+		"status":    "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": variantFieldIDs["Size"], "alpha_value": "S"},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusBadRequest)
+
+	dataViolations := respData["errors"].([]interface{})
+	assert.Equal(t, len(dataViolations), 1)
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["path"], "/custom_fields")
+	assert.Equal(t, dataViolations[0].(map[string]interface{})["message"], "Variant must specify Custom Field 'Colour'.")
+}
+
+func generateVariants(t *testing.T) {
+	dataInput := []interface{}{
+		map[string]interface{}{"field_id": variantFieldIDs["Colour"], "values": []string{"RED", "BLUE"}},
+	}
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v/variants", variantParentID)
+	respData := sendTypedRequest(t, "POST", url, "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+	assert.Equal(t, respData["message"], "Variants have been generated.")
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 4)
+
+	for _, item := range dataOutput {
+		dataVariantOutput := item.(map[string]interface{})
+		variantIDs[dataVariantOutput["code"].(string)] = dataVariantOutput["id"].(float64)
+
+		assert.Equal(t, dataVariantOutput["parent_id"], variantParentID)
+		assert.Equal(t, dataVariantOutput["description"], "T-Shirt")
+		assert.Equal(t, len(dataVariantOutput["uoms"].([]interface{})), 1)
+	}
+
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["code"], "TSHIRT-S-RED")
+	assert.Equal(t, dataOutput[3].(map[string]interface{})["code"], "TSHIRT-M-BLUE")
+}
+
+func generateVariantsAgain(t *testing.T) {
+	dataInput := []interface{}{
+		map[string]interface{}{"field_id": variantFieldIDs["Colour"], "values": []string{"RED", "GREEN"}},
+	}
+
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v/variants", variantParentID)
+	respData := sendTypedRequest(t, "POST", url, "application/json", mustMarshal(t, dataInput), http.StatusAccepted)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 2)
+	assert.Equal(t, dataOutput[0].(map[string]interface{})["code"], "TSHIRT-S-GREEN")
+	assert.Equal(t, dataOutput[1].(map[string]interface{})["code"], "TSHIRT-M-GREEN")
+}
+
+func createDuplicateVariant(t *testing.T) {
+	dataInput := map[string]interface{}{
+		"clg_code":  "CLG_TEST_VARIANT",
+		"parent_id": variantParentID,
+		"code":      "TSHIRT-SMALL-RED",
+		"status":    "A",
+		"uoms": []interface{}{
+			map[string]interface{}{"code": "EACH", "description": "Each", "ratio": 1, "change_mode": 1},
+		},
+		"custom_fields": []interface{}{
+			map[string]interface{}{"field_id": variantFieldIDs["Size"], "alpha_value": "S"},
+			map[string]interface{}{"field_id": variantFieldIDs["Colour"], "alpha_value": "RED"},
+		},
+	}
+
+	respData := sendTypedRequest(t, "POST", "http://localhost:50051/v1/products", "application/json", mustMarshal(t, dataInput), http.StatusConflict)
+	assert.Equal(t, respData["code"], "duplicate")
+	assert.Equal(t, respData["message"], "Product 'TSHIRT' already has variant 'TSHIRT-S-RED' of the same variant axis values.")
+}
+
+func getVariantInheritingFromParent(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", variantIDs["TSHIRT-S-RED"])
+	respData := sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["description"], "T-Shirt")
+
+	dataFields := dataOutput["custom_fields"].([]interface{})
+	assert.Equal(t, len(dataFields), 3)
+
+	for _, item := range dataFields {
+		dataFieldOutput := item.(map[string]interface{})
+		if dataFieldOutput["field_id"] == variantFieldIDs["Material"] {
+			assert.Equal(t, dataFieldOutput["alpha_value"], "COTTON")
+			assert.Equal(t, dataFieldOutput["inherited"], true)
+		} else {
+			assert.Equal(t, dataFieldOutput["inherited"], false)
+		}
+	}
+}
+
+func updateVariantOverridingParent(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", variantIDs["TSHIRT-S-RED"])
+	patch := []interface{}{
+		map[string]interface{}{"op": "replace", "path": "/description", "value": "T-Shirt Small Red"},
+	}
+
+	respData := sendTypedRequest(t, "PATCH", url, "application/json-patch+json", mustMarshal(t, patch), http.StatusAccepted)
+
+	dataOutput := respData["data"].(map[string]interface{})
+	assert.Equal(t, dataOutput["description"], "T-Shirt Small Red")
+	assert.Equal(t, len(dataOutput["custom_fields"].([]interface{})), 3)
+
+	// Changes of parent are followed by values variants do not override
+	url = fmt.Sprintf("http://localhost:50051/v1/products/%v", variantParentID)
+	patch = []interface{}{
+		map[string]interface{}{"op": "replace", "path": "/description", "value": "Basic T-Shirt"},
+	}
+
+	sendTypedRequest(t, "PATCH", url, "application/json-patch+json", mustMarshal(t, patch), http.StatusAccepted)
+
+	url = fmt.Sprintf("http://localhost:50051/v1/products/%v", variantIDs["TSHIRT-M-RED"])
+	respData = sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["description"], "Basic T-Shirt")
+
+	url = fmt.Sprintf("http://localhost:50051/v1/products/%v", variantIDs["TSHIRT-S-RED"])
+	respData = sendTypedRequest(t, "GET", url, "application/json", nil, http.StatusOK)
+	assert.Equal(t, respData["data"].(map[string]interface{})["description"], "T-Shirt Small Red")
+}
+
+func getProductsGroupedByParent(t *testing.T) {
+	respData := sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_VARIANT?group=parent", "application/json", nil, http.StatusOK)
+
+	dataOutput := respData["data"].([]interface{})
+	assert.Equal(t, len(dataOutput), 1)
+
+	dataParentOutput := dataOutput[0].(map[string]interface{})
+	assert.Equal(t, dataParentOutput["code"], "TSHIRT")
+
+	dataVariants := dataParentOutput["variants"].([]interface{})
+	assert.Equal(t, len(dataVariants), 6)
+	assert.Equal(t, dataVariants[1].(map[string]interface{})["description"], "Basic T-Shirt")
+
+	respData = sendTypedRequest(t, "GET", "http://localhost:50051/v1/products/bycatalogue/CLG_TEST_VARIANT", "application/json", nil, http.StatusOK)
+	assert.Equal(t, len(respData["data"].([]interface{})), 7)
+}
+
+func deleteParentWithVariants(t *testing.T) {
+	url := fmt.Sprintf("http://localhost:50051/v1/products/%v", variantParentID)
+	respData := sendTypedRequest(t, "DELETE", url, "application/json", nil, http.StatusConflict)
+	assert.Equal(t, respData["message"], "Product has variants, its variants must be deleted first.")
+}